@@ -0,0 +1,9 @@
+data "hcp_auth_check" "preflight" {
+}
+
+check "hcp_credentials" {
+  assert {
+    condition     = data.hcp_auth_check.preflight.status == "ok"
+    error_message = "HCP preflight check failed (${data.hcp_auth_check.preflight.status}): ${data.hcp_auth_check.preflight.error}"
+  }
+}
@@ -0,0 +1,19 @@
+data "hcp_available_cidr" "hvn" {
+  existing_cidrs = var.existing_cidrs
+  prefix_length  = 20
+}
+
+resource "hcp_hvn" "example" {
+  hvn_id         = "main-hvn"
+  cloud_provider = "aws"
+  region         = "us-west-2"
+  cidr_block     = data.hcp_available_cidr.hvn.cidr_block
+}
+
+# Alternatively, pick a route destination that doesn't overlap an existing HVN
+# or its routes.
+data "hcp_available_cidr" "route" {
+  hvn_link      = var.hvn_link
+  within        = ["10.0.0.0/8"]
+  prefix_length = 16
+}
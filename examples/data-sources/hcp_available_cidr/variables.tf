@@ -0,0 +1,9 @@
+variable "existing_cidrs" {
+  description = "The CIDR blocks already in use, e.g. by the VPCs the HVN will be peered to."
+  type        = list(string)
+}
+
+variable "hvn_link" {
+  description = "The `self_link` of the HashiCorp Virtual Network (HVN)."
+  type        = string
+}
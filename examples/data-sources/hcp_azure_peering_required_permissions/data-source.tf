@@ -0,0 +1,14 @@
+data "hcp_azure_peering_required_permissions" "required" {}
+
+resource "azurerm_role_definition" "definition" {
+  name  = "hcp-hvn-peering-access"
+  scope = azurerm_virtual_network.vnet.id
+
+  assignable_scopes = [
+    azurerm_virtual_network.vnet.id
+  ]
+
+  permissions {
+    actions = data.hcp_azure_peering_required_permissions.required.actions
+  }
+}
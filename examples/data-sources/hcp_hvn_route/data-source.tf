@@ -1,4 +1,10 @@
 data "hcp_hvn_route" "example" {
+  hvn_link     = var.hvn_link
+  hvn_route_id = var.hvn_route_id
+}
+
+# Alternatively, look the HVN route up by its destination CIDR.
+data "hcp_hvn_route" "by_destination" {
   hvn_link         = var.hvn_link
-  destination_cidr = var.hvn_route_id
+  destination_cidr = var.destination_cidr
 }
@@ -7,3 +7,8 @@ variable "hvn_route_id" {
   description = "The ID of the HVN route ID."
   type        = string
 }
+
+variable "destination_cidr" {
+  description = "The destination CIDR of the HVN route."
+  type        = string
+}
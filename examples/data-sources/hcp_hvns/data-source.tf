@@ -0,0 +1,7 @@
+data "hcp_hvns" "aws" {
+  cloud_provider = "aws"
+}
+
+output "aws_hvn_cidr_blocks" {
+  value = { for hvn in data.hcp_hvns.aws.hvns : hvn.hvn_id => hvn.cidr_block }
+}
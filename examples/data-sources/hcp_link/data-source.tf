@@ -0,0 +1,3 @@
+data "hcp_link" "example" {
+  self_link = var.hvn_link
+}
@@ -0,0 +1,4 @@
+data "hcp_peering_events" "example" {
+  hvn_id     = var.hvn_id
+  peering_id = var.peering_id
+}
@@ -0,0 +1,9 @@
+variable "hvn_id" {
+  description = "The ID of the HashiCorp Virtual Network (HVN)."
+  type        = string
+}
+
+variable "peering_id" {
+  description = "The ID of the peering connection."
+  type        = string
+}
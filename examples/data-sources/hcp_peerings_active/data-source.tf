@@ -0,0 +1,12 @@
+data "hcp_peerings_active" "peerings" {
+  peerings = [
+    {
+      hvn_link   = hcp_hvn.hvn.self_link
+      peering_id = hcp_azure_peering_connection.east.peering_id
+    },
+    {
+      hvn_link   = hcp_hvn.hvn.self_link
+      peering_id = hcp_azure_peering_connection.west.peering_id
+    },
+  ]
+}
@@ -0,0 +1,6 @@
+data "hcp_provider_config" "current" {
+}
+
+output "hcp_project_id" {
+  value = data.hcp_provider_config.current.project_id
+}
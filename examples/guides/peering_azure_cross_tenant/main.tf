@@ -0,0 +1,104 @@
+provider "hcp" {}
+
+// The default Azure providers manage resources in your usual tenant.
+provider "azurerm" {
+  features {}
+}
+
+provider "azuread" {}
+
+// These providers manage the peer VNet, which is in another tenant. The
+// principal they authenticate as must be able to sign in to that tenant.
+provider "azurerm" {
+  alias           = "peer"
+  tenant_id       = "<peer tenant UUID>"
+  subscription_id = "<peer subscription UUID>"
+  features {}
+}
+
+provider "azuread" {
+  alias     = "peer"
+  tenant_id = "<peer tenant UUID>"
+}
+
+resource "hcp_hvn" "hvn" {
+  hvn_id         = "main-hvn"
+  cloud_provider = "azure"
+  region         = "westus2"
+  cidr_block     = "172.25.16.0/20"
+}
+
+resource "azurerm_resource_group" "rg" {
+  provider = azurerm.peer
+
+  name     = "resource-group-test"
+  location = "West US"
+}
+
+resource "azurerm_virtual_network" "vnet" {
+  provider = azurerm.peer
+
+  name                = "vnet-test"
+  location            = azurerm_resource_group.rg.location
+  resource_group_name = azurerm_resource_group.rg.name
+
+  address_space = [
+    "10.0.0.0/16"
+  ]
+}
+
+// peer_tenant_id is the tenant that owns the peer subscription, rather than
+// the tenant of the default providers.
+resource "hcp_azure_peering_connection" "peer" {
+  hvn_link                 = hcp_hvn.hvn.self_link
+  peering_id               = "cross-tenant"
+  peer_vnet_name           = azurerm_virtual_network.vnet.name
+  peer_subscription_id     = "<peer subscription UUID>"
+  peer_tenant_id           = "<peer tenant UUID>"
+  peer_resource_group_name = azurerm_resource_group.rg.name
+  peer_vnet_region         = azurerm_virtual_network.vnet.location
+}
+
+// The service principal must be created in the peer tenant. The principal
+// of the azuread.peer provider requires the API Permissions described here:
+// https://registry.terraform.io/providers/hashicorp/azuread/latest/docs/resources/service_principal.
+resource "azuread_service_principal" "principal" {
+  provider = azuread.peer
+
+  application_id = hcp_azure_peering_connection.peer.application_id
+}
+
+data "hcp_azure_peering_required_permissions" "required" {}
+
+// The principal of the azurerm.peer provider must have Owner or User Access
+// Administrator permissions over a scope that includes the peer VNet.
+resource "azurerm_role_definition" "definition" {
+  provider = azurerm.peer
+
+  name  = "hcp-hvn-peering-access"
+  scope = azurerm_virtual_network.vnet.id
+
+  assignable_scopes = [
+    azurerm_virtual_network.vnet.id
+  ]
+
+  permissions {
+    actions = data.hcp_azure_peering_required_permissions.required.actions
+  }
+}
+
+resource "azurerm_role_assignment" "assignment" {
+  provider = azurerm.peer
+
+  principal_id       = azuread_service_principal.principal.id
+  scope              = azurerm_virtual_network.vnet.id
+  role_definition_id = azurerm_role_definition.definition.role_definition_resource_id
+}
+
+data "hcp_azure_peering_connection" "peer" {
+  hvn_link              = hcp_hvn.hvn.self_link
+  peering_id            = hcp_azure_peering_connection.peer.peering_id
+  wait_for_active_state = true
+
+  depends_on = [azurerm_role_assignment.assignment]
+}
@@ -133,6 +133,8 @@ resource "azuread_service_principal" "principal" {
   application_id = hcp_azure_peering_connection.peering.application_id
 }
 
+data "hcp_azure_peering_required_permissions" "required" {}
+
 resource "azurerm_role_definition" "definition" {
   name  = "hcp-provider-test-role-def"
   scope = azurerm_virtual_network.vnet.id
@@ -142,11 +144,7 @@ resource "azurerm_role_definition" "definition" {
   ]
 
   permissions {
-    actions = [
-      "Microsoft.Network/virtualNetworks/peer/action",
-      "Microsoft.Network/virtualNetworks/virtualNetworkPeerings/read",
-      "Microsoft.Network/virtualNetworks/virtualNetworkPeerings/write"
-    ]
+    actions = data.hcp_azure_peering_required_permissions.required.actions
   }
 }
 
@@ -4,3 +4,6 @@ terraform import hcp_azure_peering_connection.peer f709ec73-55d4-46d8-897d-816eb
 # Using the provider-default project ID, the import ID is:
 # {hvn_id}:{peering_id}
 terraform import hcp_azure_peering_connection.peer main-hvn:199e7e96-4d5f-4456-91f3-b6cc71f1e561
+# To list the IDs of the Azure peering connections of an HVN in the
+# provider-default project, use the HVN ID as the import ID:
+terraform import hcp_azure_peering_connection.peer main-hvn
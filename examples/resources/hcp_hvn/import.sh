@@ -4,3 +4,6 @@ terraform import hcp_hvn.example f709ec73-55d4-46d8-897d-816ebba28778:main-hvn
 # Using the provider-default project ID, the import ID is:
 # {hvn_id}
 terraform import hcp_hvn.example main-hvn
+# Using the self_link of the HVN, e.g. copied from the HCP Portal, the import ID is:
+# /project/{project_id}/hashicorp.network.hvn/{hvn_id}
+terraform import hcp_hvn.example /project/f709ec73-55d4-46d8-897d-816ebba28778/hashicorp.network.hvn/main-hvn
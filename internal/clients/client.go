@@ -7,7 +7,11 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math/rand"
+	"os"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/hcp-sdk-go/auth"
 	"github.com/hashicorp/hcp-sdk-go/auth/workload"
@@ -66,7 +70,9 @@ import (
 	radar_subscription_service "github.com/hashicorp/hcp-sdk-go/clients/cloud-vault-radar/preview/2023-05-01/client/integration_subscription_service"
 
 	hcpConfig "github.com/hashicorp/hcp-sdk-go/config"
+	"github.com/hashicorp/hcp-sdk-go/config/files"
 	sdk "github.com/hashicorp/hcp-sdk-go/httpclient"
+	"golang.org/x/oauth2"
 )
 
 // Client is an HCP client capable of making requests on behalf of a service principal
@@ -95,6 +101,10 @@ type Client struct {
 	RadarSourceRegistrationService radar_src_registration_service.ClientService
 	RadarConnectionService         radar_connection_service.ClientService
 	RadarSubscriptionService       radar_subscription_service.ClientService
+
+	// hvns caches the HVNs read by GetHvnByIDCached. It is nil in clients that
+	// aren't created by NewClient, which disables caching.
+	hvns *hvnCache
 }
 
 // ClientConfig specifies configuration for the client that interacts with HCP
@@ -103,6 +113,13 @@ type ClientConfig struct {
 	ClientSecret   string
 	CredentialFile string
 
+	// ClientCertificateFile and ClientKeyFile (optional) are the paths to the
+	// PEM encoded certificate and private key that the client presents on the
+	// requests made to the HCP API and auth endpoint, e.g. to a gateway that
+	// requires mutual TLS. Both must be set, or neither.
+	ClientCertificateFile string
+	ClientKeyFile         string
+
 	// WorkloadIdentityTokenFile and WorkloadIdentityResourceName can be set to
 	// indicate that authentication should occur by using workload identity
 	// federation. WorkloadIdentityTokenFile indicates the token and
@@ -116,6 +133,11 @@ type ClientConfig struct {
 	WorkloadIdentityToken        string
 	WorkloadIdentityResourceName string
 
+	// CredentialSource (optional) selects the credentials to authenticate
+	// with, and must be one of CredentialSources. If unset, the first
+	// credentials found are used, in order of precedence.
+	CredentialSource string
+
 	// OrganizationID (optional) is the organization unique identifier to launch resources in.
 	OrganizationID string
 
@@ -125,18 +147,96 @@ type ClientConfig struct {
 	// SourceChannel denotes the client (channel) that originated the HCP cluster request.
 	// this is synonymous to a user-agent.
 	SourceChannel string
+
+	// MaxRetries is the number of times a throttled or failed request is retried.
+	MaxRetries int
+
+	// RequestTimeout (optional) is the maximum duration of a single request attempt.
+	RequestTimeout time.Duration
+
+	// RequestsPerSecond (optional) limits the rate of requests made to HCP. If
+	// unset, requests are not rate limited.
+	RequestsPerSecond float64
+
+	// MaxIdleConns and MaxIdleConnsPerHost (optional) size the pool of idle
+	// connections to HCP that are kept open for reuse, in total and per host.
+	// If unset, the HCP SDK's defaults are used.
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+
+	// UserAgentSuffix (optional) is appended to the provider's product token in
+	// the User-Agent header of the requests made to HCP. It must match
+	// UserAgentSuffixRegexp.
+	UserAgentSuffix string
+
+	// ExtraHeaders (optional) are set on every request made to HCP. Their
+	// values may be secrets, so they must not be logged.
+	ExtraHeaders map[string]string
+
+	// PollInterval is the interval at which wait loops poll HCP for state
+	// changes. It is read from the HCP_POLL_INTERVAL environment variable when
+	// the client is created, and defaults to DefaultPollInterval.
+	PollInterval time.Duration
+
+	// PollJitter is the fraction of the poll interval by which wait loops
+	// randomize it, in either direction, so that the resources that wait at
+	// the same time don't poll HCP in lockstep. It defaults to
+	// DefaultPollJitter, and no jitter is applied if it is 0.
+	PollJitter float64
+
+	// SkipWaits (optional) makes wait loops return immediately instead of
+	// polling HCP until an operation completes or a resource reaches a state.
+	// Waits for a resource's state return its current state instead.
+	SkipWaits bool
+
+	// FailOnUnknownStates (optional) makes waiting for a peering connection
+	// fail when HCP reports a state that the provider doesn't recognize. By
+	// default, such states are waited through, as HCP may introduce new
+	// transient states.
+	FailOnUnknownStates bool
+
+	// APIAddress is the address (<hostname>[:port]) of the HCP API that the
+	// client makes requests to. It is resolved when the client is created,
+	// e.g. from the HCP_API_ADDRESS environment variable.
+	APIAddress string
 }
 
+// UserAgentSuffixRegexp matches the valid values of
+// ClientConfig.UserAgentSuffix: a product token with an optional version, e.g.
+// "my-tool/1.2.3".
+var UserAgentSuffixRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._+-]*(/[A-Za-z0-9][A-Za-z0-9._+-]*)?$`)
+
+// DefaultPollInterval is the interval at which wait loops poll HCP for state
+// changes if HCP_POLL_INTERVAL isn't set.
+const DefaultPollInterval = 5 * time.Second
+
+// DefaultPollJitter is the fraction of the poll interval by which wait loops
+// randomize it, e.g. 0.1 for intervals between 4.5s and 5.5s with the default
+// poll interval.
+const DefaultPollJitter = 0.1
+
 // NewClient creates a new Client that is capable of making HCP requests
 func NewClient(config ClientConfig) (*Client, error) {
+	config.PollInterval = pollIntervalFromEnv()
+	config.PollJitter = DefaultPollJitter
+
+	if config.UserAgentSuffix != "" && !UserAgentSuffixRegexp.MatchString(config.UserAgentSuffix) {
+		return nil, fmt.Errorf("invalid user_agent_suffix %q: must be a product token, such as \"my-tool/1.2.3\"", config.UserAgentSuffix)
+	}
+
+	certificate, err := loadClientCertificate(config, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
 	// Build the HCP Config options
-	opts := []hcpConfig.HCPConfigOption{hcpConfig.FromEnv()}
-	if config.ClientID != "" && config.ClientSecret != "" {
-		opts = append(opts, hcpConfig.WithClientCredentials(config.ClientID, config.ClientSecret))
-	} else if config.CredentialFile != "" {
-		opts = append(opts, hcpConfig.WithCredentialFilePath(config.CredentialFile))
-	} else if cf := loadCredentialFile(config); cf != nil {
-		opts = append(opts, hcpConfig.WithCredentialFile(cf))
+	credOpts, err := credentialOptions(config)
+	if err != nil {
+		return nil, err
+	}
+	opts := append([]hcpConfig.HCPConfigOption{hcpConfig.FromEnv()}, credOpts...)
+	if certificate != nil {
+		opts = append(opts, clientCertificateAuthOption(*certificate))
 	}
 
 	// Create the HCP Config
@@ -145,19 +245,48 @@ func NewClient(config ClientConfig) (*Client, error) {
 		return nil, fmt.Errorf("invalid HCP config: %w", err)
 	}
 
+	config.APIAddress = hcp.APIAddress()
+
 	// Fetch a token to verify that we have valid credentials
 	if _, err := hcp.Token(); err != nil {
 		return nil, fmt.Errorf("no valid credentials available: %w", err)
 	}
 
+	var sdkHCPConfig hcpConfig.HCPConfig = hcp
+	if certificate != nil {
+		sdkHCPConfig = &clientCertificateHCPConfig{HCPConfig: hcp, certificate: *certificate}
+	}
+
+	reauthenticating := &reauthenticatingHCPConfig{
+		HCPConfig: sdkHCPConfig,
+		source:    sdkHCPConfig,
+		newSource: func() (oauth2.TokenSource, error) {
+			return hcpConfig.NewHCPConfig(opts...)
+		},
+		dropCachedToken: func(accessToken string) error {
+			cacheFile, err := files.TokenCacheFile()
+			if err != nil {
+				return err
+			}
+			return dropCachedToken(cacheFile, accessToken)
+		},
+	}
+
 	httpClient, err := sdk.New(sdk.Config{
-		HCPConfig:     hcp,
+		HCPConfig:     reauthenticating,
 		SourceChannel: config.SourceChannel,
 	})
 	if err != nil {
 		return nil, err
 	}
 
+	if !configureConnectionPool(httpClient.Transport, config) {
+		log.Printf("[WARN] Unable to configure the HTTP connection pool, using the default limits")
+	}
+	transport := newTransport(httpClient.Transport, config)
+	transport.tokens = reauthenticating
+	httpClient.Transport = transport
+
 	httpClient.SetLogger(logger{})
 	if ShouldLog() {
 		httpClient.Debug = true
@@ -187,11 +316,52 @@ func NewClient(config ClientConfig) (*Client, error) {
 		RadarSourceRegistrationService: cloud_vault_radar.New(httpClient, nil).DataSourceRegistrationService,
 		RadarConnectionService:         cloud_vault_radar.New(httpClient, nil).IntegrationConnectionService,
 		RadarSubscriptionService:       cloud_vault_radar.New(httpClient, nil).IntegrationSubscriptionService,
+		hvns:                           newHvnCache(),
 	}
 
 	return client, nil
 }
 
+// pollIntervalFromEnv returns the poll interval set by the HCP_POLL_INTERVAL
+// environment variable, or DefaultPollInterval if it is unset or invalid.
+func pollIntervalFromEnv() time.Duration {
+	v, ok := os.LookupEnv("HCP_POLL_INTERVAL")
+	if !ok || v == "" {
+		return DefaultPollInterval
+	}
+
+	interval, err := time.ParseDuration(v)
+	if err != nil || interval <= 0 {
+		log.Printf("[WARN] Invalid HCP_POLL_INTERVAL (%q), must be a positive duration such as \"10s\"; using the default of %s", v, DefaultPollInterval)
+		return DefaultPollInterval
+	}
+
+	return interval
+}
+
+// pollInterval returns the interval at which a wait loop should poll HCP,
+// randomized by up to PollJitter. The interval is randomized once per wait
+// loop, which is enough for concurrent wait loops to drift apart.
+func (c *Client) pollInterval() time.Duration {
+	interval := c.Config.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	return jitterDuration(interval, c.Config.PollJitter, rand.Float64())
+}
+
+// jitterDuration scales d by a factor between 1-fraction and 1+fraction,
+// linearly picked by r in [0, 1). The fraction is capped at 1.
+func jitterDuration(d time.Duration, fraction, r float64) time.Duration {
+	if fraction <= 0 {
+		return d
+	}
+	fraction = min(fraction, 1)
+
+	return time.Duration(float64(d) * (1 + fraction*(2*r-1)))
+}
+
 // loadCredentialFile loads the credential file from the given config. If the
 // config does not specify workload identity authentication, this function
 // returns nil.
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	hcpConfig "github.com/hashicorp/hcp-sdk-go/config"
+)
+
+// loadClientCertificate loads the client certificate and key set in the
+// config, so that invalid ones are reported when the provider is configured
+// rather than by the first request. It returns nil if neither is set.
+func loadClientCertificate(config ClientConfig, now time.Time) (*tls.Certificate, error) {
+	if config.ClientCertificateFile == "" && config.ClientKeyFile == "" {
+		return nil, nil
+	}
+	if config.ClientCertificateFile == "" || config.ClientKeyFile == "" {
+		return nil, errors.New("client_certificate_file and client_key_file must both be set, or neither")
+	}
+
+	certificate, err := tls.LoadX509KeyPair(config.ClientCertificateFile, config.ClientKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the client certificate (%s) and key (%s): %w", config.ClientCertificateFile, config.ClientKeyFile, err)
+	}
+
+	leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the client certificate (%s): %w", config.ClientCertificateFile, err)
+	}
+	if now.After(leaf.NotAfter) {
+		return nil, fmt.Errorf("the client certificate (%s) expired at %s", config.ClientCertificateFile, leaf.NotAfter.UTC().Format(time.RFC3339))
+	}
+	if now.Before(leaf.NotBefore) {
+		return nil, fmt.Errorf("the client certificate (%s) isn't valid before %s", config.ClientCertificateFile, leaf.NotBefore.UTC().Format(time.RFC3339))
+	}
+	certificate.Leaf = leaf
+
+	return &certificate, nil
+}
+
+// clientCertificateHCPConfig is an HCP config that presents a client
+// certificate on the requests made to the HCP API, e.g. to a gateway that
+// requires mutual TLS.
+type clientCertificateHCPConfig struct {
+	hcpConfig.HCPConfig
+
+	certificate tls.Certificate
+}
+
+// APITLSConfig implements hcpConfig.HCPConfig.
+func (c *clientCertificateHCPConfig) APITLSConfig() *tls.Config {
+	tlsConfig := c.HCPConfig.APITLSConfig()
+	if tlsConfig == nil {
+		// TLS is disabled, e.g. by HCP_API_TLS for development purposes.
+		return nil
+	}
+
+	tlsConfig.Certificates = []tls.Certificate{c.certificate}
+	return tlsConfig
+}
+
+const (
+	// authURLEnvVar and authTLSEnvVar are the environment variables that the
+	// HCP SDK reads the URL of the auth endpoint and its TLS setting from.
+	authURLEnvVar = "HCP_AUTH_URL"
+	authTLSEnvVar = "HCP_AUTH_TLS"
+
+	// defaultAuthURL is the URL of the auth endpoint if HCP_AUTH_URL isn't set.
+	defaultAuthURL = "https://auth.idp.hashicorp.com"
+)
+
+// clientCertificateAuthOption returns the HCP config option that presents the
+// client certificate on the requests made to the auth endpoint for access
+// tokens. The HCP SDK only sets the auth endpoint's TLS config along with its
+// URL, so the URL and TLS setting are resolved from the environment as the
+// SDK does.
+func clientCertificateAuthOption(certificate tls.Certificate) hcpConfig.HCPConfigOption {
+	authURL := defaultAuthURL
+	if v, ok := os.LookupEnv(authURLEnvVar); ok {
+		authURL = v
+	}
+
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{certificate}}
+	if os.Getenv(authTLSEnvVar) == "insecure" {
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	return hcpConfig.WithAuth(authURL, tlsConfig)
+}
@@ -0,0 +1,183 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	hcpConfig "github.com/hashicorp/hcp-sdk-go/config"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestClientCertificate writes a self-signed certificate valid between
+// notBefore and notAfter, and its key, to the given directory and returns
+// their paths.
+func writeTestClientCertificate(t *testing.T, dir string, notBefore, notAfter time.Time) (string, string) {
+	t.Helper()
+	r := require.New(t)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	r.NoError(err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform-provider-hcp"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	r.NoError(err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	r.NoError(err)
+
+	certFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+	r.NoError(os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	r.NoError(os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func TestLoadClientCertificate(t *testing.T) {
+	now := time.Now()
+	certFile, keyFile := writeTestClientCertificate(t, t.TempDir(), now.Add(-time.Hour), now.Add(time.Hour))
+	_, otherKeyFile := writeTestClientCertificate(t, t.TempDir(), now.Add(-time.Hour), now.Add(time.Hour))
+	expiredCertFile, expiredKeyFile := writeTestClientCertificate(t, t.TempDir(), now.Add(-2*time.Hour), now.Add(-time.Hour))
+
+	tcs := map[string]struct {
+		config        ClientConfig
+		expectedError string
+	}{
+		"unset": {},
+		"valid": {
+			config: ClientConfig{ClientCertificateFile: certFile, ClientKeyFile: keyFile},
+		},
+		"missing key file": {
+			config:        ClientConfig{ClientCertificateFile: certFile},
+			expectedError: "client_certificate_file and client_key_file must both be set",
+		},
+		"missing certificate file": {
+			config:        ClientConfig{ClientKeyFile: keyFile},
+			expectedError: "client_certificate_file and client_key_file must both be set",
+		},
+		"nonexistent key": {
+			config:        ClientConfig{ClientCertificateFile: certFile, ClientKeyFile: filepath.Join(t.TempDir(), "client.key")},
+			expectedError: "unable to load the client certificate (" + certFile + ")",
+		},
+		"mismatched key": {
+			config:        ClientConfig{ClientCertificateFile: certFile, ClientKeyFile: otherKeyFile},
+			expectedError: "private key does not match public key",
+		},
+		"expired": {
+			config:        ClientConfig{ClientCertificateFile: expiredCertFile, ClientKeyFile: expiredKeyFile},
+			expectedError: "the client certificate (" + expiredCertFile + ") expired at",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			certificate, err := loadClientCertificate(tc.config, now)
+			if tc.expectedError != "" {
+				r.ErrorContains(err, tc.expectedError)
+				return
+			}
+			r.NoError(err)
+			if tc.config.ClientCertificateFile == "" {
+				r.Nil(certificate)
+				return
+			}
+			r.NotNil(certificate)
+			r.Equal("terraform-provider-hcp", certificate.Leaf.Subject.CommonName)
+		})
+	}
+}
+
+func TestClientCertificateHCPConfig(t *testing.T) {
+	r := require.New(t)
+
+	now := time.Now()
+	certFile, keyFile := writeTestClientCertificate(t, t.TempDir(), now.Add(-time.Hour), now.Add(time.Hour))
+	certificate, err := loadClientCertificate(ClientConfig{ClientCertificateFile: certFile, ClientKeyFile: keyFile}, now)
+	r.NoError(err)
+
+	hcp, err := hcpConfig.NewHCPConfig(hcpConfig.WithClientCredentials("client-id", "client-secret"), hcpConfig.WithoutBrowserLogin())
+	r.NoError(err)
+	config := &clientCertificateHCPConfig{HCPConfig: hcp, certificate: *certificate}
+	r.Equal([]tls.Certificate{*certificate}, config.APITLSConfig().Certificates)
+
+	// The certificate isn't presented if TLS is disabled.
+	hcp, err = hcpConfig.NewHCPConfig(hcpConfig.WithClientCredentials("client-id", "client-secret"), hcpConfig.WithoutBrowserLogin(), hcpConfig.WithAPI("localhost:8080", nil))
+	r.NoError(err)
+	config = &clientCertificateHCPConfig{HCPConfig: hcp, certificate: *certificate}
+	r.Nil(config.APITLSConfig())
+}
+
+func TestNewClient_ClientCertificateAuth(t *testing.T) {
+	r := require.New(t)
+
+	now := time.Now()
+	certFile, keyFile := writeTestClientCertificate(t, t.TempDir(), now.Add(-time.Hour), now.Add(time.Hour))
+	certificate, err := loadClientCertificate(ClientConfig{ClientCertificateFile: certFile, ClientKeyFile: keyFile}, now)
+	r.NoError(err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(certificate.Leaf)
+
+	// The server requires the client certificate on every request, including
+	// the ones for access tokens.
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if req.URL.Path == "/oauth2/token" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": "token",
+				"token_type":   "Bearer",
+				"expires_in":   3600,
+			})
+			return
+		}
+		_, _ = w.Write([]byte(`{"organizations": []}`))
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	srv.StartTLS()
+	defer srv.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("HCP_AUTH_URL", srv.URL)
+	t.Setenv("HCP_AUTH_TLS", "insecure")
+	t.Setenv("HCP_API_ADDRESS", strings.TrimPrefix(srv.URL, "https://"))
+	t.Setenv("HCP_API_TLS", "insecure")
+
+	_, err = NewClient(ClientConfig{
+		ClientID:              "client-id",
+		ClientSecret:          "client-secret",
+		ClientCertificateFile: certFile,
+		ClientKeyFile:         keyFile,
+	})
+	r.NoError(err)
+
+	// Without a cached access token, no access token is issued without the
+	// client certificate.
+	t.Setenv("HOME", t.TempDir())
+	_, err = NewClient(ClientConfig{
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+	})
+	r.ErrorContains(err, "no valid credentials available")
+}
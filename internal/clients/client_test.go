@@ -4,11 +4,20 @@
 package clients
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/hcp-sdk-go/auth"
 	"github.com/hashicorp/hcp-sdk-go/auth/workload"
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/client/organization_service"
+	"github.com/hashicorp/terraform-provider-hcp/version"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_loadCredentialFile(t *testing.T) {
@@ -82,3 +91,187 @@ func Test_loadCredentialFile(t *testing.T) {
 	}
 
 }
+
+func Test_pollIntervalFromEnv(t *testing.T) {
+	tcs := map[string]struct {
+		env  string
+		want time.Duration
+	}{
+		"unset": {
+			env:  "",
+			want: DefaultPollInterval,
+		},
+		"valid duration": {
+			env:  "30s",
+			want: 30 * time.Second,
+		},
+		"sub-second duration": {
+			env:  "500ms",
+			want: 500 * time.Millisecond,
+		},
+		"invalid duration": {
+			env:  "fast",
+			want: DefaultPollInterval,
+		},
+		"missing unit": {
+			env:  "10",
+			want: DefaultPollInterval,
+		},
+		"negative duration": {
+			env:  "-5s",
+			want: DefaultPollInterval,
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("HCP_POLL_INTERVAL", tc.env)
+
+			if got := pollIntervalFromEnv(); got != tc.want {
+				t.Errorf("pollIntervalFromEnv() = %s; want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func Test_jitterDuration(t *testing.T) {
+	tcs := map[string]struct {
+		fraction float64
+		r        float64
+		want     time.Duration
+	}{
+		"no jitter": {
+			fraction: 0,
+			r:        0.9,
+			want:     10 * time.Second,
+		},
+		"lowest": {
+			fraction: 0.2,
+			r:        0,
+			want:     8 * time.Second,
+		},
+		"middle": {
+			fraction: 0.2,
+			r:        0.5,
+			want:     10 * time.Second,
+		},
+		"highest": {
+			fraction: 0.2,
+			r:        1,
+			want:     12 * time.Second,
+		},
+		"capped fraction": {
+			fraction: 3,
+			r:        0,
+			want:     0,
+		},
+	}
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			if got := jitterDuration(10*time.Second, tc.fraction, tc.r); got != tc.want {
+				t.Errorf("jitterDuration() = %s; want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestClient_pollInterval(t *testing.T) {
+	client := &Client{Config: ClientConfig{PollInterval: 10 * time.Second, PollJitter: DefaultPollJitter}}
+
+	// The intervals are spread within 10% of the poll interval.
+	seen := make(map[time.Duration]bool)
+	for i := 0; i < 1000; i++ {
+		interval := client.pollInterval()
+		if interval < 9*time.Second || interval > 11*time.Second {
+			t.Fatalf("pollInterval() = %s; want between 9s and 11s", interval)
+		}
+		seen[interval] = true
+	}
+	if len(seen) < 100 {
+		t.Errorf("pollInterval() returned %d distinct intervals out of 1000; want them to be randomized", len(seen))
+	}
+
+	// Clients without jitter always poll at the same interval.
+	client.Config.PollJitter = 0
+	if got := client.pollInterval(); got != 10*time.Second {
+		t.Errorf("pollInterval() = %s without jitter; want 10s", got)
+	}
+}
+
+func TestNewClient_UserAgentSuffix(t *testing.T) {
+	// The server issues tokens, and records the user agent and source channel
+	// of the requests made to the HCP API.
+	var userAgent, sourceChannel atomic.Value
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasPrefix(req.URL.Path, "/oauth2/") {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": "token",
+				"token_type":   "Bearer",
+				"expires_in":   3600,
+			})
+			return
+		}
+
+		userAgent.Store(req.Header.Get("User-Agent"))
+		sourceChannel.Store(req.Header.Get("X-HCP-Source-Channel"))
+		_, _ = w.Write([]byte(`{"organizations": []}`))
+	}))
+	defer srv.Close()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("HCP_AUTH_URL", srv.URL)
+	t.Setenv("HCP_AUTH_TLS", "insecure")
+	t.Setenv("HCP_API_ADDRESS", strings.TrimPrefix(srv.URL, "https://"))
+	t.Setenv("HCP_API_TLS", "insecure")
+
+	tcs := map[string]struct {
+		suffix        string
+		expected      string
+		expectedError string
+	}{
+		"no suffix": {
+			expected: "terraform-provider-hcp/" + version.ProviderVersion,
+		},
+		"suffix": {
+			suffix:   "my-tool/0.4.0",
+			expected: "terraform-provider-hcp/" + version.ProviderVersion + " my-tool/0.4.0",
+		},
+		"suffix without version": {
+			suffix:   "my-tool",
+			expected: "terraform-provider-hcp/" + version.ProviderVersion + " my-tool",
+		},
+		"suffix with spaces": {
+			suffix:        "my tool",
+			expectedError: `invalid user_agent_suffix "my tool"`,
+		},
+		"suffix with header injection": {
+			suffix:        "my-tool\r\nX-Injected: true",
+			expectedError: "invalid user_agent_suffix",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			client, err := NewClient(ClientConfig{
+				ClientID:        "client-id",
+				ClientSecret:    "client-secret",
+				SourceChannel:   "terraform-provider-hcp/1.2.3",
+				UserAgentSuffix: tc.suffix,
+			})
+			if tc.expectedError != "" {
+				r.ErrorContains(err, tc.expectedError)
+				return
+			}
+			r.NoError(err)
+
+			_, err = client.Organization.OrganizationServiceList(organization_service.NewOrganizationServiceListParams(), nil)
+			r.NoError(err)
+			r.Equal(tc.expected, userAgent.Load())
+
+			// The suffix is only appended to the user agent.
+			r.True(strings.HasPrefix(sourceChannel.Load().(string), "terraform-provider-hcp/1.2.3 hcp-go-sdk/"), "unexpected source channel %q", sourceChannel.Load())
+		})
+	}
+}
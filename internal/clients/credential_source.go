@@ -0,0 +1,154 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/hcp-sdk-go/auth"
+	hcpConfig "github.com/hashicorp/hcp-sdk-go/config"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// CredentialSourceClientCredentials authenticates with the client ID and
+	// secret of a service principal, set in the ClientConfig or by the
+	// HCP_CLIENT_ID and HCP_CLIENT_SECRET environment variables.
+	CredentialSourceClientCredentials = "client_credentials"
+
+	// CredentialSourceToken authenticates with the access token set by the
+	// HCP_ACCESS_TOKEN environment variable. The token isn't refreshed, so it
+	// must remain valid for as long as the client is used.
+	CredentialSourceToken = "token"
+
+	// CredentialSourceFile authenticates with the credential file set in the
+	// ClientConfig or by the HCP_CRED_FILE environment variable.
+	CredentialSourceFile = "file"
+
+	// CredentialSourceWorkloadIdentity authenticates by exchanging the workload
+	// identity token set in the ClientConfig for a service principal token.
+	CredentialSourceWorkloadIdentity = "workload_identity"
+)
+
+// CredentialSources are the valid values of ClientConfig.CredentialSource.
+var CredentialSources = []string{
+	CredentialSourceClientCredentials,
+	CredentialSourceToken,
+	CredentialSourceFile,
+	CredentialSourceWorkloadIdentity,
+}
+
+const (
+	// AccessTokenEnvVar is the environment variable that the token credential
+	// source reads the access token from.
+	AccessTokenEnvVar = "HCP_ACCESS_TOKEN"
+
+	clientIDEnvVar       = "HCP_CLIENT_ID"
+	clientSecretEnvVar   = "HCP_CLIENT_SECRET"
+	credentialFileEnvVar = "HCP_CRED_FILE"
+)
+
+// credentialOptions returns the HCP config options that select the credentials
+// the client authenticates with.
+//
+// If config.CredentialSource is empty, the first of the client credentials,
+// the credential file and workload identity that is set in the config is used,
+// and the HCP SDK falls back to the credentials set by the environment
+// otherwise. If it is set, only the selected source is used, and an error is
+// returned if it isn't fully configured.
+func credentialOptions(config ClientConfig) ([]hcpConfig.HCPConfigOption, error) {
+	// The HCP SDK always prefers client credentials, so they have to be unset
+	// to stop the ones in the environment from overriding the selected source.
+	noClientCredentials := hcpConfig.WithClientCredentials("", "")
+
+	switch config.CredentialSource {
+	case "":
+		if config.ClientID != "" && config.ClientSecret != "" {
+			return []hcpConfig.HCPConfigOption{hcpConfig.WithClientCredentials(config.ClientID, config.ClientSecret)}, nil
+		} else if config.CredentialFile != "" {
+			cf, err := readCredentialFile(config.CredentialFile)
+			if err != nil {
+				return nil, err
+			}
+			return []hcpConfig.HCPConfigOption{hcpConfig.WithCredentialFile(cf)}, nil
+		} else if cf := loadCredentialFile(config); cf != nil {
+			return []hcpConfig.HCPConfigOption{hcpConfig.WithCredentialFile(cf)}, nil
+		}
+		return nil, nil
+
+	case CredentialSourceClientCredentials:
+		clientID, clientSecret := config.ClientID, config.ClientSecret
+		if clientID == "" && clientSecret == "" {
+			clientID, clientSecret = os.Getenv(clientIDEnvVar), os.Getenv(clientSecretEnvVar)
+		}
+		if clientID == "" || clientSecret == "" {
+			return nil, credentialSourceError(config.CredentialSource,
+				fmt.Sprintf("client_id and client_secret, or the %s and %s environment variables,", clientIDEnvVar, clientSecretEnvVar))
+		}
+		return []hcpConfig.HCPConfigOption{hcpConfig.WithClientCredentials(clientID, clientSecret)}, nil
+
+	case CredentialSourceToken:
+		token := os.Getenv(AccessTokenEnvVar)
+		if token == "" {
+			return nil, credentialSourceError(config.CredentialSource, fmt.Sprintf("the %s environment variable", AccessTokenEnvVar))
+		}
+		return []hcpConfig.HCPConfigOption{
+			noClientCredentials,
+			hcpConfig.WithTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token, TokenType: "Bearer"})),
+		}, nil
+
+	case CredentialSourceFile:
+		path := config.CredentialFile
+		if path == "" {
+			path = os.Getenv(credentialFileEnvVar)
+		}
+		if path == "" {
+			return nil, credentialSourceError(config.CredentialSource, fmt.Sprintf("credential_file, or the %s environment variable,", credentialFileEnvVar))
+		}
+		cf, err := readCredentialFile(path)
+		if err != nil {
+			return nil, err
+		}
+		return []hcpConfig.HCPConfigOption{noClientCredentials, hcpConfig.WithCredentialFile(cf)}, nil
+
+	case CredentialSourceWorkloadIdentity:
+		cf := loadCredentialFile(config)
+		if cf == nil {
+			return nil, credentialSourceError(config.CredentialSource, "workload_identity, with a resource_name and either a token or a token_file,")
+		}
+		return []hcpConfig.HCPConfigOption{noClientCredentials, hcpConfig.WithCredentialFile(cf)}, nil
+	}
+
+	return nil, fmt.Errorf("invalid credential_source %q, expected one of: %s", config.CredentialSource, strings.Join(CredentialSources, ", "))
+}
+
+// credentialSourceError returns the error reported when the selected
+// credential source isn't fully configured.
+func credentialSourceError(source, required string) error {
+	return fmt.Errorf("credential_source is %q, but %s must be set", source, required)
+}
+
+// readCredentialFile reads and validates the credential file at path. Unlike
+// the HCP SDK, it reports which file is malformed, and what it should contain.
+func readCredentialFile(path string) (*auth.CredentialFile, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read credential file (%s): %w", path, err)
+	}
+
+	var cf auth.CredentialFile
+	if err := json.Unmarshal(raw, &cf); err != nil {
+		return nil, fmt.Errorf("credential file (%s) isn't valid JSON: %v", path, err)
+	}
+
+	if err := cf.Validate(); err != nil {
+		return nil, fmt.Errorf("credential file (%s) is invalid: %v; expected a %q scheme with the oauth client_id and client_secret of a service principal, or a %q scheme with a workload identity provider",
+			path, err, auth.CredentialFileSchemeServicePrincipal, auth.CredentialFileSchemeWorkload)
+	}
+
+	return &cf, nil
+}
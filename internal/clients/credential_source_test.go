@@ -0,0 +1,202 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	hcpConfig "github.com/hashicorp/hcp-sdk-go/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentialOptions(t *testing.T) {
+	// The token endpoint issues access tokens named after the client ID that
+	// requested them, so that the test can tell which credentials were used.
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		clientID, _, ok := req.BasicAuth()
+		if !ok {
+			_ = req.ParseForm()
+			clientID = req.PostForm.Get("client_id")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "token-for-" + clientID,
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	}))
+	defer srv.Close()
+
+	credFile := filepath.Join(t.TempDir(), "cred_file.json")
+	require.NoError(t, os.WriteFile(credFile, []byte(`{
+  "scheme": "service_principal_creds",
+  "oauth": {"client_id": "file-id", "client_secret": "file-secret"}
+}`), 0600))
+
+	tcs := map[string]struct {
+		config        ClientConfig
+		env           map[string]string
+		expectedToken string
+		expectedError string
+	}{
+		"implicit client credentials": {
+			config:        ClientConfig{ClientID: "config-id", ClientSecret: "config-secret", CredentialFile: credFile},
+			expectedToken: "token-for-config-id",
+		},
+		"implicit credential file": {
+			config:        ClientConfig{CredentialFile: credFile},
+			expectedToken: "token-for-file-id",
+		},
+		"implicit environment": {
+			env:           map[string]string{"HCP_CLIENT_ID": "env-id", "HCP_CLIENT_SECRET": "env-secret"},
+			expectedToken: "token-for-env-id",
+		},
+		"client credentials": {
+			config:        ClientConfig{CredentialSource: CredentialSourceClientCredentials, ClientID: "config-id", ClientSecret: "config-secret"},
+			env:           map[string]string{"HCP_CRED_FILE": credFile},
+			expectedToken: "token-for-config-id",
+		},
+		"client credentials from environment": {
+			config:        ClientConfig{CredentialSource: CredentialSourceClientCredentials, CredentialFile: credFile},
+			env:           map[string]string{"HCP_CLIENT_ID": "env-id", "HCP_CLIENT_SECRET": "env-secret"},
+			expectedToken: "token-for-env-id",
+		},
+		"client credentials missing": {
+			config:        ClientConfig{CredentialSource: CredentialSourceClientCredentials, ClientID: "config-id", CredentialFile: credFile},
+			expectedError: `credential_source is "client_credentials", but client_id and client_secret`,
+		},
+		"token": {
+			config: ClientConfig{CredentialSource: CredentialSourceToken, ClientID: "config-id", ClientSecret: "config-secret"},
+			env: map[string]string{
+				"HCP_ACCESS_TOKEN":  "env-token",
+				"HCP_CLIENT_ID":     "env-id",
+				"HCP_CLIENT_SECRET": "env-secret",
+			},
+			expectedToken: "env-token",
+		},
+		"token missing": {
+			config:        ClientConfig{CredentialSource: CredentialSourceToken},
+			env:           map[string]string{"HCP_CLIENT_ID": "env-id", "HCP_CLIENT_SECRET": "env-secret"},
+			expectedError: `credential_source is "token", but the HCP_ACCESS_TOKEN environment variable must be set`,
+		},
+		"file": {
+			config:        ClientConfig{CredentialSource: CredentialSourceFile, CredentialFile: credFile},
+			env:           map[string]string{"HCP_CLIENT_ID": "env-id", "HCP_CLIENT_SECRET": "env-secret"},
+			expectedToken: "token-for-file-id",
+		},
+		"file from environment": {
+			config:        ClientConfig{CredentialSource: CredentialSourceFile, ClientID: "config-id", ClientSecret: "config-secret"},
+			env:           map[string]string{"HCP_CRED_FILE": credFile},
+			expectedToken: "token-for-file-id",
+		},
+		"file missing": {
+			config:        ClientConfig{CredentialSource: CredentialSourceFile, ClientID: "config-id", ClientSecret: "config-secret"},
+			expectedError: `credential_source is "file", but credential_file`,
+		},
+		"workload identity missing resource name": {
+			config:        ClientConfig{CredentialSource: CredentialSourceWorkloadIdentity, WorkloadIdentityToken: "jwt"},
+			expectedError: `credential_source is "workload_identity", but workload_identity`,
+		},
+		"invalid": {
+			config:        ClientConfig{CredentialSource: "browser"},
+			expectedError: `invalid credential_source "browser"`,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			for _, name := range []string{"HCP_CLIENT_ID", "HCP_CLIENT_SECRET", "HCP_CRED_FILE", AccessTokenEnvVar} {
+				t.Setenv(name, tc.env[name])
+				if _, ok := tc.env[name]; !ok {
+					os.Unsetenv(name)
+				}
+			}
+			t.Setenv("HOME", t.TempDir())
+			t.Setenv("HCP_AUTH_URL", srv.URL)
+			t.Setenv("HCP_AUTH_TLS", "insecure")
+
+			opts, err := credentialOptions(tc.config)
+			if tc.expectedError != "" {
+				r.ErrorContains(err, tc.expectedError)
+				return
+			}
+			r.NoError(err)
+
+			hcp, err := hcpConfig.NewHCPConfig(append([]hcpConfig.HCPConfigOption{hcpConfig.FromEnv()}, opts...)...)
+			r.NoError(err)
+
+			token, err := hcp.Token()
+			r.NoError(err)
+			r.Equal(tc.expectedToken, token.AccessToken)
+		})
+	}
+}
+
+func TestReadCredentialFile(t *testing.T) {
+	tcs := map[string]struct {
+		contents             string
+		expectedClientID     string
+		expectedClientSecret string
+		expectedError        string
+	}{
+		"service principal": {
+			contents: `{
+  "scheme": "service_principal_creds",
+  "oauth": {"client_id": "file-id", "client_secret": "file-secret"}
+}`,
+			expectedClientID:     "file-id",
+			expectedClientSecret: "file-secret",
+		},
+		"not json": {
+			contents:      "client_id=file-id\nclient_secret=file-secret\n",
+			expectedError: "isn't valid JSON",
+		},
+		"missing scheme": {
+			contents:      `{"oauth": {"client_id": "file-id", "client_secret": "file-secret"}}`,
+			expectedError: "is invalid: scheme must be one of",
+		},
+		"missing client secret": {
+			contents:      `{"scheme": "service_principal_creds", "oauth": {"client_id": "file-id"}}`,
+			expectedError: "is invalid: oauth: both client_id and client_secret must be set",
+		},
+		"missing oauth": {
+			contents:      `{"scheme": "service_principal_creds"}`,
+			expectedError: "is invalid: oauth config must be set",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			path := filepath.Join(t.TempDir(), "cred_file.json")
+			r.NoError(os.WriteFile(path, []byte(tc.contents), 0600))
+
+			cf, err := readCredentialFile(path)
+			if tc.expectedError != "" {
+				r.ErrorContains(err, "credential file ("+path+")")
+				r.ErrorContains(err, tc.expectedError)
+				return
+			}
+			r.NoError(err)
+			r.Equal(tc.expectedClientID, cf.Oauth.ClientID)
+			r.Equal(tc.expectedClientSecret, cf.Oauth.ClientSecret)
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "cred_file.json")
+		_, err := readCredentialFile(path)
+		require.ErrorContains(t, err, "unable to read credential file ("+path+")")
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
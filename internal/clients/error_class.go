@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc/codes"
+)
+
+// ErrorClass is the class of an error returned by a request to the HCP API,
+// which determines whether the request is worth retrying.
+type ErrorClass int
+
+const (
+	// ErrorClassPermanent is the class of errors that will keep occurring if
+	// the request is retried, e.g. invalid arguments or missing resources.
+	ErrorClassPermanent ErrorClass = iota
+
+	// ErrorClassTransient is the class of errors caused by a temporary
+	// condition, e.g. throttling, an unavailable service or a network failure,
+	// which may not occur if the request is retried.
+	ErrorClassTransient
+
+	// ErrorClassAuth is the class of errors caused by credentials that are
+	// invalid, expired or not authorized to perform the request. Retrying the
+	// request won't help until the credentials are fixed.
+	ErrorClassAuth
+)
+
+// AuthErrorMessage is reported in place of the API error message when a
+// request fails with an ErrorClassAuth error.
+const AuthErrorMessage = "HCP credentials are invalid or expired, or aren't authorized to perform this request"
+
+// ClassifyError returns the class of an error returned by a client service
+// request, or by the underlying HTTP transport.
+func ClassifyError(err error) ErrorClass {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return ErrorClassPermanent
+	}
+
+	// Failures to get a token are returned by the transport, before the
+	// request is sent to the HCP API.
+	var tokenErr *oauth2.RetrieveError
+	if errors.As(err, &tokenErr) {
+		if tokenErr.Response == nil {
+			return ErrorClassTransient
+		}
+		return classifyHTTPCode(tokenErr.Response.StatusCode, http.StatusBadRequest)
+	}
+
+	if apiErr, ok := ParseAPIError(err); ok {
+		switch apiErr.GRPCCode {
+		case codes.Unauthenticated, codes.PermissionDenied:
+			return ErrorClassAuth
+		case codes.Unavailable, codes.ResourceExhausted:
+			return ErrorClassTransient
+		}
+		return classifyHTTPCode(apiErr.HTTPCode)
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) {
+		return ErrorClassTransient
+	}
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return ErrorClassTransient
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return ErrorClassTransient
+	}
+
+	return ErrorClassPermanent
+}
+
+// IsAuthError returns true if err was caused by invalid, expired or
+// unauthorized credentials.
+func IsAuthError(err error) bool {
+	return ClassifyError(err) == ErrorClassAuth
+}
+
+// classifyHTTPCode returns the class of an unsuccessful HTTP response code.
+// authCodes are the codes, in addition to 401 and 403, that indicate invalid
+// credentials.
+func classifyHTTPCode(code int, authCodes ...int) ErrorClass {
+	if code == http.StatusUnauthorized || code == http.StatusForbidden {
+		return ErrorClassAuth
+	}
+
+	for _, c := range authCodes {
+		if code == c {
+			return ErrorClassAuth
+		}
+	}
+
+	if code == http.StatusTooManyRequests || shouldRetryErrorCode(code, errorCodesToRetry[:]) {
+		return ErrorClassTransient
+	}
+
+	return ErrorClassPermanent
+}
@@ -0,0 +1,129 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/client/network_service"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc/codes"
+)
+
+func TestClassifyError(t *testing.T) {
+	getPeeringErr := func(code int, grpcCode codes.Code) error {
+		err := network_service.NewGetPeeringDefault(code)
+		err.Payload = &sharedmodels.GrpcGatewayRuntimeError{Code: int32(grpcCode)}
+		return err
+	}
+
+	tokenErr := func(code int) error {
+		return &url.Error{
+			Op:  "Get",
+			URL: "https://api.cloud.hashicorp.com",
+			Err: &oauth2.RetrieveError{Response: &http.Response{StatusCode: code}},
+		}
+	}
+
+	tcs := map[string]struct {
+		err      error
+		expected ErrorClass
+	}{
+		"nil error": {
+			err:      nil,
+			expected: ErrorClassPermanent,
+		},
+		"unrelated error": {
+			err:      errors.New("invalid hvn_link"),
+			expected: ErrorClassPermanent,
+		},
+		"unauthorized": {
+			err:      getPeeringErr(http.StatusUnauthorized, codes.Unauthenticated),
+			expected: ErrorClassAuth,
+		},
+		"forbidden": {
+			err:      getPeeringErr(http.StatusForbidden, codes.PermissionDenied),
+			expected: ErrorClassAuth,
+		},
+		"unauthenticated grpc code": {
+			err:      getPeeringErr(http.StatusBadRequest, codes.Unauthenticated),
+			expected: ErrorClassAuth,
+		},
+		"unauthorized runtime error": {
+			err:      runtime.NewAPIError("unknown error", nil, http.StatusUnauthorized),
+			expected: ErrorClassAuth,
+		},
+		"expired token": {
+			err:      tokenErr(http.StatusUnauthorized),
+			expected: ErrorClassAuth,
+		},
+		"invalid client credentials": {
+			err:      tokenErr(http.StatusBadRequest),
+			expected: ErrorClassAuth,
+		},
+		"token endpoint unavailable": {
+			err:      tokenErr(http.StatusServiceUnavailable),
+			expected: ErrorClassTransient,
+		},
+		"throttled": {
+			err:      getPeeringErr(http.StatusTooManyRequests, codes.ResourceExhausted),
+			expected: ErrorClassTransient,
+		},
+		"service unavailable": {
+			err:      getPeeringErr(http.StatusServiceUnavailable, codes.Unavailable),
+			expected: ErrorClassTransient,
+		},
+		"bad gateway": {
+			err:      runtime.NewAPIError("unknown error", nil, http.StatusBadGateway),
+			expected: ErrorClassTransient,
+		},
+		"not found": {
+			err:      getPeeringErr(http.StatusNotFound, codes.NotFound),
+			expected: ErrorClassPermanent,
+		},
+		"internal error": {
+			err:      getPeeringErr(http.StatusInternalServerError, codes.Internal),
+			expected: ErrorClassPermanent,
+		},
+		"connection reset": {
+			err:      &url.Error{Op: "Get", URL: "https://api.cloud.hashicorp.com", Err: syscall.ECONNRESET},
+			expected: ErrorClassTransient,
+		},
+		"dial failure": {
+			err:      &url.Error{Op: "Get", URL: "https://api.cloud.hashicorp.com", Err: &net.OpError{Op: "dial", Err: errors.New("no route to host")}},
+			expected: ErrorClassTransient,
+		},
+		"unexpected eof": {
+			err:      fmt.Errorf("reading response: %w", io.ErrUnexpectedEOF),
+			expected: ErrorClassTransient,
+		},
+		"request timeout": {
+			err:      context.DeadlineExceeded,
+			expected: ErrorClassTransient,
+		},
+		"canceled": {
+			err:      &url.Error{Op: "Get", URL: "https://api.cloud.hashicorp.com", Err: context.Canceled},
+			expected: ErrorClassPermanent,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+			r.Equal(tc.expected, ClassifyError(tc.err))
+			r.Equal(tc.expected == ErrorClassAuth, IsAuthError(tc.err))
+		})
+	}
+}
@@ -15,6 +15,8 @@ var groupErrorCodesToRetry = [...]int{502, 503, 504}
 
 // CreateGroupRetry wraps the groups client with an exponential backoff retry mechanism.
 func CreateGroupRetry(client *Client, params *groups_service.GroupsServiceCreateGroupParams) (*groups_service.GroupsServiceCreateGroupOK, error) {
+	params.Context = withoutRetries(params.Context)
+
 	var res *groups_service.GroupsServiceCreateGroupOK
 	op := func() error {
 		var err error
@@ -30,6 +32,8 @@ func CreateGroupRetry(client *Client, params *groups_service.GroupsServiceCreate
 
 // UpdateGroupRetry wraps the groups client with an exponential backoff retry mechanism.
 func UpdateGroupRetry(client *Client, params *groups_service.GroupsServiceUpdateGroup2Params) (*groups_service.GroupsServiceUpdateGroup2OK, error) {
+	params.Context = withoutRetries(params.Context)
+
 	var res *groups_service.GroupsServiceUpdateGroup2OK
 	op := func() error {
 		var err error
@@ -45,6 +49,8 @@ func UpdateGroupRetry(client *Client, params *groups_service.GroupsServiceUpdate
 
 // DeleteGroupRetry wraps the groups client with an exponential backoff retry mechanism.
 func DeleteGroupRetry(client *Client, params *groups_service.GroupsServiceDeleteGroupParams) (*groups_service.GroupsServiceDeleteGroupOK, error) {
+	params.Context = withoutRetries(params.Context)
+
 	var res *groups_service.GroupsServiceDeleteGroupOK
 	op := func() error {
 		var err error
@@ -62,6 +68,8 @@ func DeleteGroupRetry(client *Client, params *groups_service.GroupsServiceDelete
 
 // UpdateGroupMembersRetry wraps the groups client with an exponential backoff retry mechanism.
 func UpdateGroupMembersRetry(client *Client, params *groups_service.GroupsServiceUpdateGroupMembersParams) (*groups_service.GroupsServiceUpdateGroupMembersOK, error) {
+	params.Context = withoutRetries(params.Context)
+
 	var res *groups_service.GroupsServiceUpdateGroupMembersOK
 	op := func() error {
 		var err error
@@ -5,13 +5,59 @@ package clients
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/client/network_service"
 	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
 	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
 )
 
-// GetHvnByID gets an HVN by its ID and location
+// grpcCodeResourceExhausted is the gRPC status code returned by the network
+// service when a region has temporarily run out of capacity.
+const grpcCodeResourceExhausted = 8
+
+const (
+	// HvnStateCreating is the CREATING state of an HVN
+	HvnStateCreating = string(networkmodels.HashicorpCloudNetwork20200907NetworkStateCREATING)
+
+	// HvnStateStable is the STABLE state of an HVN
+	HvnStateStable = string(networkmodels.HashicorpCloudNetwork20200907NetworkStateSTABLE)
+
+	// HvnStateFailed is the FAILED state of an HVN, which it enters if it
+	// couldn't be provisioned. It won't leave this state, and nothing can be
+	// created in it.
+	HvnStateFailed = string(networkmodels.HashicorpCloudNetwork20200907NetworkStateFAILED)
+
+	// HvnStateDeleting is the DELETING state of an HVN
+	HvnStateDeleting = string(networkmodels.HashicorpCloudNetwork20200907NetworkStateDELETING)
+)
+
+// CheckHvnNotFailed returns an error if the HVN is in the FAILED state, since
+// the resources that depend on it, e.g. peering connections and routes, would
+// never become usable.
+func CheckHvnNotFailed(hvn *networkmodels.HashicorpCloudNetwork20200907Network) error {
+	if hvn == nil || hvn.State == nil || string(*hvn.State) != HvnStateFailed {
+		return nil
+	}
+
+	return fmt.Errorf("HVN (%s) is in a %s state, and must be recreated before anything can be created in it", hvn.ID, HvnStateFailed)
+}
+
+// CheckHvnCidrBlockUpdate returns an error if the CIDR block of an existing
+// HVN can't be updated in place from oldCidrBlock to newCidrBlock. The network
+// service has no operation to update an HVN, so this is currently never
+// supported, and the HVN has to be recreated instead.
+func CheckHvnCidrBlockUpdate(hvnID, oldCidrBlock, newCidrBlock string) error {
+	return fmt.Errorf("the CIDR block of HVN (%s) can't be updated from %s to %s: HCP doesn't support changing the CIDR block of an existing HVN, so it must be recreated", hvnID, oldCidrBlock, newCidrBlock)
+}
+
+// GetHvnByID gets an HVN by its ID and location. The HVN is always read from
+// HCP, and replaces the one in the client's cache, if any.
 func GetHvnByID(ctx context.Context, client *Client, loc *sharedmodels.HashicorpCloudLocationLocation, hvnID string) (*networkmodels.HashicorpCloudNetwork20200907Network, error) {
 	getParams := network_service.NewGetParams()
 	getParams.Context = ctx
@@ -20,8 +66,95 @@ func GetHvnByID(ctx context.Context, client *Client, loc *sharedmodels.Hashicorp
 	getParams.LocationProjectID = loc.ProjectID
 	getResponse, err := client.Network.Get(getParams, nil)
 	if err != nil {
+		if IsResponseCodeNotFound(err) {
+			InvalidateHvn(client, loc, hvnID)
+		}
 		return nil, err
 	}
 
+	// Refresh the cached HVN, since this read is more recent.
+	if client.hvns != nil {
+		client.hvns.set(hvnCacheKey(loc, hvnID), getResponse.Payload.Network)
+	}
+
 	return getResponse.Payload.Network, nil
 }
+
+// ListHvns lists the HVNs in a location, across all pages of pageSize HVNs.
+// If pageSize is zero, the API's default page size is used.
+func ListHvns(ctx context.Context, client *Client, loc *sharedmodels.HashicorpCloudLocationLocation, pageSize int64) ([]*networkmodels.HashicorpCloudNetwork20200907Network, error) {
+	listParams := network_service.NewListParams()
+	listParams.Context = ctx
+	listParams.LocationOrganizationID = loc.OrganizationID
+	listParams.LocationProjectID = loc.ProjectID
+	listParams.PaginationPageSize = pageSizeParam(pageSize)
+
+	var hvns []*networkmodels.HashicorpCloudNetwork20200907Network
+	for {
+		listResponse, err := client.Network.List(listParams, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		hvns = append(hvns, listResponse.Payload.Networks...)
+
+		pagination := listResponse.Payload.Pagination
+		if pagination == nil || pagination.NextPageToken == "" {
+			return hvns, nil
+		}
+		listParams.PaginationNextPageToken = &pagination.NextPageToken
+	}
+}
+
+// IsHvnCapacityError returns true if the error returned by an HVN create
+// request indicates that the region is temporarily out of capacity, in which
+// case the request is safe to retry.
+func IsHvnCapacityError(err error) bool {
+	var createErr *network_service.CreateDefault
+	if !errors.As(err, &createErr) {
+		return false
+	}
+
+	if createErr.Code() == http.StatusTooManyRequests {
+		return true
+	}
+
+	return createErr.Payload != nil && createErr.Payload.Code == grpcCodeResourceExhausted
+}
+
+// hvnCapacityRetryShare is the share of the create timeout of an HVN during
+// which its creation is retried on capacity errors. The rest of the timeout is
+// left for the create operation to complete.
+const hvnCapacityRetryShare = 0.5
+
+// CreateHvnWithRetry creates an HVN, retrying with an exponential backoff for
+// as long as the request fails with a capacity error and less than
+// hvnCapacityRetryShare of the timeout has elapsed. Any other error is returned
+// immediately. The client's transport doesn't retry the request itself, so
+// that its retries of throttled requests don't stack with these.
+func CreateHvnWithRetry(ctx context.Context, client *Client, params *network_service.CreateParams, timeout time.Duration) (*network_service.CreateOK, error) {
+	params.Context = withoutRetries(ctx)
+
+	var res *network_service.CreateOK
+	op := func() error {
+		var err error
+		res, err = client.Network.Create(params, nil)
+		if err == nil {
+			return nil
+		}
+
+		if !IsHvnCapacityError(err) {
+			return backoff.Permanent(err)
+		}
+
+		log.Printf("[WARN] HVN (%s) could not be created due to insufficient regional capacity, retrying: %v", params.Body.Network.ID, err)
+		return err
+	}
+
+	b := backoff.NewExponentialBackOff(backoff.WithMaxElapsedTime(time.Duration(float64(timeout) * hvnCapacityRetryShare)))
+	if err := backoff.Retry(op, backoff.WithContext(b, ctx)); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"golang.org/x/sync/singleflight"
+)
+
+// hvnCacheTTL is how long an HVN read by GetHvnByIDCached is reused for. It is
+// short enough for the cache to only span a single plan or apply.
+const hvnCacheTTL = 30 * time.Second
+
+// hvnCache caches the HVNs read by a Client, so that the resources that depend
+// on the same HVN don't each have to read it. It is safe for concurrent use.
+type hvnCache struct {
+	mu      sync.Mutex
+	entries map[string]hvnCacheEntry
+
+	// group deduplicates concurrent reads of the same HVN, e.g. when
+	// Terraform creates several peering connections in the same HVN at once.
+	group singleflight.Group
+
+	// now returns the current time, and is replaced by tests.
+	now func() time.Time
+}
+
+type hvnCacheEntry struct {
+	hvn     *networkmodels.HashicorpCloudNetwork20200907Network
+	expires time.Time
+}
+
+func newHvnCache() *hvnCache {
+	return &hvnCache{
+		entries: make(map[string]hvnCacheEntry),
+		now:     time.Now,
+	}
+}
+
+// hvnCacheKey returns the key of the HVN with the given ID and location.
+func hvnCacheKey(loc *sharedmodels.HashicorpCloudLocationLocation, hvnID string) string {
+	return loc.OrganizationID + "/" + loc.ProjectID + "/" + hvnID
+}
+
+func (c *hvnCache) get(key string) (*networkmodels.HashicorpCloudNetwork20200907Network, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !c.now().Before(entry.expires) {
+		return nil, false
+	}
+	return entry.hvn, true
+}
+
+func (c *hvnCache) set(key string, hvn *networkmodels.HashicorpCloudNetwork20200907Network) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = hvnCacheEntry{hvn: hvn, expires: c.now().Add(hvnCacheTTL)}
+}
+
+func (c *hvnCache) invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+// GetHvnByIDCached gets an HVN by its ID and location, reusing the HVN if it
+// was read by the client in the last hvnCacheTTL. It should only be used by
+// resources that depend on the HVN, rather than to refresh the HVN itself.
+// The returned HVN may be shared, so it must not be modified.
+func GetHvnByIDCached(ctx context.Context, client *Client, loc *sharedmodels.HashicorpCloudLocationLocation, hvnID string) (*networkmodels.HashicorpCloudNetwork20200907Network, error) {
+	if client.hvns == nil {
+		return GetHvnByID(ctx, client, loc, hvnID)
+	}
+
+	key := hvnCacheKey(loc, hvnID)
+	if hvn, ok := client.hvns.get(key); ok {
+		return hvn, nil
+	}
+
+	hvn, err, _ := client.hvns.group.Do(key, func() (interface{}, error) {
+		return GetHvnByID(ctx, client, loc, hvnID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return hvn.(*networkmodels.HashicorpCloudNetwork20200907Network), nil
+}
+
+// InvalidateHvn removes the HVN with the given ID and location from the
+// client's cache, e.g. once it has been deleted.
+func InvalidateHvn(client *Client, loc *sharedmodels.HashicorpCloudLocationLocation, hvnID string) {
+	if client.hvns == nil {
+		return
+	}
+	client.hvns.invalidate(hvnCacheKey(loc, hvnID))
+}
@@ -0,0 +1,172 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/client/network_service"
+	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/stretchr/testify/require"
+)
+
+// testHvnClient is a network client that counts the HVNs it gets. If release
+// is set, Get blocks until it is closed. If notFound is set, the HVN is
+// reported as not found.
+type testHvnClient struct {
+	network_service.ClientService
+
+	gets     atomic.Int32
+	release  chan struct{}
+	notFound atomic.Bool
+}
+
+func (c *testHvnClient) Get(params *network_service.GetParams, _ runtime.ClientAuthInfoWriter, _ ...network_service.ClientOption) (*network_service.GetOK, error) {
+	c.gets.Add(1)
+	if c.release != nil {
+		<-c.release
+	}
+	if c.notFound.Load() {
+		return nil, network_service.NewGetDefault(http.StatusNotFound)
+	}
+
+	return &network_service.GetOK{
+		Payload: &networkmodels.HashicorpCloudNetwork20200907GetResponse{
+			Network: &networkmodels.HashicorpCloudNetwork20200907Network{
+				ID: params.ID,
+				Location: &sharedmodels.HashicorpCloudLocationLocation{
+					OrganizationID: params.LocationOrganizationID,
+					ProjectID:      params.LocationProjectID,
+				},
+			},
+		},
+	}, nil
+}
+
+func TestGetHvnByIDCached(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	network := &testHvnClient{}
+	cache := newHvnCache()
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+	client := &Client{Network: network, hvns: cache}
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: "f709ec73-55d4-46d8-897d-816ebba28778",
+		ProjectID:      "e20ad934-b88a-4897-a58e-d8318dd43cc3",
+	}
+	otherLoc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: loc.OrganizationID,
+		ProjectID:      "0b5ba2b8-4d5a-4a4e-9d2e-3c1f7a8b9c0d",
+	}
+
+	// The first read misses, and the next ones hit the cache.
+	hvn, err := GetHvnByIDCached(ctx, client, loc, "test-hvn")
+	r.NoError(err)
+	r.Equal("test-hvn", hvn.ID)
+	r.EqualValues(1, network.gets.Load())
+
+	cached, err := GetHvnByIDCached(ctx, client, loc, "test-hvn")
+	r.NoError(err)
+	r.Same(hvn, cached)
+	r.EqualValues(1, network.gets.Load())
+
+	// HVNs are cached by ID and location.
+	_, err = GetHvnByIDCached(ctx, client, loc, "other-hvn")
+	r.NoError(err)
+	_, err = GetHvnByIDCached(ctx, client, otherLoc, "test-hvn")
+	r.NoError(err)
+	r.EqualValues(3, network.gets.Load())
+
+	// Uncached reads always get the HVN, and refresh the cache.
+	refreshed, err := GetHvnByID(ctx, client, loc, "test-hvn")
+	r.NoError(err)
+	r.EqualValues(4, network.gets.Load())
+	cached, err = GetHvnByIDCached(ctx, client, loc, "test-hvn")
+	r.NoError(err)
+	r.Same(refreshed, cached)
+	r.EqualValues(4, network.gets.Load())
+
+	// Cached HVNs expire.
+	now = now.Add(hvnCacheTTL)
+	_, err = GetHvnByIDCached(ctx, client, loc, "test-hvn")
+	r.NoError(err)
+	r.EqualValues(5, network.gets.Load())
+
+	// Invalidated HVNs are read again.
+	InvalidateHvn(client, loc, "test-hvn")
+	_, err = GetHvnByIDCached(ctx, client, loc, "test-hvn")
+	r.NoError(err)
+	r.EqualValues(6, network.gets.Load())
+
+	// HVNs that are found to be deleted are invalidated, and errors aren't
+	// cached.
+	network.notFound.Store(true)
+	_, err = GetHvnByID(ctx, client, loc, "test-hvn")
+	r.True(IsResponseCodeNotFound(err))
+	_, err = GetHvnByIDCached(ctx, client, loc, "test-hvn")
+	r.True(IsResponseCodeNotFound(err))
+	r.EqualValues(8, network.gets.Load())
+}
+
+func TestGetHvnByIDCached_concurrent(t *testing.T) {
+	r := require.New(t)
+
+	network := &testHvnClient{release: make(chan struct{})}
+	client := &Client{Network: network, hvns: newHvnCache()}
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: "f709ec73-55d4-46d8-897d-816ebba28778",
+		ProjectID:      "e20ad934-b88a-4897-a58e-d8318dd43cc3",
+	}
+
+	var wg sync.WaitGroup
+	hvns := make([]*networkmodels.HashicorpCloudNetwork20200907Network, 10)
+	for i := range hvns {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			hvn, err := GetHvnByIDCached(context.Background(), client, loc, "test-hvn")
+			if err == nil {
+				hvns[i] = hvn
+			}
+		}(i)
+	}
+
+	// Let the goroutines wait on the first read before it completes.
+	r.Eventually(func() bool { return network.gets.Load() == 1 }, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	close(network.release)
+	wg.Wait()
+
+	r.EqualValues(1, network.gets.Load())
+	for _, hvn := range hvns {
+		r.NotNil(hvn)
+		r.Same(hvns[0], hvn)
+	}
+}
+
+func TestGetHvnByIDCached_disabled(t *testing.T) {
+	r := require.New(t)
+
+	// Clients that aren't created by NewClient don't cache HVNs.
+	network := &testHvnClient{}
+	client := &Client{Network: network}
+	loc := &sharedmodels.HashicorpCloudLocationLocation{ProjectID: "e20ad934-b88a-4897-a58e-d8318dd43cc3"}
+
+	for i := 0; i < 3; i++ {
+		_, err := GetHvnByIDCached(context.Background(), client, loc, "test-hvn")
+		r.NoError(err)
+	}
+	r.EqualValues(3, network.gets.Load())
+	InvalidateHvn(client, loc, "test-hvn")
+}
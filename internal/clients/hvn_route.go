@@ -45,7 +45,7 @@ func CreateHVNRoute(ctx context.Context, client *Client,
 	log.Printf("[INFO] Creating HVN route for HVN (%s) with destination CIDR %s", hvn.ID, destination)
 	hvnRouteResp, err := client.Network.CreateHVNRoute(hvnRouteParams, nil)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create HVN route for HVN (%s) with destination CIDR %s: %v", hvn.ID, destination, err)
+		return nil, fmt.Errorf("unable to create HVN route for HVN (%s) with destination CIDR %s: %w", hvn.ID, destination, err)
 	}
 
 	return hvnRouteResp.Payload, nil
@@ -68,7 +68,8 @@ func GetHVNRoute(ctx context.Context, client *Client, hvnID, routeID string, loc
 	return getHVNRouteResponse.Payload.Route, nil
 }
 
-// ListHVNRoutes lists the routes for an HVN.
+// ListHVNRoutes lists the routes for an HVN, across all pages. Empty
+// destination, targetID and targetType filters are ignored.
 func ListHVNRoutes(ctx context.Context, client *Client, hvnID string,
 	destination string, targetID string, targetType string,
 	loc *sharedmodels.HashicorpCloudLocationLocation) ([]*networkmodels.HashicorpCloudNetwork20200907HVNRoute, error) {
@@ -78,16 +79,31 @@ func ListHVNRoutes(ctx context.Context, client *Client, hvnID string,
 	listHVNRoutesParams.HvnID = hvnID
 	listHVNRoutesParams.HvnLocationOrganizationID = loc.OrganizationID
 	listHVNRoutesParams.HvnLocationProjectID = loc.ProjectID
-	listHVNRoutesParams.Destination = &destination
-	listHVNRoutesParams.TargetID = &targetID
-	listHVNRoutesParams.TargetType = &targetType
-
-	listHVNRoutesResponse, err := client.Network.ListHVNRoutes(listHVNRoutesParams, nil)
-	if err != nil {
-		return nil, err
+	if destination != "" {
+		listHVNRoutesParams.Destination = &destination
+	}
+	if targetID != "" {
+		listHVNRoutesParams.TargetID = &targetID
+	}
+	if targetType != "" {
+		listHVNRoutesParams.TargetType = &targetType
 	}
 
-	return listHVNRoutesResponse.Payload.Routes, nil
+	var routes []*networkmodels.HashicorpCloudNetwork20200907HVNRoute
+	for {
+		listHVNRoutesResponse, err := client.Network.ListHVNRoutes(listHVNRoutesParams, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		routes = append(routes, listHVNRoutesResponse.Payload.Routes...)
+
+		pagination := listHVNRoutesResponse.Payload.Pagination
+		if pagination == nil || pagination.NextPageToken == "" {
+			return routes, nil
+		}
+		listHVNRoutesParams.PaginationNextPageToken = &pagination.NextPageToken
+	}
 }
 
 // DeleteSnapshotByID deletes an HVN route by its ID
@@ -141,6 +157,11 @@ func WaitForHVNRouteToBeActive(ctx context.Context, client *Client,
 	loc *sharedmodels.HashicorpCloudLocationLocation,
 	timeout time.Duration) (*networkmodels.HashicorpCloudNetwork20200907HVNRoute, error) {
 
+	if client.Config.SkipWaits {
+		log.Printf("[WARN] Not waiting for the HVN route (%s) to become 'ACTIVE' because skip_waits is set", routeID)
+		return GetHVNRoute(ctx, client, hvnID, routeID, loc)
+	}
+
 	stateChangeConf := retry.StateChangeConf{
 		Pending: []string{
 			HvnRouteStateCreating,
@@ -151,7 +172,7 @@ func WaitForHVNRouteToBeActive(ctx context.Context, client *Client,
 		},
 		Refresh:      hvnRouteRefreshState(ctx, client, hvnID, routeID, loc),
 		Timeout:      timeout,
-		PollInterval: 5 * time.Second,
+		PollInterval: client.pollInterval(),
 	}
 
 	result, err := stateChangeConf.WaitForStateContext(ctx)
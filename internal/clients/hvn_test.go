@@ -0,0 +1,283 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/client/network_service"
+	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
+	cloud "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsHvnCapacityError(t *testing.T) {
+	createErr := func(code int, payload *cloud.GrpcGatewayRuntimeError) error {
+		err := network_service.NewCreateDefault(code)
+		err.Payload = payload
+		return err
+	}
+
+	tcs := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"nil error": {
+			err:      nil,
+			expected: false,
+		},
+		"unrelated error": {
+			err:      errors.New("connection reset by peer"),
+			expected: false,
+		},
+		"too many requests": {
+			err:      createErr(http.StatusTooManyRequests, nil),
+			expected: true,
+		},
+		"resource exhausted payload": {
+			err:      createErr(http.StatusServiceUnavailable, &cloud.GrpcGatewayRuntimeError{Code: 8, Message: "insufficient capacity"}),
+			expected: true,
+		},
+		"wrapped capacity error": {
+			err:      fmt.Errorf("create failed: %w", createErr(http.StatusTooManyRequests, nil)),
+			expected: true,
+		},
+		"invalid argument": {
+			err:      createErr(http.StatusBadRequest, &cloud.GrpcGatewayRuntimeError{Code: 3, Message: "invalid CIDR block"}),
+			expected: false,
+		},
+		"error from a different operation": {
+			err:      network_service.NewGetDefault(http.StatusTooManyRequests),
+			expected: false,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			require.Equal(t, tc.expected, IsHvnCapacityError(tc.err))
+		})
+	}
+}
+
+// testCreateHvnClient is a network client whose Create fails with err.
+type testCreateHvnClient struct {
+	network_service.ClientService
+
+	err   error
+	calls int
+}
+
+func (c *testCreateHvnClient) Create(params *network_service.CreateParams, _ runtime.ClientAuthInfoWriter, _ ...network_service.ClientOption) (*network_service.CreateOK, error) {
+	c.calls++
+	if noRetries, _ := params.Context.Value(noRetriesKey{}).(bool); !noRetries {
+		return nil, errors.New("the transport would retry the request")
+	}
+	return nil, c.err
+}
+
+func TestCreateHvnWithRetry(t *testing.T) {
+	r := require.New(t)
+
+	params := network_service.NewCreateParams()
+	params.Body = &networkmodels.HashicorpCloudNetwork20200907CreateRequest{
+		Network: &networkmodels.HashicorpCloudNetwork20200907Network{ID: "test-hvn"},
+	}
+
+	capacityErr := network_service.NewCreateDefault(http.StatusTooManyRequests)
+	network := &testCreateHvnClient{err: capacityErr}
+
+	// Retries stop after half of the timeout, leaving the rest for the create
+	// operation.
+	start := time.Now()
+	_, err := CreateHvnWithRetry(context.Background(), &Client{Network: network}, params, 2*time.Second)
+	r.ErrorIs(err, capacityErr)
+	r.Less(time.Since(start), 1500*time.Millisecond)
+	r.Greater(network.calls, 1)
+}
+
+func TestCheckHvnNotFailed(t *testing.T) {
+	tcs := map[string]struct {
+		hvn           *networkmodels.HashicorpCloudNetwork20200907Network
+		expectedError string
+	}{
+		"stable": {
+			hvn: &networkmodels.HashicorpCloudNetwork20200907Network{
+				ID:    "test-hvn",
+				State: networkmodels.HashicorpCloudNetwork20200907NetworkStateSTABLE.Pointer(),
+			},
+		},
+		"creating": {
+			hvn: &networkmodels.HashicorpCloudNetwork20200907Network{
+				ID:    "test-hvn",
+				State: networkmodels.HashicorpCloudNetwork20200907NetworkStateCREATING.Pointer(),
+			},
+		},
+		"no state": {
+			hvn: &networkmodels.HashicorpCloudNetwork20200907Network{ID: "test-hvn"},
+		},
+		"failed": {
+			hvn: &networkmodels.HashicorpCloudNetwork20200907Network{
+				ID:    "test-hvn",
+				State: networkmodels.HashicorpCloudNetwork20200907NetworkStateFAILED.Pointer(),
+			},
+			expectedError: "HVN (test-hvn) is in a FAILED state",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			err := CheckHvnNotFailed(tc.hvn)
+			if tc.expectedError == "" {
+				r.NoError(err)
+				return
+			}
+
+			r.ErrorContains(err, tc.expectedError)
+		})
+	}
+}
+
+func TestCheckHvnCidrBlockUpdate(t *testing.T) {
+	r := require.New(t)
+
+	r.EqualError(CheckHvnCidrBlockUpdate("test-hvn", "172.25.16.0/20", "172.25.32.0/20"),
+		"the CIDR block of HVN (test-hvn) can't be updated from 172.25.16.0/20 to 172.25.32.0/20: HCP doesn't support changing the CIDR block of an existing HVN, so it must be recreated")
+}
+
+// testListHvnsClient is a network client whose List returns one page of HVNs
+// per call, and records the page token that each call requested.
+type testListHvnsClient struct {
+	network_service.ClientService
+
+	pages  [][]*networkmodels.HashicorpCloudNetwork20200907Network
+	tokens []string
+	loc    *cloud.HashicorpCloudLocationLocation
+}
+
+func (c *testListHvnsClient) List(params *network_service.ListParams, _ runtime.ClientAuthInfoWriter, _ ...network_service.ClientOption) (*network_service.ListOK, error) {
+	page := len(c.tokens)
+	var token string
+	if params.PaginationNextPageToken != nil {
+		token = *params.PaginationNextPageToken
+	}
+	c.tokens = append(c.tokens, token)
+	c.loc = &cloud.HashicorpCloudLocationLocation{
+		OrganizationID: params.LocationOrganizationID,
+		ProjectID:      params.LocationProjectID,
+	}
+
+	var pagination *cloud.HashicorpCloudCommonPaginationResponse
+	if page < len(c.pages)-1 {
+		pagination = &cloud.HashicorpCloudCommonPaginationResponse{NextPageToken: fmt.Sprintf("page-%d", page+1)}
+	}
+	return &network_service.ListOK{
+		Payload: &networkmodels.HashicorpCloudNetwork20200907ListResponse{
+			Networks:   c.pages[page],
+			Pagination: pagination,
+		},
+	}, nil
+}
+
+func TestListHvns(t *testing.T) {
+	r := require.New(t)
+
+	network := &testListHvnsClient{
+		pages: [][]*networkmodels.HashicorpCloudNetwork20200907Network{
+			{{ID: "hvn-1"}, {ID: "hvn-2"}},
+			{{ID: "hvn-3"}},
+		},
+	}
+	client := &Client{Network: network}
+	loc := &cloud.HashicorpCloudLocationLocation{
+		OrganizationID: "f709ec73-55d4-46d8-897d-816ebba28778",
+		ProjectID:      "e20ad934-b88a-4897-a58e-d8318dd43cc3",
+	}
+
+	hvns, err := ListHvns(context.Background(), client, loc, 0)
+	r.NoError(err)
+	r.Len(hvns, 3)
+	for i, hvn := range hvns {
+		r.Equal(fmt.Sprintf("hvn-%d", i+1), hvn.ID)
+	}
+
+	r.Equal([]string{"", "page-1"}, network.tokens)
+	r.Equal(loc, network.loc)
+}
+
+// testPagedHvnsClient is a network client whose List pages through a fixed
+// set of HVNs using the requested page size, the way the API does. It records
+// the page size of each call, or zero if none was requested, in which case
+// every HVN is returned at once.
+type testPagedHvnsClient struct {
+	network_service.ClientService
+
+	hvns      []*networkmodels.HashicorpCloudNetwork20200907Network
+	pageSizes []int64
+}
+
+func (c *testPagedHvnsClient) List(params *network_service.ListParams, _ runtime.ClientAuthInfoWriter, _ ...network_service.ClientOption) (*network_service.ListOK, error) {
+	var pageSize int64
+	if params.PaginationPageSize != nil {
+		pageSize = *params.PaginationPageSize
+	}
+	c.pageSizes = append(c.pageSizes, pageSize)
+	if pageSize == 0 {
+		pageSize = int64(len(c.hvns))
+	}
+
+	var start int
+	if params.PaginationNextPageToken != nil {
+		fmt.Sscanf(*params.PaginationNextPageToken, "offset-%d", &start)
+	}
+	end := start + int(pageSize)
+	if end > len(c.hvns) {
+		end = len(c.hvns)
+	}
+
+	var pagination *cloud.HashicorpCloudCommonPaginationResponse
+	if end < len(c.hvns) {
+		pagination = &cloud.HashicorpCloudCommonPaginationResponse{NextPageToken: fmt.Sprintf("offset-%d", end)}
+	}
+	return &network_service.ListOK{
+		Payload: &networkmodels.HashicorpCloudNetwork20200907ListResponse{
+			Networks:   c.hvns[start:end],
+			Pagination: pagination,
+		},
+	}, nil
+}
+
+func TestListHvns_pageSize(t *testing.T) {
+	r := require.New(t)
+
+	network := &testPagedHvnsClient{}
+	for i := 1; i <= 7; i++ {
+		network.hvns = append(network.hvns, &networkmodels.HashicorpCloudNetwork20200907Network{ID: fmt.Sprintf("hvn-%d", i)})
+	}
+	client := &Client{Network: network}
+	loc := &cloud.HashicorpCloudLocationLocation{
+		OrganizationID: "f709ec73-55d4-46d8-897d-816ebba28778",
+		ProjectID:      "e20ad934-b88a-4897-a58e-d8318dd43cc3",
+	}
+
+	// Every HVN is listed once, in order, across pages of at most 3 HVNs.
+	hvns, err := ListHvns(context.Background(), client, loc, 3)
+	r.NoError(err)
+	r.Equal(network.hvns, hvns)
+	r.Equal([]int64{3, 3, 3}, network.pageSizes)
+
+	// Without a page size, none is requested, so the API's default is used.
+	network.pageSizes = nil
+	hvns, err = ListHvns(context.Background(), client, loc, 0)
+	r.NoError(err)
+	r.Equal(network.hvns, hvns)
+	r.Equal([]int64{0}, network.pageSizes)
+}
@@ -22,6 +22,11 @@ const operationWaitTimeout = time.Second * 5
 // WaitForOperation will poll the operation wait endpoint until an operation
 // is DONE, ctx is canceled, or consecutive errors occur waiting for operation to complete.
 func WaitForOperation(ctx context.Context, client *Client, operationName string, loc *sharedmodels.HashicorpCloudLocationLocation, operationID string) error {
+	if client.Config.SkipWaits {
+		log.Printf("[WARN] Not waiting for %s operation (%s) because skip_waits is set", operationName, operationID)
+		return nil
+	}
+
 	// Construct operation wait params.
 	waitTimeout := operationWaitTimeout.String()
 	waitParams := operation_service.NewWaitParams()
@@ -44,6 +49,11 @@ func WaitForOperation(ctx context.Context, client *Client, operationName string,
 			log.Printf("[INFO] Waiting for %s operation (%s)", operationName, operationID)
 			waitResponse, err := client.Operation.Wait(waitParams, nil)
 			if err != nil {
+				// Invalid or expired credentials won't become valid while waiting.
+				if IsAuthError(err) {
+					return true, err
+				}
+
 				// Increment consecutive errors - intermittent network errors shouldn't
 				// cause a all-out failure when waiting for an operation to complete.
 				consecutiveErrors++
@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
 	"github.com/hashicorp/hcp-sdk-go/clients/cloud-packer-service/stable/2021-04-30/client/packer_service"
 	packerservice "github.com/hashicorp/hcp-sdk-go/clients/cloud-packer-service/stable/2023-01-01/client/packer_service"
 	packermodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-packer-service/stable/2023-01-01/models"
@@ -17,8 +19,10 @@ import (
 
 type Bucket = packermodels.HashicorpCloudPacker20230101Bucket
 
-// ListBuckets queries the HCP Packer registry for all associated buckets.
-func ListBuckets(ctx context.Context, client *clients.Client, loc *sharedmodels.HashicorpCloudLocationLocation) ([]*Bucket, error) {
+// ListBuckets queries the HCP Packer registry for all associated buckets,
+// across all pages of pageSize buckets. If pageSize is zero, the API's default
+// page size is used.
+func ListBuckets(ctx context.Context, client *clients.Client, loc *sharedmodels.HashicorpCloudLocationLocation, pageSize int64) ([]*Bucket, error) {
 	nextPage := ""
 	var buckets []*Bucket
 
@@ -28,6 +32,9 @@ func ListBuckets(ctx context.Context, client *clients.Client, loc *sharedmodels.
 		params.LocationProjectID = loc.ProjectID
 		// Sort order is needed for acceptance tests.
 		params.SortingOrderBy = []string{"name"}
+		if pageSize > 0 {
+			params.PaginationPageSize = &pageSize
+		}
 		if nextPage != "" {
 			params.PaginationNextPageToken = &nextPage
 		}
@@ -50,12 +57,13 @@ func ListBuckets(ctx context.Context, client *clients.Client, loc *sharedmodels.
 	}
 }
 
-func CreateBucket(ctx context.Context, client *clients.Client, loc *sharedmodels.HashicorpCloudLocationLocation, name string) (*Bucket, error) {
+func CreateBucket(ctx context.Context, client *clients.Client, loc *sharedmodels.HashicorpCloudLocationLocation, name string, labels map[string]string) (*Bucket, error) {
 	params := packerservice.NewPackerServiceCreateBucketParams()
 	params.SetLocationOrganizationID(loc.OrganizationID)
 	params.SetLocationProjectID(loc.ProjectID)
 	params.Body = &packermodels.HashicorpCloudPacker20230101CreateBucketBody{
-		Name: name,
+		Name:   name,
+		Labels: labels,
 	}
 
 	resp, err := client.PackerV2.PackerServiceCreateBucket(params, nil)
@@ -65,3 +73,74 @@ func CreateBucket(ctx context.Context, client *clients.Client, loc *sharedmodels
 	}
 	return resp.GetPayload().Bucket, nil
 }
+
+// GetBucket gets a bucket by its name and location.
+func GetBucket(ctx context.Context, client *clients.Client, loc *sharedmodels.HashicorpCloudLocationLocation, name string) (*Bucket, error) {
+	params := packerservice.NewPackerServiceGetBucketParams()
+	params.SetContext(ctx)
+	params.SetLocationOrganizationID(loc.OrganizationID)
+	params.SetLocationProjectID(loc.ProjectID)
+	params.SetBucketName(name)
+
+	resp, err := client.PackerV2.PackerServiceGetBucket(params, nil)
+	if err != nil {
+		return nil, formatGRPCError[*packerservice.PackerServiceGetBucketDefault](err)
+	}
+	return resp.GetPayload().Bucket, nil
+}
+
+// UpdateBucketLabels replaces the labels of a bucket, removing the labels
+// that aren't in labels. The update endpoint replaces every user settable
+// field of the bucket, so the bucket's current description and platforms are
+// read first and sent back unchanged.
+func UpdateBucketLabels(ctx context.Context, client *clients.Client, loc *sharedmodels.HashicorpCloudLocationLocation, name string, labels map[string]string) (*Bucket, error) {
+	bucket, err := GetBucket(ctx, client, loc, name)
+	if err != nil {
+		return nil, err
+	}
+
+	params := packerservice.NewPackerServiceUpdateBucketParams()
+	params.SetContext(ctx)
+	params.SetLocationOrganizationID(loc.OrganizationID)
+	params.SetLocationProjectID(loc.ProjectID)
+	params.SetBucketName(name)
+	params.Body = &packermodels.HashicorpCloudPacker20230101UpdateBucketBody{
+		Description: bucket.Description,
+		Platforms:   bucket.Platforms,
+		Labels:      labels,
+	}
+
+	resp, err := client.PackerV2.PackerServiceUpdateBucket(params, nil, withExplicitLabels(params.Body))
+	if err != nil {
+		return nil, formatGRPCError[*packerservice.PackerServiceUpdateBucketDefault](err)
+	}
+	return resp.GetPayload().Bucket, nil
+}
+
+// updateBucketBody is the body of a bucket update that always has the labels.
+type updateBucketBody struct {
+	*packermodels.HashicorpCloudPacker20230101UpdateBucketBody
+
+	Labels map[string]string `json:"labels"`
+}
+
+// withExplicitLabels sends the body of a bucket update with its labels, even
+// if there are none. The API model omits empty labels, and the API leaves the
+// fields that a PATCH omits unchanged, so removing every label would
+// otherwise be a no-op.
+func withExplicitLabels(body *packermodels.HashicorpCloudPacker20230101UpdateBucketBody) packerservice.ClientOption {
+	labels := body.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	return func(op *runtime.ClientOperation) {
+		params := op.Params
+		op.Params = runtime.ClientRequestWriterFunc(func(req runtime.ClientRequest, reg strfmt.Registry) error {
+			if err := params.WriteToRequest(req, reg); err != nil {
+				return err
+			}
+			return req.SetBodyParam(updateBucketBody{HashicorpCloudPacker20230101UpdateBucketBody: body, Labels: labels})
+		})
+	}
+}
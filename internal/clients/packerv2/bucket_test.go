@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package packerv2
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	cloud_packer_v2 "github.com/hashicorp/hcp-sdk-go/clients/cloud-packer-service/stable/2023-01-01/client"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestUpdateBucketLabels(t *testing.T) {
+	tcs := map[string]struct {
+		labels       map[string]string
+		expectedBody string
+	}{
+		"replace labels": {
+			labels:       map[string]string{"os": "ubuntu"},
+			expectedBody: `{"description": "Ubuntu images", "platforms": ["aws"], "labels": {"os": "ubuntu"}}`,
+		},
+		"remove all labels": {
+			labels:       nil,
+			expectedBody: `{"description": "Ubuntu images", "platforms": ["aws"], "labels": {}}`,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			// The fake PackerV2 API returns the bucket, and records the body
+			// of its update.
+			var updateBody string
+			rt := httptransport.NewWithClient("api.cloud.hashicorp.com", "", []string{"https"}, &http.Client{
+				Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					if req.Method == http.MethodPatch {
+						body, err := io.ReadAll(req.Body)
+						r.NoError(err)
+						updateBody = string(body)
+					}
+					return &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": []string{"application/json"}},
+						Body:       io.NopCloser(strings.NewReader(`{"bucket": {"name": "ubuntu", "description": "Ubuntu images", "platforms": ["aws"], "labels": {"os": "debian"}}}`)),
+						Request:    req,
+					}, nil
+				}),
+			})
+			client := &clients.Client{PackerV2: cloud_packer_v2.New(rt, strfmt.Default).PackerService}
+			loc := &sharedmodels.HashicorpCloudLocationLocation{OrganizationID: "org", ProjectID: "project"}
+
+			_, err := UpdateBucketLabels(context.Background(), client, loc, "ubuntu", tc.labels)
+			r.NoError(err)
+			r.JSONEq(tc.expectedBody, updateBody)
+		})
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+const (
+	// DefaultListPageSize is the number of results that the list data
+	// sources request per page when page_size isn't set.
+	DefaultListPageSize = 100
+
+	// MaxListPageSize is the largest page_size that the list data sources
+	// accept. Larger pages need fewer requests, but the whole page is held
+	// in memory while it is decoded.
+	MaxListPageSize = 1000
+)
+
+// pageSizeParam returns the pagination page size parameter of a list request.
+// A page size of zero leaves the parameter unset, so that the API's default is
+// used.
+func pageSizeParam(pageSize int64) *int64 {
+	if pageSize <= 0 {
+		return nil
+	}
+	return &pageSize
+}
@@ -5,13 +5,24 @@ package clients
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/client/network_service"
 	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
 	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
 )
 
 // GetPeeringByID gets a peering by its ID, hvnID, and location
@@ -30,6 +41,144 @@ func GetPeeringByID(ctx context.Context, client *Client, peeringID string, hvnID
 	return getPeeringResponse.Payload.Peering, nil
 }
 
+// PeeringReadAfterCreateTimeout bounds how long GetPeeringAfterCreate retries
+// reading a newly created peering connection that the API doesn't report yet.
+const PeeringReadAfterCreateTimeout = 30 * time.Second
+
+// PeeringReadAfterImportTimeout bounds how long GetPeeringAfterImport retries
+// reading a peering connection that the API doesn't report yet, e.g. because
+// it was only just created in the HCP Portal.
+const PeeringReadAfterImportTimeout = 30 * time.Second
+
+// GetPeeringAfterCreate gets a peering connection that has just been created.
+// Reads aren't guaranteed to observe a create straight away, so a not found
+// response is retried until PeeringReadAfterCreateTimeout has elapsed.
+func GetPeeringAfterCreate(ctx context.Context, client *Client, peeringID string, hvnID string, loc *sharedmodels.HashicorpCloudLocationLocation) (*networkmodels.HashicorpCloudNetwork20200907Peering, error) {
+	return getPeeringOnceVisible(ctx, client, peeringID, hvnID, loc, "create", PeeringReadAfterCreateTimeout)
+}
+
+// GetPeeringAfterImport gets a peering connection that is being imported. As
+// with GetPeeringAfterCreate, a not found response is retried until
+// PeeringReadAfterImportTimeout has elapsed, since the peering connection may
+// have been created moments before.
+func GetPeeringAfterImport(ctx context.Context, client *Client, peeringID string, hvnID string, loc *sharedmodels.HashicorpCloudLocationLocation) (*networkmodels.HashicorpCloudNetwork20200907Peering, error) {
+	return getPeeringOnceVisible(ctx, client, peeringID, hvnID, loc, "import", PeeringReadAfterImportTimeout)
+}
+
+// getPeeringOnceVisible gets a peering connection, retrying not found
+// responses until the timeout has elapsed.
+func getPeeringOnceVisible(ctx context.Context, client *Client, peeringID string, hvnID string, loc *sharedmodels.HashicorpCloudLocationLocation, action string, timeout time.Duration) (*networkmodels.HashicorpCloudNetwork20200907Peering, error) {
+	deadline := time.Now().Add(timeout)
+
+	for {
+		peering, err := GetPeeringByID(ctx, client, peeringID, hvnID, loc)
+		if err == nil || !IsResponseCodeNotFound(err) || time.Now().After(deadline) {
+			return peering, err
+		}
+
+		log.Printf("[DEBUG] Peering connection (%s) not found on %s, retrying", peeringID, action)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(client.pollInterval()):
+		}
+	}
+}
+
+// ListPeerings lists the peering connections of an HVN, across all pages.
+func ListPeerings(ctx context.Context, client *Client, hvnID string, loc *sharedmodels.HashicorpCloudLocationLocation) ([]*networkmodels.HashicorpCloudNetwork20200907Peering, error) {
+	listPeeringsParams := network_service.NewListPeeringsParams()
+	listPeeringsParams.Context = ctx
+	listPeeringsParams.HvnID = hvnID
+	listPeeringsParams.LocationOrganizationID = loc.OrganizationID
+	listPeeringsParams.LocationProjectID = loc.ProjectID
+
+	var peerings []*networkmodels.HashicorpCloudNetwork20200907Peering
+	for {
+		listPeeringsResponse, err := client.Network.ListPeerings(listPeeringsParams, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		peerings = append(peerings, listPeeringsResponse.Payload.Peerings...)
+
+		pagination := listPeeringsResponse.Payload.Pagination
+		if pagination == nil || pagination.NextPageToken == "" {
+			return peerings, nil
+		}
+		listPeeringsParams.PaginationNextPageToken = &pagination.NextPageToken
+	}
+}
+
+// ErrPeeringNotFound is returned by GetPeeringByAzureID if no peering
+// connection of the HVN has the given Azure peering ID.
+var ErrPeeringNotFound = errors.New("peering connection not found")
+
+// GetPeeringByAzureID gets the peering connection of an HVN whose Azure-side
+// peering ID, the ProviderPeeringID reported by HCP, is azurePeeringID. The
+// network API can't filter peering connections by it, so every peering
+// connection of the HVN is listed. Azure resource IDs are case-insensitive, so
+// they are compared as such. ErrPeeringNotFound is returned if none matches.
+func GetPeeringByAzureID(ctx context.Context, client *Client, azurePeeringID string, hvnID string, loc *sharedmodels.HashicorpCloudLocationLocation) (*networkmodels.HashicorpCloudNetwork20200907Peering, error) {
+	peerings, err := ListPeerings(ctx, client, hvnID, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, peering := range peerings {
+		if peering.ProviderPeeringID != "" && strings.EqualFold(peering.ProviderPeeringID, azurePeeringID) {
+			return peering, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no peering connection of HVN (%s) has the Azure peering ID %q: %w", hvnID, azurePeeringID, ErrPeeringNotFound)
+}
+
+// ErrPeeringNotPending is returned by CancelPeering if the peering connection
+// has already been accepted, and so can no longer be canceled.
+var ErrPeeringNotPending = errors.New("peering connection is no longer pending acceptance")
+
+// CancelPeering deletes a peering connection that is still waiting to be
+// accepted by the peer, rather than leaving it to expire. The network API
+// deletes pending peering connections through the same endpoint as active ones,
+// so the peering connection's state is checked first to avoid tearing down a
+// connection that has been accepted in the meantime, in which case
+// ErrPeeringNotPending is returned. A peering connection that no longer exists
+// is considered canceled.
+func CancelPeering(ctx context.Context, client *Client, peeringID string, hvnID string, loc *sharedmodels.HashicorpCloudLocationLocation) error {
+	peering, err := GetPeeringByID(ctx, client, peeringID, hvnID, loc)
+	if err != nil {
+		if IsResponseCodeNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if peering.State == nil {
+		return fmt.Errorf("peering connection (%s) has no state: %w", peeringID, ErrPeeringNotPending)
+	}
+	if state := string(*peering.State); state != PeeringStateCreating && state != PeeringStatePendingAcceptance {
+		return fmt.Errorf("peering connection (%s) is %s: %w", peeringID, state, ErrPeeringNotPending)
+	}
+
+	deletePeeringParams := network_service.NewDeletePeeringParams()
+	deletePeeringParams.Context = ctx
+	deletePeeringParams.ID = peeringID
+	deletePeeringParams.HvnID = hvnID
+	deletePeeringParams.LocationOrganizationID = loc.OrganizationID
+	deletePeeringParams.LocationProjectID = loc.ProjectID
+	deletePeeringResponse, err := client.Network.DeletePeering(deletePeeringParams, nil)
+	if err != nil {
+		if IsResponseCodeNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	return WaitForOperation(ctx, client, "cancel peering connection", loc, deletePeeringResponse.Payload.Operation.ID)
+}
+
 const (
 	// PeeringStateCreating is the CREATING state of a peering connection
 	PeeringStateCreating = string(networkmodels.HashicorpCloudNetwork20200907PeeringStateCREATING)
@@ -42,44 +191,349 @@ const (
 
 	// PeeringStateActive is the ACTIVE state of a peering connection
 	PeeringStateActive = string(networkmodels.HashicorpCloudNetwork20200907PeeringStateACTIVE)
+
+	// PeeringStateExpired is the EXPIRED state of a peering connection that
+	// wasn't accepted before its expiry time
+	PeeringStateExpired = string(networkmodels.HashicorpCloudNetwork20200907PeeringStateEXPIRED)
+
+	// peeringStateUnrecognized is reported to wait loops instead of the
+	// states of peering connections that the provider doesn't recognize,
+	// unless FailOnUnknownStates is set, so that they are waited through.
+	peeringStateUnrecognized = "UNRECOGNIZED"
+)
+
+// knownPeeringStates are the peering connection states that the provider
+// recognizes. HCP may introduce new states before the provider is updated.
+var knownPeeringStates = []string{
+	string(networkmodels.HashicorpCloudNetwork20200907PeeringStateUNSET),
+	PeeringStateCreating,
+	PeeringStatePendingAcceptance,
+	PeeringStateAccepted,
+	PeeringStateActive,
+	string(networkmodels.HashicorpCloudNetwork20200907PeeringStateFAILED),
+	PeeringStateExpired,
+	string(networkmodels.HashicorpCloudNetwork20200907PeeringStateREJECTED),
+	string(networkmodels.HashicorpCloudNetwork20200907PeeringStateDELETING),
+}
+
+const (
+	// PeeringConnectivityUnknown is reported for active peering connections,
+	// since the network API doesn't yet expose whether traffic is flowing.
+	PeeringConnectivityUnknown = "UNKNOWN"
+
+	// PeeringConnectivityNotConnected is reported for peering connections that
+	// can't be carrying traffic because they aren't active.
+	PeeringConnectivityNotConnected = "NOT_CONNECTED"
 )
 
+// PeeringConnectivityState returns the connectivity state of a peering
+// connection. Once the network API reports connectivity health for peerings,
+// it should be surfaced here.
+func PeeringConnectivityState(peering *networkmodels.HashicorpCloudNetwork20200907Peering) string {
+	if peering.State == nil || *peering.State != networkmodels.HashicorpCloudNetwork20200907PeeringStateACTIVE {
+		return PeeringConnectivityNotConnected
+	}
+
+	return PeeringConnectivityUnknown
+}
+
+// AzurePeeringRequiredActions returns the Azure actions that the service
+// principal of an Azure peering connection's application must be allowed to
+// perform on the peer VNet, for HCP to peer its HVN with the VNet.
+func AzurePeeringRequiredActions() []string {
+	return []string{
+		"Microsoft.Network/virtualNetworks/peer/action",
+		"Microsoft.Network/virtualNetworks/virtualNetworkPeerings/read",
+		"Microsoft.Network/virtualNetworks/virtualNetworkPeerings/write",
+	}
+}
+
+// azureAuthorizationFailedClient matches the client that Azure reports in
+// AuthorizationFailed errors, i.e. the application that lacks permissions.
+var azureAuthorizationFailedClient = regexp.MustCompile(`client '([^']+)'`)
+
+// AzurePeeringPermissionError reports whether err, returned by a request to
+// create an Azure peering connection, was caused by the service principal of
+// the peering connection's application not being allowed to perform the
+// AzurePeeringRequiredActions on the peer VNet, rather than by the HCP
+// credentials. It also returns the ID of the application, if Azure reported
+// it.
+func AzurePeeringPermissionError(err error) (applicationID string, ok bool) {
+	apiErr, ok := ParseAPIError(err)
+	if !ok {
+		return "", false
+	}
+
+	// HCP passes on the error returned by Azure, which names the missing
+	// action, but reports it with a generic status code. Other authorization
+	// errors are only blamed on the application if they name one of the
+	// actions it requires, as those caused by the HCP credentials don't.
+	permissionDenied := apiErr.HTTPCode == http.StatusForbidden || apiErr.GRPCCode == codes.PermissionDenied
+	if !strings.Contains(apiErr.Message, "AuthorizationFailed") && !(permissionDenied && namesAzurePeeringRequiredAction(apiErr.Message)) {
+		return "", false
+	}
+
+	if match := azureAuthorizationFailedClient.FindStringSubmatch(apiErr.Message); match != nil {
+		return match[1], true
+	}
+	return "", true
+}
+
+// namesAzurePeeringRequiredAction reports whether message names one of the
+// AzurePeeringRequiredActions.
+func namesAzurePeeringRequiredAction(message string) bool {
+	return slices.ContainsFunc(AzurePeeringRequiredActions(), func(action string) bool {
+		return strings.Contains(message, action)
+	})
+}
+
+// AzurePeeringAcceptanceCommand returns the Azure CLI commands that grant the
+// application of an Azure peering connection the permissions HCP requires on
+// the peer VNet, for users who complete peering connections outside of
+// Terraform. It is empty until HCP has assigned the peering connection an
+// application.
+//
+// The commands first select the peer subscription, so that the service
+// principal is created in the tenant that owns it, which may not be the
+// tenant the Azure CLI defaults to.
+func AzurePeeringAcceptanceCommand(peering *networkmodels.HashicorpCloudNetwork20200907Peering) string {
+	target := peering.Target.AzureTarget
+	if target.ApplicationID == "" {
+		return ""
+	}
+
+	vnetID := AzureVnetResourceID(target)
+	roleName := "hcp-hvn-peering-" + peering.ID
+
+	// The role definition only contains IDs and action names, so it can be
+	// single-quoted as is.
+	roleDefinition, _ := json.Marshal(struct {
+		Name             string
+		Actions          []string
+		AssignableScopes []string
+	}{
+		Name:             roleName,
+		Actions:          AzurePeeringRequiredActions(),
+		AssignableScopes: []string{vnetID},
+	})
+
+	return strings.Join([]string{
+		fmt.Sprintf("az account set --subscription %s", target.SubscriptionID),
+		fmt.Sprintf("az ad sp create --id %s", target.ApplicationID),
+		fmt.Sprintf("az role definition create --role-definition '%s'", roleDefinition),
+		fmt.Sprintf("az role assignment create --assignee %s --role %s --scope %s", target.ApplicationID, roleName, vnetID),
+	}, "\n")
+}
+
+// AzurePeeringAcceptanceDetails are the details of an Azure peering
+// connection that are needed to accept it outside of Terraform.
+type AzurePeeringAcceptanceDetails struct {
+	ApplicationID         string `json:"application_id"`
+	PeerSubscriptionID    string `json:"peer_subscription_id"`
+	PeerTenantID          string `json:"peer_tenant_id"`
+	PeerResourceGroupName string `json:"peer_resource_group_name"`
+	PeerVnetName          string `json:"peer_vnet_name"`
+	PeerVnetID            string `json:"peer_vnet_id"`
+}
+
+// AzurePeeringAcceptanceDetailsJSON returns the AzurePeeringAcceptanceDetails
+// of an Azure peering connection encoded as JSON, for external tooling that
+// accepts peering connections. The application_id is empty until HCP has
+// assigned the peering connection an application.
+func AzurePeeringAcceptanceDetailsJSON(peering *networkmodels.HashicorpCloudNetwork20200907Peering) string {
+	target := peering.Target.AzureTarget
+
+	// The details only contain strings, so they can always be encoded.
+	details, _ := json.Marshal(AzurePeeringAcceptanceDetails{
+		ApplicationID:         target.ApplicationID,
+		PeerSubscriptionID:    target.SubscriptionID,
+		PeerTenantID:          target.TenantID,
+		PeerResourceGroupName: target.ResourceGroupName,
+		PeerVnetName:          target.VnetName,
+		PeerVnetID:            AzureVnetResourceID(target),
+	})
+	return string(details)
+}
+
+// peeringSensitiveField matches the names of the fields of a peering
+// connection that PeeringRawJSON redacts, should the HCP API ever return any.
+var peeringSensitiveField = regexp.MustCompile(`(?i)secret|token|password|private_key|credential`)
+
+// PeeringRawJSON returns the peering connection as it was returned by the HCP
+// API, encoded as JSON, with the values of sensitive fields redacted. It
+// allows users to read fields that the provider doesn't model yet.
+func PeeringRawJSON(peering *networkmodels.HashicorpCloudNetwork20200907Peering) (string, error) {
+	encoded, err := json.Marshal(peering)
+	if err != nil {
+		return "", err
+	}
+
+	var fields interface{}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return "", err
+	}
+
+	redacted, err := json.Marshal(redactSensitiveFields(fields))
+	if err != nil {
+		return "", err
+	}
+	return string(redacted), nil
+}
+
+// redactSensitiveFields replaces the values of the fields of v that match
+// peeringSensitiveField, at any depth.
+func redactSensitiveFields(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for name, value := range v {
+			if peeringSensitiveField.MatchString(name) {
+				v[name] = "REDACTED"
+				continue
+			}
+			v[name] = redactSensitiveFields(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactSensitiveFields(value)
+		}
+	}
+	return v
+}
+
+// PeeringSecondsToExpiry returns the number of seconds left at now to accept
+// a peering connection before it expires. It is zero for peering connections
+// that aren't pending acceptance, or whose expiry time has already passed.
+func PeeringSecondsToExpiry(peering *networkmodels.HashicorpCloudNetwork20200907Peering, now time.Time) int64 {
+	if peering.State == nil || string(*peering.State) != PeeringStatePendingAcceptance {
+		return 0
+	}
+
+	remaining := time.Time(peering.ExpiresAt).Sub(now)
+	if remaining <= 0 {
+		return 0
+	}
+
+	return int64(remaining / time.Second)
+}
+
+// AzureVnetResourceID builds the fully qualified Azure resource ID of the VNet
+// targeted by an Azure peering connection.
+func AzureVnetResourceID(target *networkmodels.HashicorpCloudNetwork20200907AzurePeeringTarget) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/virtualNetworks/%s",
+		target.SubscriptionID, target.ResourceGroupName, target.VnetName)
+}
+
+// AzureResourceGroupID builds the fully qualified Azure resource ID of the
+// resource group of the VNet targeted by an Azure peering connection.
+func AzureResourceGroupID(target *networkmodels.HashicorpCloudNetwork20200907AzurePeeringTarget) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", target.SubscriptionID, target.ResourceGroupName)
+}
+
+// PeeringStuckCreatingFunc is called by the peering wait functions once they
+// return, if the peering connection was still CREATING after half of the
+// timeout had elapsed, with how long it had been CREATING for. Peering
+// connections are normally created within minutes, so it may be stuck.
+type PeeringStuckCreatingFunc func(elapsed time.Duration)
+
+// peeringStuckCreating records whether a peering connection has been CREATING
+// for longer than threshold. It is updated by the refresh function, which runs
+// concurrently with the wait.
+type peeringStuckCreating struct {
+	threshold time.Duration
+	elapsed   atomic.Int64
+}
+
 // peeringRefreshState refreshes the state of the peering connection by calling
-// the GET endpoint
-func peeringRefreshState(ctx context.Context, client *Client, peeringID string, hvnID string, loc *sharedmodels.HashicorpCloudLocationLocation) retry.StateRefreshFunc {
+// the GET endpoint. Every poll is logged at trace level, but only changes of
+// state are logged at info level, along with how long the wait has taken. A
+// warning is logged the first time the peering connection is found to still be
+// CREATING after stuck's threshold.
+func peeringRefreshState(ctx context.Context, client *Client, peeringID string, hvnID string, loc *sharedmodels.HashicorpCloudLocationLocation, stuck *peeringStuckCreating) retry.StateRefreshFunc {
+	start := time.Now()
+	var previous string
+	warned := make(map[string]bool)
 	return func() (interface{}, string, error) {
 		peering, err := GetPeeringByID(ctx, client, peeringID, hvnID, loc)
 		if err != nil {
 			return nil, "", err
 		}
 
-		return peering, string(*peering.State), nil
+		state := string(*peering.State)
+		fields := map[string]interface{}{
+			"peering_id": peeringID,
+			"state":      state,
+			"elapsed":    time.Since(start).Round(time.Second).String(),
+		}
+		tflog.Trace(ctx, "Polled peering connection state", fields)
+		if state != previous {
+			fields["previous_state"] = previous
+			tflog.Info(ctx, fmt.Sprintf("Peering connection (%s) state: %s", peeringID, peeringStateTransition(previous, state)), fields)
+			previous = state
+		}
+
+		if elapsed := time.Since(start); state == PeeringStateCreating && elapsed >= stuck.threshold && stuck.elapsed.Load() == 0 {
+			tflog.Warn(ctx, fmt.Sprintf("Peering connection (%s) has been CREATING for %s, longer than expected", peeringID, elapsed.Round(time.Second)), fields)
+			stuck.elapsed.Store(int64(elapsed))
+		}
+
+		if !slices.Contains(knownPeeringStates, state) && !client.Config.FailOnUnknownStates {
+			if !warned[state] {
+				tflog.Warn(ctx, fmt.Sprintf("Peering connection (%s) is in a state that the provider doesn't recognize (%s), waiting for it to change", peeringID, state), fields)
+				warned[state] = true
+			}
+			return peering, peeringStateUnrecognized, nil
+		}
+
+		return peering, state, nil
 	}
 }
 
-type WaitFor = func(ctx context.Context, client *Client, peeringID string, hvnID string, loc *sharedmodels.HashicorpCloudLocationLocation, timeout time.Duration) (*networkmodels.HashicorpCloudNetwork20200907Peering, error)
+// peeringStateTransition formats a change of a peering connection's state for
+// logging, e.g. "CREATING -> PENDING_ACCEPTANCE". The first state observed has
+// no previous state.
+func peeringStateTransition(previous, state string) string {
+	if previous == "" {
+		return state
+	}
+	return previous + " -> " + state
+}
+
+// WaitFor waits for a peering connection to reach a state. If onStuckCreating
+// is passed, it is called if the peering connection was CREATING for longer
+// than half of the timeout, once the wait is over, so that callers can warn
+// that something may be wrong.
+type WaitFor = func(ctx context.Context, client *Client, peeringID string, hvnID string, loc *sharedmodels.HashicorpCloudLocationLocation, timeout time.Duration, onStuckCreating ...PeeringStuckCreatingFunc) (*networkmodels.HashicorpCloudNetwork20200907Peering, error)
 
-// peeringState contains a target peering state and a list of every allowed pending state
+// peeringState contains the target peering states and a list of every allowed pending state
 type peeringState struct {
-	Target  string
+	Targets []string
 	Pending []string
 }
 
 func waitForPeeringToBe(ps peeringState) WaitFor {
-	return func(ctx context.Context, client *Client, peeringID string, hvnID string, loc *sharedmodels.HashicorpCloudLocationLocation, timeout time.Duration) (*networkmodels.HashicorpCloudNetwork20200907Peering, error) {
+	return func(ctx context.Context, client *Client, peeringID string, hvnID string, loc *sharedmodels.HashicorpCloudLocationLocation, timeout time.Duration, onStuckCreating ...PeeringStuckCreatingFunc) (*networkmodels.HashicorpCloudNetwork20200907Peering, error) {
+		if client.Config.SkipWaits {
+			log.Printf("[WARN] Not waiting for peering connection (%s) to become '%s' because skip_waits is set", peeringID, strings.Join(ps.Targets, "' or '"))
+			return GetPeeringByID(ctx, client, peeringID, hvnID, loc)
+		}
+
+		stuck := &peeringStuckCreating{threshold: timeout / 2}
 		stateChangeConfig := retry.StateChangeConf{
-			Pending: ps.Pending,
-			Target: []string{
-				ps.Target,
-			},
-			Refresh:      peeringRefreshState(ctx, client, peeringID, hvnID, loc),
+			Pending:      append(slices.Clip(ps.Pending), peeringStateUnrecognized),
+			Target:       ps.Targets,
+			Refresh:      peeringRefreshState(ctx, client, peeringID, hvnID, loc, stuck),
 			Timeout:      timeout,
-			PollInterval: 5 * time.Second,
+			PollInterval: client.pollInterval(),
 		}
 
 		result, err := stateChangeConfig.WaitForStateContext(ctx)
+		if elapsed := time.Duration(stuck.elapsed.Load()); elapsed > 0 {
+			for _, f := range onStuckCreating {
+				f(elapsed)
+			}
+		}
 		if err != nil {
-			err = fmt.Errorf("error waiting for peering connection (%s) to become '%s': %v", peeringID, ps.Target, err)
+			err = fmt.Errorf("error waiting for peering connection (%s) to become '%s': %w", peeringID, strings.Join(ps.Targets, "' or '"), err)
 			if result != nil {
 				return result.(*networkmodels.HashicorpCloudNetwork20200907Peering), err
 			}
@@ -93,21 +547,115 @@ func waitForPeeringToBe(ps peeringState) WaitFor {
 // WaitForPeeringToBePendingAcceptance will poll the GET peering endpoint until
 // the state is PENDING_ACCEPTANCE, ctx is canceled, or an error occurs.
 var WaitForPeeringToBePendingAcceptance = waitForPeeringToBe(peeringState{
-	Target:  PeeringStatePendingAcceptance,
+	Targets: []string{PeeringStatePendingAcceptance},
 	Pending: []string{PeeringStateCreating},
 })
 
 // WaitForPeeringToBeAccepted will poll the GET peering endpoint until the state is ACCEPTED, ctx is canceled, or an error occurs.
 var WaitForPeeringToBeAccepted = waitForPeeringToBe(peeringState{
-	Target:  PeeringStateAccepted,
+	Targets: []string{PeeringStateAccepted},
 	Pending: []string{PeeringStateCreating, PeeringStatePendingAcceptance},
 })
 
 // WaitForPeeringToBeActive will poll the GET peering endpoint until the state is ACTIVE, ctx is canceled, or an error occurs.
 var WaitForPeeringToBeActive = waitForPeeringToBe(peeringState{
-	Target:  PeeringStateActive,
+	Targets: []string{PeeringStateActive},
 	Pending: WaitForPeeringToBeActiveStates,
 })
 
 // WaitForPeeringToBeActiveStates are those from which we'd expect an ACTIVE state to be possible.
 var WaitForPeeringToBeActiveStates = []string{PeeringStateCreating, PeeringStatePendingAcceptance, PeeringStateAccepted}
+
+// PeeringAcceptableStates are the states that a peering connection can be
+// waited on to reach by WaitForPeeringToBeAnyOf: ACTIVE, and the states from
+// which we'd expect an ACTIVE state to be possible.
+var PeeringAcceptableStates = append(append([]string{}, WaitForPeeringToBeActiveStates...), PeeringStateActive)
+
+// WaitForPeeringToBeAnyOf returns a WaitFor that polls the GET peering
+// endpoint until the state is any of the passed states, which must be
+// PeeringAcceptableStates. Reaching ACTIVE always completes the wait, since
+// a peering connection may pass through the other states between polls.
+func WaitForPeeringToBeAnyOf(states []string) WaitFor {
+	targets := states
+	if !slices.Contains(targets, PeeringStateActive) {
+		targets = append(append([]string{}, states...), PeeringStateActive)
+	}
+
+	var pending []string
+	for _, state := range WaitForPeeringToBeActiveStates {
+		if !slices.Contains(targets, state) {
+			pending = append(pending, state)
+		}
+	}
+
+	return waitForPeeringToBe(peeringState{Targets: targets, Pending: pending})
+}
+
+// peeringStateDeleted is reported to the wait loop of WaitForPeeringToBeDeleted
+// once a peering connection is no longer found. The network API doesn't report
+// a DELETED state.
+const peeringStateDeleted = "DELETED"
+
+// WaitForPeeringToBeDeleted polls the GET peering endpoint until the peering
+// connection is no longer found, ctx is canceled, or an error occurs. A delete
+// operation can complete while the peering connection is still reported, e.g.
+// as DELETING, during which creating one with the same ID conflicts.
+func WaitForPeeringToBeDeleted(ctx context.Context, client *Client, peeringID string, hvnID string, loc *sharedmodels.HashicorpCloudLocationLocation, timeout time.Duration) error {
+	if client.Config.SkipWaits {
+		log.Printf("[WARN] Not waiting for peering connection (%s) to be deleted because skip_waits is set", peeringID)
+		return nil
+	}
+
+	refresh := peeringRefreshState(ctx, client, peeringID, hvnID, loc, &peeringStuckCreating{threshold: timeout})
+	stateChangeConfig := retry.StateChangeConf{
+		Pending: append(slices.Clone(knownPeeringStates), peeringStateUnrecognized),
+		Target:  []string{peeringStateDeleted},
+		Refresh: func() (interface{}, string, error) {
+			peering, state, err := refresh()
+			if err != nil && IsResponseCodeNotFound(err) {
+				return struct{}{}, peeringStateDeleted, nil
+			}
+			return peering, state, err
+		},
+		Timeout:      timeout,
+		PollInterval: client.pollInterval(),
+	}
+
+	if _, err := stateChangeConfig.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for peering connection (%s) to be deleted: %w", peeringID, err)
+	}
+	return nil
+}
+
+// PeeringLocator identifies a peering connection by its ID, the ID of its HVN
+// and the HVN's location.
+type PeeringLocator struct {
+	PeeringID string
+	HvnID     string
+	Location  *sharedmodels.HashicorpCloudLocationLocation
+}
+
+// WaitForPeeringsToBeActive polls the GET peering endpoint for each of the
+// passed peering connections concurrently, until they are all ACTIVE. The
+// peering connections are returned in the same order as they were passed. If
+// any of them fails to become ACTIVE, waiting for the others is canceled and
+// the first error is returned.
+func WaitForPeeringsToBeActive(ctx context.Context, client *Client, peerings []PeeringLocator, timeout time.Duration) ([]*networkmodels.HashicorpCloudNetwork20200907Peering, error) {
+	results := make([]*networkmodels.HashicorpCloudNetwork20200907Peering, len(peerings))
+
+	g, ctx := errgroup.WithContext(ctx)
+	for i, p := range peerings {
+		i, p := i, p
+		g.Go(func() error {
+			peering, err := WaitForPeeringToBeActive(ctx, client, p.PeeringID, p.HvnID, p.Location, timeout)
+			results[i] = peering
+			return err
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return results, err
+	}
+
+	return results, nil
+}
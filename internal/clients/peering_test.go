@@ -0,0 +1,755 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/client/network_service"
+	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestPeeringConnectivityState(t *testing.T) {
+	tests := map[string]struct {
+		state    *networkmodels.HashicorpCloudNetwork20200907PeeringState
+		expected string
+	}{
+		"no state": {
+			state:    nil,
+			expected: PeeringConnectivityNotConnected,
+		},
+		"pending acceptance": {
+			state:    networkmodels.HashicorpCloudNetwork20200907PeeringStatePENDINGACCEPTANCE.Pointer(),
+			expected: PeeringConnectivityNotConnected,
+		},
+		"failed": {
+			state:    networkmodels.HashicorpCloudNetwork20200907PeeringStateFAILED.Pointer(),
+			expected: PeeringConnectivityNotConnected,
+		},
+		"active": {
+			state:    networkmodels.HashicorpCloudNetwork20200907PeeringStateACTIVE.Pointer(),
+			expected: PeeringConnectivityUnknown,
+		},
+	}
+
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			peering := &networkmodels.HashicorpCloudNetwork20200907Peering{State: tc.state}
+			require.Equal(t, tc.expected, PeeringConnectivityState(peering))
+		})
+	}
+}
+
+// testPeeringStateClient is a network client whose GetPeering returns the next
+// state of the requested peering connection's sequence on every call. Once a
+// sequence is exhausted, its last state is returned.
+type testPeeringStateClient struct {
+	network_service.ClientService
+
+	mu        sync.Mutex
+	sequences map[string][]networkmodels.HashicorpCloudNetwork20200907PeeringState
+}
+
+func (c *testPeeringStateClient) GetPeering(params *network_service.GetPeeringParams, _ runtime.ClientAuthInfoWriter, _ ...network_service.ClientOption) (*network_service.GetPeeringOK, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sequence := c.sequences[params.ID]
+	state := sequence[0]
+	if len(sequence) > 1 {
+		c.sequences[params.ID] = sequence[1:]
+	}
+
+	return &network_service.GetPeeringOK{
+		Payload: &networkmodels.HashicorpCloudNetwork20200907GetPeeringResponse{
+			Peering: &networkmodels.HashicorpCloudNetwork20200907Peering{
+				ID:    params.ID,
+				State: state.Pointer(),
+			},
+		},
+	}, nil
+}
+
+func TestWaitForPeeringsToBeActive(t *testing.T) {
+	var (
+		creating  = networkmodels.HashicorpCloudNetwork20200907PeeringStateCREATING
+		pending   = networkmodels.HashicorpCloudNetwork20200907PeeringStatePENDINGACCEPTANCE
+		accepted  = networkmodels.HashicorpCloudNetwork20200907PeeringStateACCEPTED
+		active    = networkmodels.HashicorpCloudNetwork20200907PeeringStateACTIVE
+		failed    = networkmodels.HashicorpCloudNetwork20200907PeeringStateFAILED
+		loc       = &sharedmodels.HashicorpCloudLocationLocation{OrganizationID: "org", ProjectID: "project"}
+		locators  = []PeeringLocator{{PeeringID: "a", HvnID: "hvn", Location: loc}, {PeeringID: "b", HvnID: "hvn", Location: loc}, {PeeringID: "c", HvnID: "hvn", Location: loc}}
+		newClient = func(sequences map[string][]networkmodels.HashicorpCloudNetwork20200907PeeringState) *Client {
+			return &Client{
+				Config:  ClientConfig{PollInterval: time.Millisecond},
+				Network: &testPeeringStateClient{sequences: sequences},
+			}
+		}
+	)
+
+	t.Run("all become active", func(t *testing.T) {
+		r := require.New(t)
+
+		client := newClient(map[string][]networkmodels.HashicorpCloudNetwork20200907PeeringState{
+			"a": {creating, pending, pending, accepted, active},
+			"b": {accepted, active},
+			"c": {active},
+		})
+
+		peerings, err := WaitForPeeringsToBeActive(context.Background(), client, locators, time.Minute)
+		r.NoError(err)
+		r.Len(peerings, 3)
+		for i, peering := range peerings {
+			r.Equal(locators[i].PeeringID, peering.ID)
+			r.Equal(active, *peering.State)
+		}
+	})
+
+	t.Run("one fails", func(t *testing.T) {
+		r := require.New(t)
+
+		client := newClient(map[string][]networkmodels.HashicorpCloudNetwork20200907PeeringState{
+			"a": {pending},
+			"b": {creating, failed},
+			"c": {active},
+		})
+
+		peerings, err := WaitForPeeringsToBeActive(context.Background(), client, locators, time.Minute)
+		r.ErrorContains(err, "peering connection (b)")
+		r.Equal(failed, *peerings[1].State)
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		r := require.New(t)
+
+		client := newClient(map[string][]networkmodels.HashicorpCloudNetwork20200907PeeringState{
+			"a": {pending},
+			"b": {active},
+			"c": {accepted},
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := WaitForPeeringsToBeActive(ctx, client, locators, time.Minute)
+		r.Error(err)
+		r.Less(time.Since(start), 10*time.Second)
+	})
+}
+
+func TestWaitForPeeringToBeActive_logsTransitions(t *testing.T) {
+	r := require.New(t)
+
+	var (
+		creating = networkmodels.HashicorpCloudNetwork20200907PeeringStateCREATING
+		pending  = networkmodels.HashicorpCloudNetwork20200907PeeringStatePENDINGACCEPTANCE
+		accepted = networkmodels.HashicorpCloudNetwork20200907PeeringStateACCEPTED
+		active   = networkmodels.HashicorpCloudNetwork20200907PeeringStateACTIVE
+		loc      = &sharedmodels.HashicorpCloudLocationLocation{OrganizationID: "org", ProjectID: "project"}
+	)
+	client := &Client{
+		Config: ClientConfig{PollInterval: time.Millisecond},
+		Network: &testPeeringStateClient{sequences: map[string][]networkmodels.HashicorpCloudNetwork20200907PeeringState{
+			"a": {creating, creating, pending, pending, pending, accepted, active},
+		}},
+	}
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	_, err := WaitForPeeringToBeActive(ctx, client, "a", "hvn", loc, time.Minute)
+	r.NoError(err)
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	r.NoError(err)
+
+	// Every poll is logged at trace level, but each change of state is only
+	// logged once at info level.
+	var polls int
+	var transitions []string
+	for _, entry := range entries {
+		switch entry["@level"] {
+		case "trace":
+			polls++
+		case "info":
+			r.Equal("a", entry["peering_id"])
+			r.Contains(entry, "elapsed")
+			transitions = append(transitions, entry["@message"].(string))
+		}
+	}
+	r.Equal(7, polls)
+	r.Equal([]string{
+		"Peering connection (a) state: CREATING",
+		"Peering connection (a) state: CREATING -> PENDING_ACCEPTANCE",
+		"Peering connection (a) state: PENDING_ACCEPTANCE -> ACCEPTED",
+		"Peering connection (a) state: ACCEPTED -> ACTIVE",
+	}, transitions)
+}
+
+func TestWaitForPeeringToBePendingAcceptance_stuckCreating(t *testing.T) {
+	var (
+		creating = networkmodels.HashicorpCloudNetwork20200907PeeringStateCREATING
+		pending  = networkmodels.HashicorpCloudNetwork20200907PeeringStatePENDINGACCEPTANCE
+		loc      = &sharedmodels.HashicorpCloudLocationLocation{OrganizationID: "org", ProjectID: "project"}
+	)
+
+	// With a timeout of 1s, peering connections are expected to be created
+	// within 500ms.
+	tcs := map[string]struct {
+		creatingPolls int
+		expectWarning bool
+	}{
+		"created quickly": {
+			creatingPolls: 2,
+		},
+		"stuck creating": {
+			creatingPolls: 28,
+			expectWarning: true,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			sequence := make([]networkmodels.HashicorpCloudNetwork20200907PeeringState, tc.creatingPolls, tc.creatingPolls+1)
+			for i := range sequence {
+				sequence[i] = creating
+			}
+			client := &Client{
+				Config: ClientConfig{PollInterval: 25 * time.Millisecond},
+				Network: &testPeeringStateClient{sequences: map[string][]networkmodels.HashicorpCloudNetwork20200907PeeringState{
+					"a": append(sequence, pending),
+				}},
+			}
+
+			var output bytes.Buffer
+			ctx := tflogtest.RootLogger(context.Background(), &output)
+
+			var calls int
+			var stuckFor time.Duration
+			peering, err := WaitForPeeringToBePendingAcceptance(ctx, client, "a", "hvn", loc, time.Second, func(elapsed time.Duration) {
+				calls++
+				stuckFor = elapsed
+			})
+			r.NoError(err)
+			r.Equal(pending, *peering.State)
+
+			entries, err := tflogtest.MultilineJSONDecode(&output)
+			r.NoError(err)
+			var warnings []string
+			for _, entry := range entries {
+				if entry["@level"] == "warn" {
+					r.Equal("a", entry["peering_id"])
+					warnings = append(warnings, entry["@message"].(string))
+				}
+			}
+
+			if !tc.expectWarning {
+				r.Zero(calls)
+				r.Empty(warnings)
+				return
+			}
+
+			// The warning is only logged and reported once, and the wait
+			// carries on until the peering connection is created.
+			r.Equal(1, calls)
+			r.GreaterOrEqual(stuckFor, 500*time.Millisecond)
+			r.Len(warnings, 1)
+			r.Contains(warnings[0], "Peering connection (a) has been CREATING for")
+		})
+	}
+}
+
+func TestWaitForPeeringToBeActive_unknownState(t *testing.T) {
+	var (
+		creating = networkmodels.HashicorpCloudNetwork20200907PeeringStateCREATING
+		unknown  = networkmodels.HashicorpCloudNetwork20200907PeeringState("PROVISIONING")
+		active   = networkmodels.HashicorpCloudNetwork20200907PeeringStateACTIVE
+		loc      = &sharedmodels.HashicorpCloudLocationLocation{OrganizationID: "org", ProjectID: "project"}
+	)
+
+	tcs := map[string]struct {
+		failOnUnknownStates bool
+		expectedError       string
+	}{
+		"transient by default": {},
+		"failure if strict": {
+			failOnUnknownStates: true,
+			expectedError:       "unexpected state 'PROVISIONING', wanted target 'ACTIVE'",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			client := &Client{
+				Config: ClientConfig{PollInterval: time.Millisecond, FailOnUnknownStates: tc.failOnUnknownStates},
+				Network: &testPeeringStateClient{sequences: map[string][]networkmodels.HashicorpCloudNetwork20200907PeeringState{
+					"a": {creating, unknown, unknown, unknown, active},
+				}},
+			}
+
+			var output bytes.Buffer
+			ctx := tflogtest.RootLogger(context.Background(), &output)
+
+			peering, err := WaitForPeeringToBeActive(ctx, client, "a", "hvn", loc, time.Second)
+			if tc.expectedError != "" {
+				r.ErrorContains(err, tc.expectedError)
+				return
+			}
+			r.NoError(err)
+			r.Equal(active, *peering.State)
+
+			// The unknown state is warned about once.
+			entries, err := tflogtest.MultilineJSONDecode(&output)
+			r.NoError(err)
+			var warnings []string
+			for _, entry := range entries {
+				if entry["@level"] == "warn" {
+					warnings = append(warnings, entry["@message"].(string))
+				}
+			}
+			r.Equal([]string{
+				"Peering connection (a) is in a state that the provider doesn't recognize (PROVISIONING), waiting for it to change",
+			}, warnings)
+		})
+	}
+}
+
+func TestWaitForPeeringToBeAnyOf(t *testing.T) {
+	var (
+		creating = networkmodels.HashicorpCloudNetwork20200907PeeringStateCREATING
+		pending  = networkmodels.HashicorpCloudNetwork20200907PeeringStatePENDINGACCEPTANCE
+		accepted = networkmodels.HashicorpCloudNetwork20200907PeeringStateACCEPTED
+		active   = networkmodels.HashicorpCloudNetwork20200907PeeringStateACTIVE
+		failed   = networkmodels.HashicorpCloudNetwork20200907PeeringStateFAILED
+		loc      = &sharedmodels.HashicorpCloudLocationLocation{OrganizationID: "org", ProjectID: "project"}
+	)
+
+	tcs := map[string]struct {
+		states        []string
+		sequence      []networkmodels.HashicorpCloudNetwork20200907PeeringState
+		expectedState networkmodels.HashicorpCloudNetwork20200907PeeringState
+		expectedError string
+	}{
+		"first acceptable state": {
+			states:        []string{PeeringStateAccepted, PeeringStatePendingAcceptance},
+			sequence:      []networkmodels.HashicorpCloudNetwork20200907PeeringState{creating, pending, accepted, active},
+			expectedState: pending,
+		},
+		"acceptable state skipped": {
+			states:        []string{PeeringStatePendingAcceptance},
+			sequence:      []networkmodels.HashicorpCloudNetwork20200907PeeringState{creating, accepted, active},
+			expectedState: active,
+		},
+		"failed": {
+			states:        []string{PeeringStateAccepted},
+			sequence:      []networkmodels.HashicorpCloudNetwork20200907PeeringState{creating, failed},
+			expectedError: "error waiting for peering connection (a) to become 'ACCEPTED' or 'ACTIVE'",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			client := &Client{
+				Config:  ClientConfig{PollInterval: time.Millisecond},
+				Network: &testPeeringStateClient{sequences: map[string][]networkmodels.HashicorpCloudNetwork20200907PeeringState{"a": tc.sequence}},
+			}
+
+			peering, err := WaitForPeeringToBeAnyOf(tc.states)(context.Background(), client, "a", "hvn", loc, time.Minute)
+			if tc.expectedError != "" {
+				r.ErrorContains(err, tc.expectedError)
+				return
+			}
+			r.NoError(err)
+			r.Equal(tc.expectedState, *peering.State)
+		})
+	}
+}
+
+func TestPeeringSecondsToExpiry(t *testing.T) {
+	now := time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		state     networkmodels.HashicorpCloudNetwork20200907PeeringState
+		expiresAt time.Time
+		expected  int64
+	}{
+		"pending acceptance": {
+			state:     networkmodels.HashicorpCloudNetwork20200907PeeringStatePENDINGACCEPTANCE,
+			expiresAt: now.Add(90*time.Minute + 500*time.Millisecond),
+			expected:  5400,
+		},
+		"pending acceptance past expiry": {
+			state:     networkmodels.HashicorpCloudNetwork20200907PeeringStatePENDINGACCEPTANCE,
+			expiresAt: now.Add(-time.Minute),
+			expected:  0,
+		},
+		"creating": {
+			state:     networkmodels.HashicorpCloudNetwork20200907PeeringStateCREATING,
+			expiresAt: now.Add(time.Hour),
+			expected:  0,
+		},
+		"active": {
+			state:     networkmodels.HashicorpCloudNetwork20200907PeeringStateACTIVE,
+			expiresAt: now.Add(time.Hour),
+			expected:  0,
+		},
+	}
+
+	for n, tc := range tests {
+		t.Run(n, func(t *testing.T) {
+			peering := &networkmodels.HashicorpCloudNetwork20200907Peering{
+				State:     tc.state.Pointer(),
+				ExpiresAt: strfmt.DateTime(tc.expiresAt),
+			}
+			require.Equal(t, tc.expected, PeeringSecondsToExpiry(peering, now))
+		})
+	}
+}
+
+func TestAzurePeeringRequiredActions(t *testing.T) {
+	require.Equal(t, []string{
+		"Microsoft.Network/virtualNetworks/peer/action",
+		"Microsoft.Network/virtualNetworks/virtualNetworkPeerings/read",
+		"Microsoft.Network/virtualNetworks/virtualNetworkPeerings/write",
+	}, AzurePeeringRequiredActions())
+}
+
+func TestAzurePeeringPermissionError(t *testing.T) {
+	createPeeringErr := func(code int, grpcCode codes.Code, message string) error {
+		err := network_service.NewCreatePeeringDefault(code)
+		err.Payload = &sharedmodels.GrpcGatewayRuntimeError{Code: int32(grpcCode), Message: message}
+		return err
+	}
+
+	tcs := map[string]struct {
+		err                   error
+		expected              bool
+		expectedApplicationID string
+	}{
+		"azure authorization failed": {
+			err:                   createPeeringErr(http.StatusBadRequest, codes.FailedPrecondition, "AuthorizationFailed: The client 'f4b2e8a6-3c1d-4e5f-9a7b-8c6d5e4f3a2b' with object id '0e1d2c3b-4a59-6877-8695-a4b3c2d1e0f9' does not have authorization to perform action 'Microsoft.Network/virtualNetworks/peer/action'"),
+			expected:              true,
+			expectedApplicationID: "f4b2e8a6-3c1d-4e5f-9a7b-8c6d5e4f3a2b",
+		},
+		"azure authorization failed without client": {
+			err:      createPeeringErr(http.StatusBadRequest, codes.FailedPrecondition, "AuthorizationFailed"),
+			expected: true,
+		},
+		"permission denied naming a required action": {
+			err:      createPeeringErr(http.StatusForbidden, codes.PermissionDenied, "not authorized to perform Microsoft.Network/virtualNetworks/virtualNetworkPeerings/write"),
+			expected: true,
+		},
+		"permission denied code naming a required action": {
+			err:      createPeeringErr(http.StatusBadRequest, codes.PermissionDenied, "not authorized to perform Microsoft.Network/virtualNetworks/peer/action"),
+			expected: true,
+		},
+		"permission denied without a required action": {
+			err: createPeeringErr(http.StatusForbidden, codes.PermissionDenied, "principal does not have permission"),
+		},
+		"required action without permission denied": {
+			err: createPeeringErr(http.StatusBadRequest, codes.InvalidArgument, "unable to perform Microsoft.Network/virtualNetworks/peer/action"),
+		},
+		"vnet not found": {
+			err: createPeeringErr(http.StatusNotFound, codes.NotFound, "ResourceNotFound: The Resource 'Microsoft.Network/virtualNetworks/vnet' under resource group 'rg' was not found"),
+		},
+		"not an api error": {
+			err: errors.New("AuthorizationFailed"),
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			applicationID, ok := AzurePeeringPermissionError(tc.err)
+			r.Equal(tc.expected, ok)
+			r.Equal(tc.expectedApplicationID, applicationID)
+		})
+	}
+}
+
+// testNotFoundPeeringClient is a network client whose GetPeering reports the
+// peering connection as not found for the first notFound calls, or fails with
+// err if it is set.
+type testNotFoundPeeringClient struct {
+	network_service.ClientService
+
+	notFound int
+	err      error
+	calls    int
+}
+
+func (c *testNotFoundPeeringClient) GetPeering(params *network_service.GetPeeringParams, _ runtime.ClientAuthInfoWriter, _ ...network_service.ClientOption) (*network_service.GetPeeringOK, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	if c.calls <= c.notFound {
+		return nil, network_service.NewGetPeeringDefault(http.StatusNotFound)
+	}
+
+	return &network_service.GetPeeringOK{
+		Payload: &networkmodels.HashicorpCloudNetwork20200907GetPeeringResponse{
+			Peering: &networkmodels.HashicorpCloudNetwork20200907Peering{ID: params.ID},
+		},
+	}, nil
+}
+
+func TestGetPeeringAfterCreate(t *testing.T) {
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: "f709ec73-55d4-46d8-897d-816ebba28778",
+		ProjectID:      "e20ad934-b88a-4897-a58e-d8318dd43cc3",
+	}
+
+	t.Run("not found is retried", func(t *testing.T) {
+		r := require.New(t)
+
+		network := &testNotFoundPeeringClient{notFound: 2}
+		client := &Client{
+			Config:  ClientConfig{PollInterval: time.Millisecond},
+			Network: network,
+		}
+
+		peering, err := GetPeeringAfterCreate(context.Background(), client, "test-peering", "test-hvn", loc)
+		r.NoError(err)
+		r.Equal("test-peering", peering.ID)
+		r.Equal(3, network.calls)
+	})
+
+	t.Run("other errors are not retried", func(t *testing.T) {
+		r := require.New(t)
+
+		network := &testNotFoundPeeringClient{err: network_service.NewGetPeeringDefault(http.StatusInternalServerError)}
+		client := &Client{
+			Config:  ClientConfig{PollInterval: time.Millisecond},
+			Network: network,
+		}
+
+		_, err := GetPeeringAfterCreate(context.Background(), client, "test-peering", "test-hvn", loc)
+		r.Error(err)
+		r.Equal(1, network.calls)
+	})
+}
+
+// testListPeeringsClient is a network client whose ListPeerings returns one
+// page per element of pages.
+type testListPeeringsClient struct {
+	network_service.ClientService
+
+	pages [][]*networkmodels.HashicorpCloudNetwork20200907Peering
+}
+
+func (c *testListPeeringsClient) ListPeerings(params *network_service.ListPeeringsParams, _ runtime.ClientAuthInfoWriter, _ ...network_service.ClientOption) (*network_service.ListPeeringsOK, error) {
+	page := 0
+	if params.PaginationNextPageToken != nil {
+		page = int((*params.PaginationNextPageToken)[0] - '0')
+	}
+
+	pagination := &sharedmodels.HashicorpCloudCommonPaginationResponse{}
+	if page+1 < len(c.pages) {
+		pagination.NextPageToken = string(rune('0' + page + 1))
+	}
+
+	return &network_service.ListPeeringsOK{
+		Payload: &networkmodels.HashicorpCloudNetwork20200907ListPeeringsResponse{
+			Peerings:   c.pages[page],
+			Pagination: pagination,
+		},
+	}, nil
+}
+
+func TestGetPeeringByAzureID(t *testing.T) {
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: "f709ec73-55d4-46d8-897d-816ebba28778",
+		ProjectID:      "e20ad934-b88a-4897-a58e-d8318dd43cc3",
+	}
+	azurePeeringID := "/subscriptions/2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b/resourceGroups/hcp-rg/providers/Microsoft.Network/virtualNetworks/hcp-vnet/virtualNetworkPeerings/test-peering"
+
+	client := &Client{
+		Network: &testListPeeringsClient{
+			pages: [][]*networkmodels.HashicorpCloudNetwork20200907Peering{
+				{
+					{ID: "pending-peering"},
+					{ID: "other-peering", ProviderPeeringID: azurePeeringID + "-other"},
+				},
+				{
+					{ID: "test-peering", ProviderPeeringID: azurePeeringID},
+				},
+			},
+		},
+	}
+
+	tcs := map[string]struct {
+		azurePeeringID    string
+		expectedPeeringID string
+		expectedError     error
+	}{
+		"match on a later page": {
+			azurePeeringID:    azurePeeringID,
+			expectedPeeringID: "test-peering",
+		},
+		"case-insensitive match": {
+			azurePeeringID:    strings.ToLower(azurePeeringID),
+			expectedPeeringID: "test-peering",
+		},
+		"no match": {
+			azurePeeringID: azurePeeringID + "-missing",
+			expectedError:  ErrPeeringNotFound,
+		},
+		"peering connections without an Azure peering ID don't match": {
+			azurePeeringID: "",
+			expectedError:  ErrPeeringNotFound,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			peering, err := GetPeeringByAzureID(context.Background(), client, tc.azurePeeringID, "test-hvn", loc)
+			if tc.expectedError != nil {
+				r.ErrorIs(err, tc.expectedError)
+				return
+			}
+			r.NoError(err)
+			r.Equal(tc.expectedPeeringID, peering.ID)
+		})
+	}
+}
+
+func TestAzurePeeringAcceptanceCommand(t *testing.T) {
+	r := require.New(t)
+
+	peering := &networkmodels.HashicorpCloudNetwork20200907Peering{
+		ID: "test-peering",
+		Target: &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{
+			AzureTarget: &networkmodels.HashicorpCloudNetwork20200907AzurePeeringTarget{
+				SubscriptionID:    "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b",
+				ResourceGroupName: "test-rg",
+				VnetName:          "test-vnet",
+			},
+		},
+	}
+
+	// No command can be built until HCP has assigned an application.
+	r.Empty(AzurePeeringAcceptanceCommand(peering))
+
+	peering.Target.AzureTarget.ApplicationID = "5c1d6f3a-9e2b-4a7c-8d0f-3b6e1a4c7d9e"
+	vnetID := "/subscriptions/2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b/resourceGroups/test-rg/providers/Microsoft.Network/virtualNetworks/test-vnet"
+	r.Equal(`az account set --subscription 2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b
+az ad sp create --id 5c1d6f3a-9e2b-4a7c-8d0f-3b6e1a4c7d9e
+az role definition create --role-definition '{"Name":"hcp-hvn-peering-test-peering","Actions":["Microsoft.Network/virtualNetworks/peer/action","Microsoft.Network/virtualNetworks/virtualNetworkPeerings/read","Microsoft.Network/virtualNetworks/virtualNetworkPeerings/write"],"AssignableScopes":["`+vnetID+`"]}'
+az role assignment create --assignee 5c1d6f3a-9e2b-4a7c-8d0f-3b6e1a4c7d9e --role hcp-hvn-peering-test-peering --scope `+vnetID, AzurePeeringAcceptanceCommand(peering))
+}
+
+func TestAzurePeeringAcceptanceDetailsJSON(t *testing.T) {
+	r := require.New(t)
+
+	peering := &networkmodels.HashicorpCloudNetwork20200907Peering{
+		ID: "test-peering",
+		Target: &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{
+			AzureTarget: &networkmodels.HashicorpCloudNetwork20200907AzurePeeringTarget{
+				ApplicationID:     "5c1d6f3a-9e2b-4a7c-8d0f-3b6e1a4c7d9e",
+				SubscriptionID:    "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b",
+				TenantID:          "0d7b3c1e-8a4f-4e6d-b2c9-5f1a3e7d9c2b",
+				ResourceGroupName: "test-rg",
+				VnetName:          "test-vnet",
+			},
+		},
+	}
+
+	var details map[string]string
+	r.NoError(json.Unmarshal([]byte(AzurePeeringAcceptanceDetailsJSON(peering)), &details))
+	r.Equal(map[string]string{
+		"application_id":           "5c1d6f3a-9e2b-4a7c-8d0f-3b6e1a4c7d9e",
+		"peer_subscription_id":     "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b",
+		"peer_tenant_id":           "0d7b3c1e-8a4f-4e6d-b2c9-5f1a3e7d9c2b",
+		"peer_resource_group_name": "test-rg",
+		"peer_vnet_name":           "test-vnet",
+		"peer_vnet_id":             "/subscriptions/2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b/resourceGroups/test-rg/providers/Microsoft.Network/virtualNetworks/test-vnet",
+	}, details)
+}
+
+func TestPeeringRawJSON(t *testing.T) {
+	r := require.New(t)
+
+	peering := &networkmodels.HashicorpCloudNetwork20200907Peering{
+		ID: "test-peering",
+		Hvn: &sharedmodels.HashicorpCloudLocationLink{
+			ID:   "test-hvn",
+			Type: "hashicorp.network.hvn",
+			Location: &sharedmodels.HashicorpCloudLocationLocation{
+				OrganizationID: "f709ec73-55d4-46d8-897d-816ebba28778",
+				ProjectID:      "e20ad934-b88a-4897-a58e-d8318dd43cc3",
+			},
+		},
+		Target: &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{
+			AzureTarget: &networkmodels.HashicorpCloudNetwork20200907AzurePeeringTarget{
+				ApplicationID: "f4b2e8a6-3c1d-4e5f-9a7b-8c6d5e4f3a2b",
+				VnetName:      "test-vnet",
+			},
+		},
+		State:     networkmodels.HashicorpCloudNetwork20200907PeeringStatePENDINGACCEPTANCE.Pointer(),
+		CreatedAt: strfmt.DateTime(time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)),
+	}
+
+	rawJSON, err := PeeringRawJSON(peering)
+	r.NoError(err)
+
+	var decoded struct {
+		ID     string `json:"id"`
+		State  string `json:"state"`
+		Target struct {
+			AzureTarget struct {
+				ApplicationID string `json:"application_id"`
+				VnetName      string `json:"vnet_name"`
+			} `json:"azure_target"`
+		} `json:"target"`
+	}
+	r.NoError(json.Unmarshal([]byte(rawJSON), &decoded))
+	r.Equal("test-peering", decoded.ID)
+	r.Equal("PENDING_ACCEPTANCE", decoded.State)
+	r.Equal("f4b2e8a6-3c1d-4e5f-9a7b-8c6d5e4f3a2b", decoded.Target.AzureTarget.ApplicationID)
+	r.Equal("test-vnet", decoded.Target.AzureTarget.VnetName)
+}
+
+func TestRedactSensitiveFields(t *testing.T) {
+	var fields interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+  "id": "test-peering",
+  "target": {"azure_target": {"client_secret": "s3cr3t", "vnet_name": "test-vnet"}},
+  "tokens": [{"value": "t0k3n"}],
+  "links": [{"access_token": "t0k3n", "id": "link"}]
+}`), &fields))
+
+	require.Equal(t, map[string]interface{}{
+		"id": "test-peering",
+		"target": map[string]interface{}{
+			"azure_target": map[string]interface{}{"client_secret": "REDACTED", "vnet_name": "test-vnet"},
+		},
+		"tokens": "REDACTED",
+		"links":  []interface{}{map[string]interface{}{"access_token": "REDACTED", "id": "link"}},
+	}, redactSensitiveFields(fields))
+}
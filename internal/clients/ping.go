@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-iam/stable/2019-12-10/client/iam_service"
+)
+
+// PingErrorKind is the reason why Ping failed.
+type PingErrorKind string
+
+const (
+	// PingErrorAuth is reported when the HCP API rejected the credentials,
+	// because they are invalid, expired or not authorized.
+	PingErrorAuth PingErrorKind = "auth"
+
+	// PingErrorNetwork is reported when no response was received from the HCP
+	// API, e.g. because it couldn't be reached, or no access token could be
+	// obtained for the request.
+	PingErrorNetwork PingErrorKind = "network"
+
+	// PingErrorAPI is reported when the HCP API was reached and accepted the
+	// credentials, but failed to serve the request.
+	PingErrorAPI PingErrorKind = "api"
+)
+
+// PingError is returned by Ping when the HCP API can't be reached with the
+// client's credentials.
+type PingError struct {
+	Kind PingErrorKind
+	Err  error
+}
+
+func (e *PingError) Error() string {
+	switch e.Kind {
+	case PingErrorAuth:
+		return fmt.Sprintf("%s: %v", AuthErrorMessage, e.Err)
+	case PingErrorNetwork:
+		return fmt.Sprintf("unable to reach the HCP API: %v", e.Err)
+	default:
+		return fmt.Sprintf("the HCP API failed to respond: %v", e.Err)
+	}
+}
+
+func (e *PingError) Unwrap() error {
+	return e.Err
+}
+
+// Ping checks that the HCP API can be reached and accepts the client's
+// credentials, by making a cheap authenticated request for the identity of
+// the caller. It returns nil if it can, and a *PingError otherwise.
+func Ping(ctx context.Context, client *Client) error {
+	params := iam_service.NewIamServiceGetCallerIdentityParamsWithContext(ctx)
+	if _, err := client.IAM.IamServiceGetCallerIdentity(params, nil); err != nil {
+		return &PingError{Kind: pingErrorKind(err), Err: err}
+	}
+
+	return nil
+}
+
+// pingErrorKind returns the kind of the error returned by the request made by
+// Ping.
+func pingErrorKind(err error) PingErrorKind {
+	if IsAuthError(err) {
+		return PingErrorAuth
+	}
+	if _, ok := ParseAPIError(err); ok {
+		return PingErrorAPI
+	}
+
+	// No response was received from the HCP API, e.g. because its address
+	// couldn't be resolved, or the connection failed or timed out.
+	return PingErrorNetwork
+}
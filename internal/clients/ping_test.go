@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	cloud_iam "github.com/hashicorp/hcp-sdk-go/clients/cloud-iam/stable/2019-12-10/client"
+	"github.com/stretchr/testify/require"
+)
+
+// roundTripperFunc is an http.RoundTripper that calls the function.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// jsonResponse returns a round trip function that responds with the given
+// status code and JSON body.
+func jsonResponse(status int, body string) roundTripperFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    req,
+		}, nil
+	}
+}
+
+func TestPing(t *testing.T) {
+	tcs := map[string]struct {
+		transport    roundTripperFunc
+		expectedKind PingErrorKind
+	}{
+		"ok": {
+			transport: jsonResponse(http.StatusOK, `{"principal": {"id": "sp-id"}}`),
+		},
+		"unauthenticated": {
+			transport:    jsonResponse(http.StatusUnauthorized, `{"code": 16, "message": "unauthenticated"}`),
+			expectedKind: PingErrorAuth,
+		},
+		"permission denied": {
+			transport:    jsonResponse(http.StatusForbidden, `{"code": 7, "message": "permission denied"}`),
+			expectedKind: PingErrorAuth,
+		},
+		"connection refused": {
+			transport: func(*http.Request) (*http.Response, error) {
+				return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+			},
+			expectedKind: PingErrorNetwork,
+		},
+		"unresolvable host": {
+			transport: func(*http.Request) (*http.Response, error) {
+				return nil, &net.DNSError{Err: "no such host", Name: "api.cloud.hashicorp.com", IsNotFound: true}
+			},
+			expectedKind: PingErrorNetwork,
+		},
+		"server error": {
+			transport:    jsonResponse(http.StatusInternalServerError, `{"code": 13, "message": "internal error"}`),
+			expectedKind: PingErrorAPI,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			runtime := httptransport.NewWithClient("api.cloud.hashicorp.com", "", []string{"https"}, &http.Client{Transport: tc.transport})
+			client := &Client{IAM: cloud_iam.New(runtime, strfmt.Default).IamService}
+
+			err := Ping(context.Background(), client)
+			if tc.expectedKind == "" {
+				r.NoError(err)
+				return
+			}
+
+			var pingErr *PingError
+			r.ErrorAs(err, &pingErr)
+			r.Equal(tc.expectedKind, pingErr.Kind)
+		})
+	}
+}
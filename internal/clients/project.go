@@ -6,6 +6,7 @@ package clients
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/cenkalti/backoff/v4"
 
@@ -36,6 +37,17 @@ func GetParentOrganizationIDByProjectID(ctx context.Context, client *Client, pro
 	return project.Parent.ID, nil
 }
 
+// MultipleProjectsDetail describes how to choose between the projects of an
+// organization when no project is configured on the provider, listing them so
+// that one can be picked.
+func MultipleProjectsDetail(projects []*resourcemodels.HashicorpCloudResourcemanagerProject) string {
+	names := make([]string, len(projects))
+	for i, project := range projects {
+		names[i] = fmt.Sprintf("%s (%s)", project.ID, project.Name)
+	}
+	return fmt.Sprintf("Please configure which project to use with project_id in the HCP provider config block, or the HCP_PROJECT_ID environment variable. The organization has the projects: %s.", strings.Join(names, ", "))
+}
+
 func CreateProject(ctx context.Context, client *Client, name, organizationID string) (*resourcemodels.HashicorpCloudResourcemanagerProject, error) {
 	projectOrg := &resourcemodels.HashicorpCloudResourcemanagerResourceID{
 		ID:   organizationID,
@@ -57,6 +69,8 @@ func CreateProject(ctx context.Context, client *Client, name, organizationID str
 
 // CreateProjectWithRetry wraps the projects service client with an exponential backoff retry mechanism.
 func CreateProjectWithRetry(client *Client, params *project_service.ProjectServiceCreateParams) (*project_service.ProjectServiceCreateOK, error) {
+	params.Context = withoutRetries(params.Context)
+
 	var res *project_service.ProjectServiceCreateOK
 	op := func() error {
 		var err error
@@ -72,6 +86,8 @@ func CreateProjectWithRetry(client *Client, params *project_service.ProjectServi
 
 // SetProjectNameWithRetry wraps the projects service client with an exponential backoff retry mechanism.
 func SetProjectNameWithRetry(client *Client, params *project_service.ProjectServiceSetNameParams) (*project_service.ProjectServiceSetNameOK, error) {
+	params.Context = withoutRetries(params.Context)
+
 	var res *project_service.ProjectServiceSetNameOK
 	op := func() error {
 		var err error
@@ -87,6 +103,8 @@ func SetProjectNameWithRetry(client *Client, params *project_service.ProjectServ
 
 // SetProjectDescriptionWithRetry wraps the projects service client with an exponential backoff retry mechanism.
 func SetProjectDescriptionWithRetry(client *Client, params *project_service.ProjectServiceSetDescriptionParams) (*project_service.ProjectServiceSetDescriptionOK, error) {
+	params.Context = withoutRetries(params.Context)
+
 	var res *project_service.ProjectServiceSetDescriptionOK
 	op := func() error {
 		var err error
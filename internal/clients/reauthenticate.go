@@ -0,0 +1,164 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	hcpConfig "github.com/hashicorp/hcp-sdk-go/config"
+	"golang.org/x/oauth2"
+)
+
+// tokenInvalidator drops access tokens that HCP rejected, so that the requests
+// they were sent with can be retried with new ones.
+type tokenInvalidator interface {
+	invalidate(accessToken string)
+}
+
+// reauthenticatingHCPConfig is an HCP config whose access tokens can be
+// invalidated. The HCP config caches access tokens until they expire, both in
+// memory and in the token cache file it shares with the HCP CLI, so an access
+// token that HCP rejects before then, e.g. because it was revoked, would keep
+// being sent. Invalidating it drops it from the token cache file and replaces
+// the token source with a new one, so that a new access token is fetched.
+type reauthenticatingHCPConfig struct {
+	hcpConfig.HCPConfig
+
+	// mu guards source, and serializes getting tokens with invalidating them.
+	mu     sync.Mutex
+	source oauth2.TokenSource
+
+	// newSource returns a token source that doesn't have any token cached in
+	// memory.
+	newSource func() (oauth2.TokenSource, error)
+
+	// dropCachedToken removes the access token from the token cache file.
+	dropCachedToken func(accessToken string) error
+}
+
+// Token implements oauth2.TokenSource.
+func (c *reauthenticatingHCPConfig) Token() (*oauth2.Token, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.source.Token()
+}
+
+// invalidate implements tokenInvalidator. Requests that are in flight
+// concurrently may be rejected with the same access token, so it is only
+// dropped if it is still the current one.
+func (c *reauthenticatingHCPConfig) invalidate(accessToken string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if current, err := c.source.Token(); err == nil && current.AccessToken != accessToken {
+		return
+	}
+
+	if c.dropCachedToken != nil {
+		if err := c.dropCachedToken(accessToken); err != nil {
+			log.Printf("[WARN] Unable to remove the rejected access token from the token cache: %v", err)
+		}
+	}
+
+	source, err := c.newSource()
+	if err != nil {
+		log.Printf("[WARN] Unable to create a new token source: %v", err)
+		return
+	}
+	c.source = source
+}
+
+// dropCachedToken removes the entries of the token cache file that hold the
+// access token. The file is keyed by credential type and identifier, so any
+// entry with the access token is removed, wherever it is.
+//
+// The HCP SDK's token sources read the file on every call, so a new token
+// source alone would return the rejected access token again. The file is shared
+// with the HCP CLI and other processes, and the SDK's own writes to it aren't
+// synchronized with this one, so the file is replaced atomically, which at
+// least stops them from ever reading it half written. A concurrent update
+// that is lost only costs its writer a new access token.
+func dropCachedToken(cacheFile, accessToken string) error {
+	contents, err := os.ReadFile(cacheFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	var cache map[string]interface{}
+	if err := json.Unmarshal(contents, &cache); err != nil {
+		return err
+	}
+
+	if !dropAccessToken(cache, accessToken) {
+		return nil
+	}
+
+	contents, err = json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	return replaceFile(cacheFile, contents)
+}
+
+// replaceFile atomically replaces the contents of the file, by writing them to
+// a temporary file in the same directory and renaming it over the file.
+func replaceFile(name string, contents []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(name), filepath.Base(name)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(contents); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// dropAccessToken removes the entries of entries, and of the maps nested in
+// it, whose access_token is accessToken. It returns whether any was removed.
+func dropAccessToken(entries map[string]interface{}, accessToken string) bool {
+	dropped := false
+	for key, value := range entries {
+		entry, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if entry["access_token"] == accessToken {
+			delete(entries, key)
+			dropped = true
+		} else if dropAccessToken(entry, accessToken) {
+			dropped = true
+		}
+	}
+
+	return dropped
+}
+
+// rejectedAccessToken returns the access token that the request of resp was
+// sent with, or an empty string if it wasn't sent with one.
+func rejectedAccessToken(resp *http.Response) string {
+	if resp.Request == nil {
+		return ""
+	}
+
+	_, token, _ := strings.Cut(resp.Request.Header.Get("Authorization"), " ")
+	return token
+}
@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestReauthenticatingHCPConfig_invalidate(t *testing.T) {
+	r := require.New(t)
+
+	minter := &mintingTokenSource{}
+	tokens := &reauthenticatingHCPConfig{
+		source: oauth2.ReuseTokenSource(nil, minter),
+		newSource: func() (oauth2.TokenSource, error) {
+			return oauth2.ReuseTokenSource(nil, minter), nil
+		},
+	}
+
+	token, err := tokens.Token()
+	r.NoError(err)
+	r.Equal("token-1", token.AccessToken)
+
+	// Requests rejected concurrently with the same token only replace it once.
+	tokens.invalidate("token-1")
+	tokens.invalidate("token-1")
+	token, err = tokens.Token()
+	r.NoError(err)
+	r.Equal("token-2", token.AccessToken)
+	r.EqualValues(2, minter.tokens.Load())
+}
+
+func TestDropCachedToken(t *testing.T) {
+	r := require.New(t)
+
+	cacheFile := filepath.Join(t.TempDir(), "creds-cache.json")
+	r.NoError(dropCachedToken(cacheFile, "rejected-token"))
+
+	r.NoError(os.WriteFile(cacheFile, []byte(`{
+  "login": {"access_token": "login-token", "refresh_token": "refresh"},
+  "service_principals": {
+    "client-a": {"access_token": "rejected-token", "access_token_expiry": "2030-01-01T00:00:00Z"},
+    "client-b": {"access_token": "other-token", "access_token_expiry": "2030-01-01T00:00:00Z"}
+  },
+  "workloads": {}
+}`), 0600))
+	r.NoError(dropCachedToken(cacheFile, "rejected-token"))
+
+	contents, err := os.ReadFile(cacheFile)
+	r.NoError(err)
+	r.JSONEq(`{
+  "login": {"access_token": "login-token", "refresh_token": "refresh"},
+  "service_principals": {
+    "client-b": {"access_token": "other-token", "access_token_expiry": "2030-01-01T00:00:00Z"}
+  },
+  "workloads": {}
+}`, string(contents))
+
+	// The file is replaced, so no temporary file is left behind, and it stays
+	// private.
+	entries, err := os.ReadDir(filepath.Dir(cacheFile))
+	r.NoError(err)
+	r.Len(entries, 1)
+	info, err := os.Stat(cacheFile)
+	r.NoError(err)
+	r.Equal(os.FileMode(0600), info.Mode().Perm())
+}
@@ -10,6 +10,8 @@ import (
 	"strings"
 
 	"github.com/go-openapi/runtime"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"google.golang.org/grpc/codes"
 )
 
 // IsResponseCodeNotFound takes an error returned from a client service
@@ -43,9 +45,93 @@ func IsResponseForbidden(err error) bool {
 	}
 }
 
+// IsResponseCodeConflict takes an error returned from a client service
+// request, and returns true if the response code was 409 conflict, or if the
+// service reported that the resource already exists.
+func IsResponseCodeConflict(err error) bool {
+	if apiErr, ok := ParseAPIError(err); ok {
+		return apiErr.HTTPCode == http.StatusConflict || apiErr.GRPCCode == codes.AlreadyExists
+	}
+
+	return strings.Contains(err.Error(), fmt.Sprintf("[%d]", http.StatusConflict))
+}
+
 // ErrorWithCode is an interface wrapping the error interface
 // to also return the response status code.
 type ErrorWithCode interface {
 	error
 	Code() int
 }
+
+// APIError holds the status codes and message of a failed HCP API request,
+// which are needed to investigate the failure.
+type APIError struct {
+	// HTTPCode is the HTTP status code of the response.
+	HTTPCode int
+
+	// GRPCCode is the gRPC status code reported by the service, or codes.OK
+	// if the response didn't include one.
+	GRPCCode codes.Code
+
+	// Message is the error message reported by the service, or the HTTP
+	// status text if the response didn't include one.
+	Message string
+}
+
+// grpcGatewayErrorResponse is implemented by the default responses of the
+// services that report errors as grpc-gateway runtime errors.
+type grpcGatewayErrorResponse interface {
+	GetPayload() *sharedmodels.GrpcGatewayRuntimeError
+}
+
+// rpcStatusErrorResponse is implemented by the default responses of the
+// services that report errors as google.rpc.Status.
+type rpcStatusErrorResponse interface {
+	GetPayload() *sharedmodels.GoogleRPCStatus
+}
+
+// ParseAPIError extracts the status codes and message from an error returned
+// by a client service request. It returns false if the error isn't the result
+// of an unsuccessful response from the HCP API.
+func ParseAPIError(err error) (*APIError, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	var codeErr ErrorWithCode
+	if !errors.As(err, &codeErr) {
+		var runtimeErr *runtime.APIError
+		if !errors.As(err, &runtimeErr) {
+			return nil, false
+		}
+
+		return &APIError{
+			HTTPCode: runtimeErr.Code,
+			Message:  http.StatusText(runtimeErr.Code),
+		}, true
+	}
+
+	apiErr := &APIError{HTTPCode: codeErr.Code()}
+
+	switch res := codeErr.(type) {
+	case grpcGatewayErrorResponse:
+		if payload := res.GetPayload(); payload != nil {
+			apiErr.GRPCCode = codes.Code(payload.Code)
+			apiErr.Message = payload.Message
+			if apiErr.Message == "" {
+				apiErr.Message = payload.Error
+			}
+		}
+	case rpcStatusErrorResponse:
+		if payload := res.GetPayload(); payload != nil {
+			apiErr.GRPCCode = codes.Code(payload.Code)
+			apiErr.Message = payload.Message
+		}
+	}
+
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(apiErr.HTTPCode)
+	}
+
+	return apiErr, true
+}
@@ -0,0 +1,152 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/go-openapi/runtime"
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/client/network_service"
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/client/organization_service"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestParseAPIError(t *testing.T) {
+	getPeeringErr := func(code int, payload *sharedmodels.GrpcGatewayRuntimeError) error {
+		err := network_service.NewGetPeeringDefault(code)
+		err.Payload = payload
+		return err
+	}
+
+	tcs := map[string]struct {
+		err      error
+		expected *APIError
+	}{
+		"nil error": {
+			err:      nil,
+			expected: nil,
+		},
+		"unrelated error": {
+			err:      errors.New("connection reset by peer"),
+			expected: nil,
+		},
+		"grpc-gateway payload": {
+			err: getPeeringErr(http.StatusNotFound, &sharedmodels.GrpcGatewayRuntimeError{
+				Code:    int32(codes.NotFound),
+				Error:   "not found",
+				Message: "peering connection not found",
+			}),
+			expected: &APIError{
+				HTTPCode: http.StatusNotFound,
+				GRPCCode: codes.NotFound,
+				Message:  "peering connection not found",
+			},
+		},
+		"grpc-gateway payload without message": {
+			err: getPeeringErr(http.StatusConflict, &sharedmodels.GrpcGatewayRuntimeError{
+				Code:  int32(codes.FailedPrecondition),
+				Error: "peering connection is still being created",
+			}),
+			expected: &APIError{
+				HTTPCode: http.StatusConflict,
+				GRPCCode: codes.FailedPrecondition,
+				Message:  "peering connection is still being created",
+			},
+		},
+		"no payload": {
+			err: getPeeringErr(http.StatusBadGateway, nil),
+			expected: &APIError{
+				HTTPCode: http.StatusBadGateway,
+				GRPCCode: codes.OK,
+				Message:  "Bad Gateway",
+			},
+		},
+		"rpc status payload": {
+			err: func() error {
+				err := organization_service.NewOrganizationServiceGetDefault(http.StatusForbidden)
+				err.Payload = &sharedmodels.GoogleRPCStatus{
+					Code:    int32(codes.PermissionDenied),
+					Message: "principal does not have permission",
+				}
+				return err
+			}(),
+			expected: &APIError{
+				HTTPCode: http.StatusForbidden,
+				GRPCCode: codes.PermissionDenied,
+				Message:  "principal does not have permission",
+			},
+		},
+		"wrapped error": {
+			err: fmt.Errorf("error waiting for peering connection: %w", getPeeringErr(http.StatusInternalServerError, &sharedmodels.GrpcGatewayRuntimeError{
+				Code:    int32(codes.Internal),
+				Message: "internal error",
+			})),
+			expected: &APIError{
+				HTTPCode: http.StatusInternalServerError,
+				GRPCCode: codes.Internal,
+				Message:  "internal error",
+			},
+		},
+		"unexpected response": {
+			err: runtime.NewAPIError("unknown error", nil, http.StatusTeapot),
+			expected: &APIError{
+				HTTPCode: http.StatusTeapot,
+				GRPCCode: codes.OK,
+				Message:  "I'm a teapot",
+			},
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			apiErr, ok := ParseAPIError(tc.err)
+			r.Equal(tc.expected != nil, ok)
+			r.Equal(tc.expected, apiErr)
+		})
+	}
+}
+
+func TestIsResponseCodeConflict(t *testing.T) {
+	tcs := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"conflict": {
+			err:      network_service.NewCreatePeeringDefault(http.StatusConflict),
+			expected: true,
+		},
+		"already exists": {
+			err: func() error {
+				err := network_service.NewCreatePeeringDefault(http.StatusBadRequest)
+				err.Payload = &sharedmodels.GrpcGatewayRuntimeError{
+					Code:    int32(codes.AlreadyExists),
+					Message: "peering already exists",
+				}
+				return err
+			}(),
+			expected: true,
+		},
+		"not found": {
+			err:      network_service.NewCreatePeeringDefault(http.StatusNotFound),
+			expected: false,
+		},
+		"unrelated error": {
+			err:      errors.New("connection reset by peer"),
+			expected: false,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			require.Equal(t, tc.expected, IsResponseCodeConflict(tc.err))
+		})
+	}
+}
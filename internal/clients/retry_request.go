@@ -23,6 +23,10 @@ const (
 
 var errorCodesToRetry = [...]int{502, 503, 504}
 
+// retrySleep waits between the attempts of the retry loops. Tests replace it
+// to not wait.
+var retrySleep = time.Sleep
+
 // Helper to check what requests to retry based on the response HTTP code
 func shouldRetryErrorCode(errorCode int, errorCodesToRetry []int) bool {
 	for i := range errorCodesToRetry {
@@ -35,6 +39,8 @@ func shouldRetryErrorCode(errorCode int, errorCodesToRetry []int) bool {
 
 // Wraps the OrganizationServiceList function in a loop that supports retrying the GET request
 func RetryOrganizationServiceList(client *Client, params *organization_service.OrganizationServiceListParams) (*organization_service.OrganizationServiceListOK, error) {
+	params.Context = withoutRetries(params.Context)
+
 	resp, err := client.Organization.OrganizationServiceList(params, nil)
 
 	if err != nil {
@@ -51,7 +57,7 @@ func RetryOrganizationServiceList(client *Client, params *organization_service.O
 			// Avoid wasting time if we're not going to retry next loop cycle
 			if (counter + 1) != retryCount {
 				fmt.Printf("Error trying to get list of organizations. Retrying in %d seconds...", retryDelay*counter)
-				time.Sleep(time.Duration(retryDelay*counter) * time.Second)
+				retrySleep(time.Duration(retryDelay*counter) * time.Second)
 			}
 			counter++
 		}
@@ -61,6 +67,8 @@ func RetryOrganizationServiceList(client *Client, params *organization_service.O
 
 // Wraps the ProjectServiceList function in a loop that supports retrying the GET request
 func RetryProjectServiceList(client *Client, params *project_service.ProjectServiceListParams) (*project_service.ProjectServiceListOK, error) {
+	params.Context = withoutRetries(params.Context)
+
 	resp, err := client.Project.ProjectServiceList(params, nil)
 
 	if err != nil {
@@ -78,7 +86,7 @@ func RetryProjectServiceList(client *Client, params *project_service.ProjectServ
 			// Avoid wasting time if we're not going to retry next loop cycle
 			if (counter + 1) != retryCount {
 				fmt.Printf("Error trying to get list of projects. Retrying in %d seconds...", retryDelay*counter)
-				time.Sleep(time.Duration(retryDelay*counter) * time.Second)
+				retrySleep(time.Duration(retryDelay*counter) * time.Second)
 			}
 			counter++
 		}
@@ -88,6 +96,8 @@ func RetryProjectServiceList(client *Client, params *project_service.ProjectServ
 
 // Wraps the ProjectServiceGet function in a loop that supports retrying the GET request
 func RetryProjectServiceGet(client *Client, params *project_service.ProjectServiceGetParams) (*project_service.ProjectServiceGetOK, error) {
+	params.Context = withoutRetries(params.Context)
+
 	resp, err := client.Project.ProjectServiceGet(params, nil)
 
 	if err != nil {
@@ -105,7 +115,7 @@ func RetryProjectServiceGet(client *Client, params *project_service.ProjectServi
 			// Avoid wasting time if we're not going to retry next loop cycle
 			if (counter + 1) != retryCount {
 				fmt.Printf("Error trying to get configured project. Retrying in %d seconds...", retryDelay*counter)
-				time.Sleep(time.Duration(retryDelay*counter) * time.Second)
+				retrySleep(time.Duration(retryDelay*counter) * time.Second)
 			}
 			counter++
 		}
@@ -115,6 +125,8 @@ func RetryProjectServiceGet(client *Client, params *project_service.ProjectServi
 
 // Wraps the BillingServiceUpdate function in a loop that supports retrying the PUT request
 func RetryBillingServiceUpdate(client *Client, params *billing.BillingAccountServiceUpdateParams) (*billing.BillingAccountServiceUpdateOK, error) {
+	params.Context = withoutRetries(params.Context)
+
 	resp, err := client.Billing.BillingAccountServiceUpdate(params, nil)
 
 	if err != nil {
@@ -132,7 +144,7 @@ func RetryBillingServiceUpdate(client *Client, params *billing.BillingAccountSer
 			// Avoid wasting time if we're not going to retry next loop cycle
 			if (counter + 1) != retryCount {
 				fmt.Printf("Error trying to update billing account. Retrying in %d seconds...", retryDelay*counter)
-				time.Sleep(time.Duration(retryDelay*counter) * time.Second)
+				retrySleep(time.Duration(retryDelay*counter) * time.Second)
 			}
 			counter++
 		}
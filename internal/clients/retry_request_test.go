@@ -3,7 +3,18 @@
 
 package clients
 
-import "testing"
+import (
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	cloud_resource_manager "github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/client"
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/client/organization_service"
+	"github.com/stretchr/testify/require"
+)
 
 func TestShouldRetryErrorCode(t *testing.T) {
 	errorCodesToRetry := []int{502, 503, 504}
@@ -18,3 +29,28 @@ func TestShouldRetryErrorCode(t *testing.T) {
 		t.Errorf("shouldRetryErrorCode(503, []int{502, 503, 504}[:]) = %v; want true", shouldSucceed)
 	}
 }
+
+func TestRetryOrganizationServiceList_attempts(t *testing.T) {
+	r := require.New(t)
+
+	sleep := retrySleep
+	retrySleep = func(time.Duration) {}
+	t.Cleanup(func() { retrySleep = sleep })
+
+	var attempts atomic.Int32
+	unavailable := jsonResponse(http.StatusServiceUnavailable, `{"code": 14, "message": "unavailable"}`)
+	rt := httptransport.New("api.cloud.hashicorp.com", "", []string{"https"})
+	transport := newTestTransport(ClientConfig{MaxRetries: DefaultMaxRetries})
+	transport.base = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		attempts.Add(1)
+		return unavailable(req)
+	})
+	rt.Transport = transport
+	client := &Client{Organization: cloud_resource_manager.New(rt, strfmt.Default).OrganizationService}
+
+	// The loop retries the request itself, so the transport doesn't, and
+	// the retries don't stack.
+	_, err := RetryOrganizationServiceList(client, organization_service.NewOrganizationServiceListParams())
+	r.Error(err)
+	r.Equal(int32(retryCount), attempts.Load())
+}
@@ -6,6 +6,7 @@ package clients
 import (
 	"context"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/client/network_service"
@@ -60,6 +61,11 @@ func tgwAttachmentRefreshState(ctx context.Context, client *Client, tgwAttachmen
 // WaitForTGWAttachmentToBeActive will poll the GET TGW attachment endpoint
 // until the state is ACTIVE, ctx is canceled, or an error occurs.
 func WaitForTGWAttachmentToBeActive(ctx context.Context, client *Client, tgwAttachmentID string, hvnID string, loc *sharedmodels.HashicorpCloudLocationLocation, timeout time.Duration) (*networkmodels.HashicorpCloudNetwork20200907TGWAttachment, error) {
+	if client.Config.SkipWaits {
+		log.Printf("[WARN] Not waiting for transit gateway attachment (%s) to become 'ACTIVE' because skip_waits is set", tgwAttachmentID)
+		return GetTGWAttachmentByID(ctx, client, tgwAttachmentID, hvnID, loc)
+	}
+
 	stateChangeConf := retry.StateChangeConf{
 		Pending: WaitForTGWAttachmentToBeActiveStates,
 		Target: []string{
@@ -67,7 +73,7 @@ func WaitForTGWAttachmentToBeActive(ctx context.Context, client *Client, tgwAtta
 		},
 		Refresh:      tgwAttachmentRefreshState(ctx, client, tgwAttachmentID, hvnID, loc),
 		Timeout:      timeout,
-		PollInterval: 5 * time.Second,
+		PollInterval: client.pollInterval(),
 	}
 
 	result, err := stateChangeConf.WaitForStateContext(ctx)
@@ -93,6 +99,11 @@ var WaitForTGWAttachmentToBeActiveStates = []string{
 // endpoint until the state is PENDING_ACCEPTANCE, ctx is canceled, or an error
 // occurs.
 func WaitForTGWAttachmentToBePendingAcceptance(ctx context.Context, client *Client, tgwAttachmentID string, hvnID string, loc *sharedmodels.HashicorpCloudLocationLocation, timeout time.Duration) (*networkmodels.HashicorpCloudNetwork20200907TGWAttachment, error) {
+	if client.Config.SkipWaits {
+		log.Printf("[WARN] Not waiting for transit gateway attachment (%s) to become 'PENDING_ACCEPTANCE' because skip_waits is set", tgwAttachmentID)
+		return GetTGWAttachmentByID(ctx, client, tgwAttachmentID, hvnID, loc)
+	}
+
 	stateChangeConf := retry.StateChangeConf{
 		Pending: []string{
 			TgwAttachmentStateCreating,
@@ -102,7 +113,7 @@ func WaitForTGWAttachmentToBePendingAcceptance(ctx context.Context, client *Clie
 		},
 		Refresh:      tgwAttachmentRefreshState(ctx, client, tgwAttachmentID, hvnID, loc),
 		Timeout:      timeout,
-		PollInterval: 5 * time.Second,
+		PollInterval: client.pollInterval(),
 	}
 
 	result, err := stateChangeConf.WaitForStateContext(ctx)
@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// RequestTimeoutError is returned for a single request to HCP that didn't
+// complete within the provider's request_timeout. It is a
+// context.DeadlineExceeded error, so it is retried like other timeouts.
+type RequestTimeoutError struct {
+	Method  string
+	Path    string
+	Timeout time.Duration
+}
+
+func (e *RequestTimeoutError) Error() string {
+	return fmt.Sprintf("%s %s didn't complete within request_timeout (%s)", e.Method, e.Path, e.Timeout)
+}
+
+// Is implements errors.Is.
+func (e *RequestTimeoutError) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
+
+// TimeoutSummary returns a summary and a detail describing the timeout that
+// caused err, so that users can tell which setting to tune:
+//
+//   - a single request timed out, after the provider's request_timeout;
+//   - waiting for a resource to reach a state timed out; or
+//   - the operation as a whole timed out, after the resource's timeout.
+//
+// ok is false if err wasn't caused by a timeout.
+func TimeoutSummary(err error) (summary string, detail string, ok bool) {
+	var requestErr *RequestTimeoutError
+	if errors.As(err, &requestErr) {
+		return fmt.Sprintf("a request to HCP timed out after %s (request_timeout)", requestErr.Timeout),
+			fmt.Sprintf("The request (%s %s) didn't complete within the provider's request_timeout of %s, even after being retried. "+
+				"If HCP is slow to respond, increase request_timeout in the provider configuration.", requestErr.Method, requestErr.Path, requestErr.Timeout),
+			true
+	}
+
+	var waitErr *retry.TimeoutError
+	if errors.As(err, &waitErr) {
+		return fmt.Sprintf("timed out after %s waiting for the operation to complete", waitErr.Timeout),
+			fmt.Sprintf("%v\n\nEach request to HCP succeeded, but the resource didn't reach the expected state within the resource's timeout of %s. "+
+				"If the operation routinely takes longer, increase it with the resource's timeouts block.", err, waitErr.Timeout),
+			true
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timed out waiting for the operation to complete",
+			fmt.Sprintf("%v\n\nThe operation didn't complete within the resource's timeout. "+
+				"If the operation routinely takes longer, increase it with the resource's timeouts block.", err),
+			true
+	}
+
+	return "", "", false
+}
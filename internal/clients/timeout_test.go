@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeoutSummary(t *testing.T) {
+	// A request that times out in the transport.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		<-req.Context().Done()
+	}))
+	defer srv.Close()
+	client := &http.Client{Transport: newTestTransport(ClientConfig{RequestTimeout: 20 * time.Millisecond})}
+	_, requestErr := client.Get(srv.URL + "/network/2020-09-07/peerings")
+
+	// A request whose context is done because the resource's timeout was
+	// reached isn't blamed on request_timeout.
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	client = &http.Client{Transport: newTestTransport(ClientConfig{RequestTimeout: time.Minute})}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL, nil)
+	require.NoError(t, err)
+	_, operationErr := client.Do(req)
+
+	tcs := map[string]struct {
+		err             error
+		expectedSummary string
+		expectedDetail  string
+		notTimeout      bool
+	}{
+		"request timeout": {
+			err:             fmt.Errorf("unable to get peering connection: %w", requestErr),
+			expectedSummary: "a request to HCP timed out after 20ms (request_timeout)",
+			expectedDetail:  "The request (GET /network/2020-09-07/peerings) didn't complete within the provider's request_timeout of 20ms",
+		},
+		"wait timeout": {
+			err: fmt.Errorf("error waiting for peering connection (test-peering) to become 'ACTIVE': %w", &retry.TimeoutError{
+				LastState:     "PENDING_ACCEPTANCE",
+				Timeout:       time.Minute,
+				ExpectedState: []string{"ACTIVE"},
+			}),
+			expectedSummary: "timed out after 1m0s waiting for the operation to complete",
+			expectedDetail:  "didn't reach the expected state within the resource's timeout of 1m0s",
+		},
+		"operation timeout": {
+			err:             operationErr,
+			expectedSummary: "timed out waiting for the operation to complete",
+			expectedDetail:  "increase it with the resource's timeouts block",
+		},
+		"not a timeout": {
+			err:        errors.New("unsupported protocol scheme"),
+			notTimeout: true,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			summary, detail, ok := TimeoutSummary(tc.err)
+			if tc.notTimeout {
+				r.False(ok)
+				return
+			}
+			r.True(ok)
+			r.Equal(tc.expectedSummary, summary)
+			r.Contains(detail, tc.expectedDetail)
+		})
+	}
+}
@@ -0,0 +1,406 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-provider-hcp/version"
+	"golang.org/x/oauth2"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a throttled or failed read is
+	// retried when max_retries isn't set in the provider configuration.
+	DefaultMaxRetries = 3
+
+	// MaxWriteRetries is the number of times a throttled write is retried, if
+	// max_retries allows it. Writes aren't idempotent, so they are retried
+	// conservatively, to limit the risk of applying them twice.
+	MaxWriteRetries = 1
+
+	// DefaultRequestTimeout is the maximum duration of a single request attempt
+	// when request_timeout isn't set in the provider configuration. It is 0,
+	// so that by default requests are only bounded by the resource timeouts.
+	DefaultRequestTimeout time.Duration = 0
+
+	// DefaultMaxIdleConns is the maximum number of idle connections to HCP
+	// kept open for reuse when max_idle_conns isn't set in the provider
+	// configuration.
+	DefaultMaxIdleConns = 100
+
+	// DefaultMaxIdleConnsPerHost is the maximum number of idle connections
+	// kept open per host when max_idle_conns_per_host isn't set in the
+	// provider configuration. Nearly all requests go to the same API host, so
+	// it allows twice Terraform's default parallelism of 10, leaving room for
+	// the requests of the resources that are waiting on operations.
+	DefaultMaxIdleConnsPerHost = 20
+)
+
+// transport is an http.RoundTripper that rate limits, times out, retries and
+// sets extra headers on the requests made to the HCP API.
+type transport struct {
+	base http.RoundTripper
+
+	// limiter throttles outgoing requests. It is nil if requests should not be
+	// rate limited.
+	limiter *rate.Limiter
+
+	// maxRetries bounds the retries of idempotent requests, and
+	// maxWriteRetries those of the others.
+	maxRetries      int
+	maxWriteRetries int
+	requestTimeout  time.Duration
+
+	// userAgent is the User-Agent header of every request. It is left as set
+	// by the API client if empty.
+	userAgent string
+
+	// extraHeaders are set on every request. They are added here rather than
+	// by the API client so that they are left out of its debug request logs.
+	extraHeaders http.Header
+
+	// newBackoff returns the backoff used between retries of a request.
+	newBackoff func() backoff.BackOff
+
+	// tokens invalidates the access tokens that HCP rejects. It is nil if
+	// access tokens can't be invalidated, in which case requests are retried
+	// with whichever token the token source returns.
+	tokens tokenInvalidator
+}
+
+// newTransport wraps the base http.RoundTripper with the rate limiting,
+// timeout, retry and extra header behavior specified in the client config.
+func newTransport(base http.RoundTripper, config ClientConfig) *transport {
+	t := &transport{
+		base:            base,
+		maxRetries:      config.MaxRetries,
+		maxWriteRetries: min(config.MaxRetries, MaxWriteRetries),
+		requestTimeout:  config.RequestTimeout,
+		userAgent:       userAgent(config.UserAgentSuffix),
+		newBackoff:      newBackoff,
+	}
+
+	if len(config.ExtraHeaders) > 0 {
+		t.extraHeaders = make(http.Header, len(config.ExtraHeaders))
+		for name, value := range config.ExtraHeaders {
+			t.extraHeaders.Set(name, value)
+		}
+	}
+
+	if config.RequestsPerSecond > 0 {
+		t.limiter = sharedLimiter(config.RequestsPerSecond)
+	}
+
+	return t
+}
+
+var (
+	// limiters are the rate limiters of the process, by requests per second.
+	// Each of the muxed providers creates its own client, as do resources
+	// that add module metadata to the source channel, so the rate limiter is
+	// shared by the clients configured with the same rate for them to make
+	// no more requests than configured in total.
+	limiters   = make(map[float64]*rate.Limiter)
+	limitersMu sync.Mutex
+)
+
+// sharedLimiter returns the rate limiter of the process for the given rate,
+// creating it if necessary.
+func sharedLimiter(requestsPerSecond float64) *rate.Limiter {
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+
+	if limiter, ok := limiters[requestsPerSecond]; ok {
+		return limiter
+	}
+
+	// Allow short bursts of up to a second's worth of requests, so that
+	// requests which are issued in parallel aren't unnecessarily serialized.
+	burst := int(requestsPerSecond)
+	if burst < 1 {
+		burst = 1
+	}
+	limiter := rate.NewLimiter(rate.Limit(requestsPerSecond), burst)
+	limiters[requestsPerSecond] = limiter
+	return limiter
+}
+
+// userAgent returns the User-Agent header of the requests made to HCP: the
+// provider's product token, followed by suffix if it is set.
+func userAgent(suffix string) string {
+	ua := "terraform-provider-hcp/" + version.ProviderVersion
+	if suffix != "" {
+		ua = strings.Join([]string{ua, suffix}, " ")
+	}
+	return ua
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.userAgent != "" || len(t.extraHeaders) > 0 {
+		// A RoundTripper must not modify the request it was given.
+		req = req.Clone(req.Context())
+		if t.userAgent != "" {
+			req.Header.Set("User-Agent", t.userAgent)
+		}
+		for name, values := range t.extraHeaders {
+			req.Header[name] = values
+		}
+	}
+
+	b := backoff.WithContext(t.newBackoff(), req.Context())
+	maxRetries := t.maxRetriesFor(req)
+	reauthenticated := false
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 {
+			var err error
+			if attemptReq, err = replayRequest(req); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.roundTrip(attemptReq)
+
+		// The access token can expire or be revoked while a request is in
+		// flight, e.g. during a long apply. The request is then rejected before
+		// being processed, so it is retried once, regardless of max_retries.
+		// The rejected token is invalidated first, as the HCP config would
+		// otherwise keep returning it until its expiry, so that the oauth2
+		// transport below sends the retry with a new one.
+		if !reauthenticated && shouldReauthenticate(req, resp, err) {
+			reauthenticated = true
+			log.Printf("[DEBUG] %s %s was rejected as unauthenticated, retrying with a new access token", req.Method, req.URL.Path)
+			if t.tokens != nil {
+				t.tokens.invalidate(rejectedAccessToken(resp))
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+
+			if attemptReq, err = replayRequest(req); err != nil {
+				return nil, err
+			}
+			resp, err = t.roundTrip(attemptReq)
+		}
+
+		if attempt >= maxRetries || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := b.NextBackOff()
+		if wait == backoff.Stop {
+			return resp, err
+		}
+
+		if err != nil {
+			log.Printf("[DEBUG] %s %s failed: %v, retrying in %s (attempt %d of %d)", req.Method, req.URL.Path, err, wait, attempt+1, maxRetries)
+		} else {
+			log.Printf("[DEBUG] %s %s returned %d, retrying in %s (attempt %d of %d)", req.Method, req.URL.Path, resp.StatusCode, wait, attempt+1, maxRetries)
+
+			// Drain the body so that the connection can be reused.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// noRetriesKey is the context key that marks requests which the transport
+// must not retry.
+type noRetriesKey struct{}
+
+// withoutRetries returns a context for requests that the transport must not
+// retry, because their callers retry them on the errors the transport would
+// retry on, so that the retries don't stack. A nil ctx is treated as
+// context.Background(), as it is by the API clients.
+func withoutRetries(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, noRetriesKey{}, true)
+}
+
+// maxRetriesFor returns the maximum number of times the request is retried.
+// Idempotent requests, such as reads, can safely be retried more than writes.
+func (t *transport) maxRetriesFor(req *http.Request) int {
+	if noRetries, _ := req.Context().Value(noRetriesKey{}).(bool); noRetries {
+		return 0
+	}
+	if isIdempotent(req) {
+		return t.maxRetries
+	}
+	return t.maxWriteRetries
+}
+
+// replayRequest returns a copy of the request to send again, with its body
+// rewound.
+func replayRequest(req *http.Request) (*http.Request, error) {
+	if req.GetBody == nil {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	replay := req.Clone(req.Context())
+	replay.Body = body
+	return replay, nil
+}
+
+// roundTrip performs a single, rate limited attempt of the request.
+func (t *transport) roundTrip(req *http.Request) (*http.Response, error) {
+	if t.limiter != nil {
+		if err := t.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+
+	if t.requestTimeout <= 0 {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.requestTimeout)
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		// Tell the request timing out apart from the caller's context being
+		// done, e.g. because the resource's timeout was reached.
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) && req.Context().Err() == nil {
+			err = &RequestTimeoutError{Method: req.Method, Path: req.URL.Path, Timeout: t.requestTimeout}
+		}
+		cancel()
+		return nil, err
+	}
+
+	// The timeout must also cover reading the body, so only cancel the context
+	// once the body has been closed.
+	resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// shouldRetry returns true if the request can be safely retried given the
+// response, or the error returned in place of one. Throttled requests were
+// rejected before being processed, so they are always retried, while requests
+// failing with a server or network error are only retried if they are
+// idempotent. Requests failing because of invalid or expired credentials are
+// not retried here, as they would keep failing; see shouldReauthenticate.
+func (t *transport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if !canReplay(req) {
+		return false
+	}
+
+	if err != nil {
+		if req.Context().Err() != nil || ClassifyError(err) != ErrorClassTransient {
+			return false
+		}
+		return isIdempotent(req)
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	return isIdempotent(req) && shouldRetryErrorCode(resp.StatusCode, errorCodesToRetry[:])
+}
+
+// shouldReauthenticate returns true if the request was rejected because its
+// access token is no longer valid, and can be sent again with a new one.
+func shouldReauthenticate(req *http.Request, resp *http.Response, err error) bool {
+	return err == nil && resp.StatusCode == http.StatusUnauthorized && canReplay(req)
+}
+
+// canReplay returns true if the request's body, if any, can be sent again.
+func canReplay(req *http.Request) bool {
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// isIdempotent returns true if the request can be sent several times without
+// side effects.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// cancelOnCloseBody cancels the context of a request once the response body
+// has been closed.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// configureConnectionPool sizes the connection pool of the *http.Transport at
+// the bottom of rt, the round tripper built by the HCP SDK, from the client
+// config. Limits that are 0 keep the SDK's defaults. It returns false if no
+// *http.Transport was found, in which case the SDK's defaults are used.
+func configureConnectionPool(rt http.RoundTripper, config ClientConfig) bool {
+	base, ok := baseTransport(rt)
+	if !ok {
+		return false
+	}
+
+	if config.MaxIdleConns > 0 {
+		base.MaxIdleConns = config.MaxIdleConns
+	}
+	if config.MaxIdleConnsPerHost > 0 {
+		base.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+	}
+	return true
+}
+
+// baseTransport returns the *http.Transport that rt wraps. The HCP SDK wraps
+// it in an OAuth2 transport and in a middleware round tripper, which isn't
+// exported, so the latter is unwrapped through its OriginalRoundTripper field.
+func baseTransport(rt http.RoundTripper) (*http.Transport, bool) {
+	for rt != nil {
+		switch t := rt.(type) {
+		case *http.Transport:
+			return t, true
+		case *oauth2.Transport:
+			rt = t.Base
+		default:
+			v := reflect.Indirect(reflect.ValueOf(rt))
+			if v.Kind() != reflect.Struct {
+				return nil, false
+			}
+			field := v.FieldByName("OriginalRoundTripper")
+			if !field.IsValid() || !field.CanInterface() {
+				return nil, false
+			}
+			next, ok := field.Interface().(http.RoundTripper)
+			if !ok {
+				return nil, false
+			}
+			rt = next
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,470 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	hcpConfig "github.com/hashicorp/hcp-sdk-go/config"
+	sdk "github.com/hashicorp/hcp-sdk-go/httpclient"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+	"golang.org/x/time/rate"
+)
+
+// newTestTransport returns a transport for the given config that doesn't wait
+// between retries.
+func newTestTransport(config ClientConfig) *transport {
+	t := newTransport(http.DefaultTransport, config)
+	t.newBackoff = func() backoff.BackOff {
+		return &backoff.ZeroBackOff{}
+	}
+	return t
+}
+
+func TestTransport_RateLimit(t *testing.T) {
+	r := require.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: newTestTransport(ClientConfig{RequestsPerSecond: 10})}
+
+	// The first 10 requests are served by the burst, the remaining 5 have to
+	// wait for the limiter to refill at 10 requests per second.
+	start := time.Now()
+	for i := 0; i < 15; i++ {
+		resp, err := client.Get(srv.URL)
+		r.NoError(err)
+		resp.Body.Close()
+	}
+	r.GreaterOrEqual(time.Since(start), 400*time.Millisecond)
+}
+
+func TestTransport_NoRateLimit(t *testing.T) {
+	r := require.New(t)
+
+	r.Nil(newTransport(http.DefaultTransport, ClientConfig{}).limiter)
+	r.NotNil(newTransport(http.DefaultTransport, ClientConfig{RequestsPerSecond: 0.5}).limiter)
+}
+
+func TestTransport_SharedRateLimit(t *testing.T) {
+	r := require.New(t)
+
+	// The muxed providers each create a client, which must share the rate.
+	first := newTransport(http.DefaultTransport, ClientConfig{RequestsPerSecond: 7})
+	second := newTransport(http.DefaultTransport, ClientConfig{RequestsPerSecond: 7})
+	r.Same(first.limiter, second.limiter)
+
+	other := newTransport(http.DefaultTransport, ClientConfig{RequestsPerSecond: 3})
+	r.NotSame(first.limiter, other.limiter)
+	r.Equal(rate.Limit(3), other.limiter.Limit())
+}
+
+func TestTransport_Retries(t *testing.T) {
+	tcs := map[string]struct {
+		method           string
+		status           int
+		maxRetries       int
+		expectedAttempts int32
+	}{
+		"throttled get is retried": {
+			method:           http.MethodGet,
+			status:           http.StatusTooManyRequests,
+			maxRetries:       3,
+			expectedAttempts: 4,
+		},
+		"throttled post is retried once": {
+			method:           http.MethodPost,
+			status:           http.StatusTooManyRequests,
+			maxRetries:       3,
+			expectedAttempts: 2,
+		},
+		"unavailable get is retried": {
+			method:           http.MethodGet,
+			status:           http.StatusServiceUnavailable,
+			maxRetries:       3,
+			expectedAttempts: 4,
+		},
+		"unavailable post is not retried": {
+			method:           http.MethodPost,
+			status:           http.StatusServiceUnavailable,
+			maxRetries:       3,
+			expectedAttempts: 1,
+		},
+		"not found is not retried": {
+			method:           http.MethodGet,
+			status:           http.StatusNotFound,
+			maxRetries:       3,
+			expectedAttempts: 1,
+		},
+		"retries disabled": {
+			method:           http.MethodGet,
+			status:           http.StatusTooManyRequests,
+			maxRetries:       0,
+			expectedAttempts: 1,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			var attempts atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				attempts.Add(1)
+				w.WriteHeader(tc.status)
+			}))
+			defer srv.Close()
+
+			client := &http.Client{Transport: newTestTransport(ClientConfig{MaxRetries: tc.maxRetries})}
+
+			req, err := http.NewRequest(tc.method, srv.URL, strings.NewReader("{}"))
+			r.NoError(err)
+
+			resp, err := client.Do(req)
+			r.NoError(err)
+			resp.Body.Close()
+
+			r.Equal(tc.status, resp.StatusCode)
+			r.Equal(tc.expectedAttempts, attempts.Load())
+		})
+	}
+}
+
+func TestTransport_MaxRetriesFor(t *testing.T) {
+	tcs := map[string]struct {
+		method             string
+		maxRetries         int
+		withoutRetries     bool
+		expectedMaxRetries int
+	}{
+		"get": {
+			method:             http.MethodGet,
+			maxRetries:         DefaultMaxRetries,
+			expectedMaxRetries: DefaultMaxRetries,
+		},
+		"head": {
+			method:             http.MethodHead,
+			maxRetries:         10,
+			expectedMaxRetries: 10,
+		},
+		"post": {
+			method:             http.MethodPost,
+			maxRetries:         DefaultMaxRetries,
+			expectedMaxRetries: MaxWriteRetries,
+		},
+		"put": {
+			method:             http.MethodPut,
+			maxRetries:         10,
+			expectedMaxRetries: MaxWriteRetries,
+		},
+		"delete": {
+			method:             http.MethodDelete,
+			maxRetries:         DefaultMaxRetries,
+			expectedMaxRetries: MaxWriteRetries,
+		},
+		"get with retries disabled": {
+			method:             http.MethodGet,
+			maxRetries:         0,
+			expectedMaxRetries: 0,
+		},
+		"post with retries disabled": {
+			method:             http.MethodPost,
+			maxRetries:         0,
+			expectedMaxRetries: 0,
+		},
+		"get retried by the caller": {
+			method:             http.MethodGet,
+			maxRetries:         DefaultMaxRetries,
+			withoutRetries:     true,
+			expectedMaxRetries: 0,
+		},
+		"post retried by the caller": {
+			method:             http.MethodPost,
+			maxRetries:         DefaultMaxRetries,
+			withoutRetries:     true,
+			expectedMaxRetries: 0,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.withoutRetries {
+				ctx = withoutRetries(ctx)
+			}
+			req, err := http.NewRequestWithContext(ctx, tc.method, "https://api.cloud.hashicorp.com", nil)
+			require.NoError(t, err)
+
+			tr := newTransport(http.DefaultTransport, ClientConfig{MaxRetries: tc.maxRetries})
+			require.Equal(t, tc.expectedMaxRetries, tr.maxRetriesFor(req))
+		})
+	}
+}
+
+func TestTransport_RequestTimeout(t *testing.T) {
+	r := require.New(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		select {
+		case <-req.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: newTestTransport(ClientConfig{RequestTimeout: 50 * time.Millisecond})}
+
+	_, err := client.Get(srv.URL)
+	r.ErrorIs(err, context.DeadlineExceeded)
+
+	var timeoutErr *RequestTimeoutError
+	r.ErrorAs(err, &timeoutErr)
+	r.Equal(50*time.Millisecond, timeoutErr.Timeout)
+	r.Equal(http.MethodGet, timeoutErr.Method)
+}
+
+// errRoundTripper is an http.RoundTripper that counts its calls and always
+// fails with err.
+type errRoundTripper struct {
+	err      error
+	attempts atomic.Int32
+}
+
+func (rt *errRoundTripper) RoundTrip(*http.Request) (*http.Response, error) {
+	rt.attempts.Add(1)
+	return nil, rt.err
+}
+
+func TestTransport_RetriesErrors(t *testing.T) {
+	tcs := map[string]struct {
+		method           string
+		err              error
+		expectedAttempts int32
+	}{
+		"connection reset get is retried": {
+			method:           http.MethodGet,
+			err:              syscall.ECONNRESET,
+			expectedAttempts: 4,
+		},
+		"connection reset post is not retried": {
+			method:           http.MethodPost,
+			err:              syscall.ECONNRESET,
+			expectedAttempts: 1,
+		},
+		"expired token is not retried": {
+			method:           http.MethodGet,
+			err:              &oauth2.RetrieveError{Response: &http.Response{StatusCode: http.StatusUnauthorized}},
+			expectedAttempts: 1,
+		},
+		"unrelated error is not retried": {
+			method:           http.MethodGet,
+			err:              errors.New("unsupported protocol scheme"),
+			expectedAttempts: 1,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			base := &errRoundTripper{err: tc.err}
+			tr := newTransport(base, ClientConfig{MaxRetries: 3})
+			tr.newBackoff = func() backoff.BackOff {
+				return &backoff.ZeroBackOff{}
+			}
+
+			req, err := http.NewRequest(tc.method, "https://api.cloud.hashicorp.com", strings.NewReader("{}"))
+			r.NoError(err)
+
+			_, err = tr.RoundTrip(req)
+			r.ErrorIs(err, tc.err)
+			r.Equal(tc.expectedAttempts, base.attempts.Load())
+		})
+	}
+}
+
+// mintingTokenSource is an oauth2.TokenSource that returns a new access token,
+// valid for an hour, every time it is called.
+type mintingTokenSource struct {
+	tokens atomic.Int32
+}
+
+func (s *mintingTokenSource) Token() (*oauth2.Token, error) {
+	return &oauth2.Token{
+		AccessToken: fmt.Sprintf("token-%d", s.tokens.Add(1)),
+		Expiry:      time.Now().Add(time.Hour),
+	}, nil
+}
+
+func TestTransport_Reauthenticate(t *testing.T) {
+	tcs := map[string]struct {
+		method           string
+		acceptedToken    string
+		expectedStatus   int
+		expectedAttempts int32
+	}{
+		"get is retried with a new token": {
+			method:           http.MethodGet,
+			acceptedToken:    "token-2",
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 2,
+		},
+		"post is retried with a new token": {
+			method:           http.MethodPost,
+			acceptedToken:    "token-2",
+			expectedStatus:   http.StatusOK,
+			expectedAttempts: 2,
+		},
+		"rejected new token is not retried again": {
+			method:           http.MethodGet,
+			acceptedToken:    "other-token",
+			expectedStatus:   http.StatusUnauthorized,
+			expectedAttempts: 2,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			var attempts atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				attempts.Add(1)
+
+				body, err := io.ReadAll(req.Body)
+				if err != nil || (req.Method == http.MethodPost && string(body) != "{}") {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				if req.Header.Get("Authorization") != "Bearer "+tc.acceptedToken {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer srv.Close()
+
+			// Like the HCP config, the token source caches the token until it
+			// expires, so the rejected token must be invalidated for the retry
+			// to be sent with a new one.
+			minter := &mintingTokenSource{}
+			var dropped []string
+			tokens := &reauthenticatingHCPConfig{
+				source: oauth2.ReuseTokenSource(nil, minter),
+				newSource: func() (oauth2.TokenSource, error) {
+					return oauth2.ReuseTokenSource(nil, minter), nil
+				},
+				dropCachedToken: func(accessToken string) error {
+					dropped = append(dropped, accessToken)
+					return nil
+				},
+			}
+
+			// Retries are disabled, so only the retry with a new token is made.
+			tr := newTransport(&oauth2.Transport{Source: tokens, Base: http.DefaultTransport}, ClientConfig{})
+			tr.tokens = tokens
+			client := &http.Client{Transport: tr}
+
+			req, err := http.NewRequest(tc.method, srv.URL, strings.NewReader("{}"))
+			r.NoError(err)
+
+			resp, err := client.Do(req)
+			r.NoError(err)
+			resp.Body.Close()
+
+			r.Equal(tc.expectedStatus, resp.StatusCode)
+			r.Equal(tc.expectedAttempts, attempts.Load())
+			r.EqualValues(2, minter.tokens.Load())
+			r.Equal([]string{"token-1"}, dropped)
+		})
+	}
+}
+
+func TestTransport_ExtraHeaders(t *testing.T) {
+	r := require.New(t)
+
+	var received http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		received = req.Header.Clone()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: newTestTransport(ClientConfig{
+		ExtraHeaders: map[string]string{
+			"x-proxy-token": "secret",
+			"X-Team":        "platform",
+		},
+	})}
+
+	req, err := http.NewRequest(http.MethodGet, srv.URL, nil)
+	r.NoError(err)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	r.NoError(err)
+	resp.Body.Close()
+
+	r.Equal("secret", received.Get("X-Proxy-Token"))
+	r.Equal("platform", received.Get("X-Team"))
+	r.Equal("application/json", received.Get("Accept"))
+
+	// The caller's request is left untouched.
+	r.Empty(req.Header.Get("X-Proxy-Token"))
+}
+
+func TestConfigureConnectionPool(t *testing.T) {
+	newRoundTripper := func(t *testing.T) http.RoundTripper {
+		hcp, err := hcpConfig.NewHCPConfig(hcpConfig.WithClientCredentials("client-id", "client-secret"))
+		require.NoError(t, err)
+		httpClient, err := sdk.New(sdk.Config{HCPConfig: hcp})
+		require.NoError(t, err)
+		return httpClient.Transport
+	}
+
+	t.Run("configured", func(t *testing.T) {
+		r := require.New(t)
+
+		rt := newRoundTripper(t)
+		r.True(configureConnectionPool(rt, ClientConfig{MaxIdleConns: 250, MaxIdleConnsPerHost: 64}))
+
+		base, ok := baseTransport(rt)
+		r.True(ok)
+		r.Equal(250, base.MaxIdleConns)
+		r.Equal(64, base.MaxIdleConnsPerHost)
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		r := require.New(t)
+
+		rt := newRoundTripper(t)
+		before, ok := baseTransport(rt)
+		r.True(ok)
+		maxIdleConns, maxIdleConnsPerHost := before.MaxIdleConns, before.MaxIdleConnsPerHost
+
+		r.True(configureConnectionPool(rt, ClientConfig{}))
+		r.Equal(maxIdleConns, before.MaxIdleConns)
+		r.Equal(maxIdleConnsPerHost, before.MaxIdleConnsPerHost)
+	})
+
+	t.Run("unknown transport", func(t *testing.T) {
+		rt := roundTripperFunc(func(*http.Request) (*http.Response, error) { return nil, errors.New("unused") })
+		require.False(t, configureConnectionPool(rt, ClientConfig{MaxIdleConns: 250}))
+	})
+}
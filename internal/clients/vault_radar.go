@@ -6,6 +6,7 @@ package clients
 import (
 	"context"
 	"errors"
+	"math/rand"
 	"time"
 
 	dsrs "github.com/hashicorp/hcp-sdk-go/clients/cloud-vault-radar/preview/2023-05-01/client/data_source_registration_service"
@@ -62,6 +63,11 @@ func OffboardRadarSource(ctx context.Context, client *Client, projectID, sourceI
 }
 
 func WaitOnOffboardRadarSource(ctx context.Context, client *Client, projectID, sourceID string) error {
+	if client.Config.SkipWaits {
+		tflog.Warn(ctx, "Not confirming radar source deletion because skip_waits is set.")
+		return nil
+	}
+
 	deletionConfirmation := func() (bool, error) {
 		tflog.Trace(ctx, "Confirming radar source deletion.")
 		if _, err := GetRadarSource(ctx, client, projectID, sourceID); err != nil {
@@ -79,7 +85,7 @@ func WaitOnOffboardRadarSource(ctx context.Context, client *Client, projectID, s
 		return false, nil
 	}
 
-	retry := 10 * time.Second
+	retry := jitterDuration(10*time.Second, client.Config.PollJitter, rand.Float64())
 	timeout := 10 * time.Minute
 	maxConsecutiveErrors := 5
 	return waitFor(ctx, retry, timeout, maxConsecutiveErrors, deletionConfirmation)
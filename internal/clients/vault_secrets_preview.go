@@ -18,7 +18,7 @@ import (
 
 // OpenVaultSecretsAppSecret will retrieve the latest secret for a Vault Secrets app, including it's value.
 func OpenVaultSecretsAppSecret(ctx context.Context, client *Client, loc *sharedmodels.HashicorpCloudLocationLocation, appName, secretName string) (*secretmodels.Secrets20231128OpenSecret, error) {
-	getParams := secret_service.NewOpenAppSecretParamsWithContext(ctx).
+	getParams := secret_service.NewOpenAppSecretParamsWithContext(withoutRetries(ctx)).
 		WithAppName(appName).
 		WithSecretName(secretName).
 		WithOrganizationID(loc.OrganizationID).
@@ -54,7 +54,7 @@ func OpenVaultSecretsAppSecret(ctx context.Context, client *Client, loc *sharedm
 }
 
 func OpenVaultSecretsAppSecrets(ctx context.Context, client *Client, loc *sharedmodels.HashicorpCloudLocationLocation, appName string) ([]*secretmodels.Secrets20231128OpenSecret, error) {
-	params := secret_service.NewOpenAppSecretsParamsWithContext(ctx).
+	params := secret_service.NewOpenAppSecretsParamsWithContext(withoutRetries(ctx)).
 		WithAppName(appName).
 		WithOrganizationID(loc.OrganizationID).
 		WithProjectID(loc.ProjectID)
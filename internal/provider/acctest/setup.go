@@ -14,10 +14,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
 	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
 	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
 	"github.com/hashicorp/terraform-provider-hcp/internal/provider"
 	"github.com/hashicorp/terraform-provider-hcp/internal/provider/packer/utils/location"
+	"github.com/hashicorp/terraform-provider-hcp/internal/providersdkv2"
 	"github.com/hashicorp/terraform-provider-hcp/version"
 )
 
@@ -33,6 +35,32 @@ var ProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, erro
 	},
 }
 
+// ProtoV6MuxedProviderFactories provides a Provider Factory that serves both
+// the framework and SDKv2 providers, like the released provider does. It should
+// be used by acceptance tests that depend on resources that haven't yet been
+// migrated to the framework.
+var ProtoV6MuxedProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"hcp": func() (tfprotov6.ProviderServer, error) {
+		// Upgrade the provider sdkv2 version to protocol 6
+		upgradedSdkProvider, err := tf5to6server.UpgradeServer(
+			context.Background(),
+			providersdkv2.New()().GRPCProvider,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		providers := []func() tfprotov6.ProviderServer{
+			providerserver.NewProtocol6(provider.NewFrameworkProvider(version.ProviderVersion)()),
+			func() tfprotov6.ProviderServer {
+				return upgradedSdkProvider
+			},
+		}
+
+		return tf6muxserver.NewMuxServer(context.Background(), providers...)
+	},
+}
+
 // PreCheck verifies that the required provider testing configuration is set.
 //
 // This PreCheck function should be present in every acceptance test. It ensures
@@ -0,0 +1,397 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+const (
+	// hvnResourceType is the resource type of an HVN
+	hvnResourceType = "hashicorp.network.hvn"
+
+	// peeringResourceType is the resource type of a network peering
+	peeringResourceType = "hashicorp.network.peering"
+)
+
+// peeringActiveTimeout is the default time to wait for a peering connection to
+// become active when wait_for_active_state is set.
+var peeringActiveTimeout = time.Minute * 35
+
+// hvnLinkRegex matches the self_link of an HVN, eg.
+// /project/{project_id}/hashicorp.network.hvn/{hvn_id}
+var hvnLinkRegex = regexp.MustCompile("^/project/([^/]+)/" + regexp.QuoteMeta(hvnResourceType) + "/([^/]+)$")
+
+type DataSourceAzurePeeringConnection struct {
+	client *clients.Client
+}
+
+type DataSourceAzurePeeringConnectionModel struct {
+	ID                    types.String   `tfsdk:"id"`
+	PeeringID             types.String   `tfsdk:"peering_id"`
+	HvnLink               types.String   `tfsdk:"hvn_link"`
+	WaitForActiveState    types.Bool     `tfsdk:"wait_for_active_state"`
+	AcceptableStates      types.List     `tfsdk:"acceptable_states"`
+	OrganizationID        types.String   `tfsdk:"organization_id"`
+	ProjectID             types.String   `tfsdk:"project_id"`
+	ApplicationID         types.String   `tfsdk:"application_id"`
+	PeerVnetName          types.String   `tfsdk:"peer_vnet_name"`
+	PeerVnetID            types.String   `tfsdk:"peer_vnet_id"`
+	PeerSubscriptionID    types.String   `tfsdk:"peer_subscription_id"`
+	PeerVnetRegion        types.String   `tfsdk:"peer_vnet_region"`
+	PeerTenantID          types.String   `tfsdk:"peer_tenant_id"`
+	PeerResourceGroupName types.String   `tfsdk:"peer_resource_group_name"`
+	PeerResourceGroupID   types.String   `tfsdk:"peer_resource_group_id"`
+	AllowForwardedTraffic types.Bool     `tfsdk:"allow_forwarded_traffic"`
+	UseRemoteGateways     types.Bool     `tfsdk:"use_remote_gateways"`
+	AzurePeeringID        types.String   `tfsdk:"azure_peering_id"`
+	CreatedAt             types.String   `tfsdk:"created_at"`
+	ExpiresAt             types.String   `tfsdk:"expires_at"`
+	LastRefreshed         types.String   `tfsdk:"last_refreshed"`
+	SecondsToExpiry       types.Int64    `tfsdk:"seconds_to_expiry"`
+	SelfLink              types.String   `tfsdk:"self_link"`
+	State                 types.String   `tfsdk:"state"`
+	ConnectivityState     types.String   `tfsdk:"connectivity_state"`
+	AcceptanceCommand     types.String   `tfsdk:"acceptance_command"`
+	AcceptanceDetailsJSON types.String   `tfsdk:"acceptance_details_json"`
+	Timeouts              timeouts.Value `tfsdk:"timeouts"`
+}
+
+func NewAzurePeeringConnectionDataSource() datasource.DataSource {
+	return &DataSourceAzurePeeringConnection{}
+}
+
+func (d *DataSourceAzurePeeringConnection) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_azure_peering_connection"
+}
+
+func (d *DataSourceAzurePeeringConnection) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The Azure peering connection data source provides information about a peering connection between an HVN and a peer Azure VNet.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of this resource.",
+				Computed:    true,
+			},
+			// Required inputs
+			"hvn_link": schema.StringAttribute{
+				Description: "The `self_link` of the HashiCorp Virtual Network (HVN).",
+				Required:    true,
+			},
+			// Optional inputs
+			"peering_id": schema.StringAttribute{
+				Description: "The ID of the peering connection. Exactly one of `peering_id` or `azure_peering_id` must be set.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("azure_peering_id")),
+				},
+			},
+			"azure_peering_id": schema.StringAttribute{
+				Description: "The peering connection ID used by Azure, i.e. the resource ID of the peering of the HVN's VNet. Set it to look up a peering connection by its Azure-side ID, e.g. when the HCP `peering_id` isn't known. Since HCP can't filter peering connections by it, every peering connection of the HVN is listed. Exactly one of `peering_id` or `azure_peering_id` must be set.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"wait_for_active_state": schema.BoolAttribute{
+				Description: "If `true`, Terraform will wait for the peering connection to reach an `ACTIVE` state before continuing, for at most the `read` timeout. If `false`, the default, the peering connection is returned immediately in its current state, e.g. `PENDING_ACCEPTANCE`, without an error, which suits peering connections that are accepted outside of Terraform.",
+				Optional:    true,
+			},
+			"acceptable_states": schema.ListAttribute{
+				Description: "The states that `wait_for_active_state` waits for the peering connection to reach any of, for workflows that only need the peering connection to be partially ready. Valid options are `CREATING`, `PENDING_ACCEPTANCE`, `ACCEPTED` and `ACTIVE`. Reaching `ACTIVE` always completes the wait. Defaults to `[\"ACTIVE\"]`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.OneOf(clients.PeeringAcceptableStates...)),
+				},
+			},
+			// Computed outputs
+			"organization_id": schema.StringAttribute{
+				Description: "The ID of the HCP organization where the peering connection is located. Always matches the HVN's organization.",
+				Computed:    true,
+			},
+			"project_id": schema.StringAttribute{
+				Description: `
+The ID of the HCP project where the peering connection is located. Always matches the HVN's project.
+If not specified, the project specified in the HCP Provider config block will be used, if configured.
+If a project is not configured in the HCP Provider config block, the organization's only project will be used.`,
+				Computed: true,
+			},
+			"application_id": schema.StringAttribute{
+				Description: "The ID of the Azure application whose credentials are used to peer the HCP HVN's underlying VNet with the customer VNet.",
+				Computed:    true,
+			},
+			"peer_vnet_name": schema.StringAttribute{
+				Description: "The name of the peer VNet in Azure.",
+				Computed:    true,
+			},
+			"peer_vnet_id": schema.StringAttribute{
+				Description: "The fully qualified Azure resource ID of the peer VNet.",
+				Computed:    true,
+			},
+			"peer_subscription_id": schema.StringAttribute{
+				Description: "The subscription ID of the peer VNet in Azure.",
+				Computed:    true,
+			},
+			"peer_vnet_region": schema.StringAttribute{
+				Description: "The region of the peer VNet in Azure.",
+				Computed:    true,
+			},
+			"peer_tenant_id": schema.StringAttribute{
+				Description: "The tenant ID of the peer VNet in Azure.",
+				Computed:    true,
+			},
+			"peer_resource_group_name": schema.StringAttribute{
+				Description: "The resource group name of the peer VNet in Azure.",
+				Computed:    true,
+			},
+			"peer_resource_group_id": schema.StringAttribute{
+				Description: "The fully qualified Azure resource ID of the peer VNet's resource group.",
+				Computed:    true,
+			},
+			"allow_forwarded_traffic": schema.BoolAttribute{
+				Description: "Whether the forwarded traffic originating from the peered VNet is allowed in the HVN",
+				Computed:    true,
+			},
+			"use_remote_gateways": schema.BoolAttribute{
+				Description: "If the HVN should use the gateway of the peered VNet",
+				Computed:    true,
+			},
+			"created_at": schema.StringAttribute{
+				Description: "The time that the peering connection was created.",
+				Computed:    true,
+			},
+			"expires_at": schema.StringAttribute{
+				Description: "The time after which the peering connection will be considered expired if it hasn't transitioned into `ACCEPTED` or `ACTIVE` state.",
+				Computed:    true,
+			},
+			"last_refreshed": schema.StringAttribute{
+				Description: "The time that the peering connection was last read from HCP, in RFC 3339 format. It changes on every refresh, e.g. to confirm that `state` is up to date.",
+				Computed:    true,
+			},
+			"seconds_to_expiry": schema.Int64Attribute{
+				Description: "The number of seconds left to accept the peering connection before it expires, as of the last refresh. `0` if the peering connection isn't in a `PENDING_ACCEPTANCE` state.",
+				Computed:    true,
+			},
+			"self_link": schema.StringAttribute{
+				Description: "A unique URL identifying the peering connection",
+				Computed:    true,
+			},
+			"state": schema.StringAttribute{
+				Description: "The state of the Azure peering connection.",
+				Computed:    true,
+			},
+			"acceptance_command": schema.StringAttribute{
+				Description: "The Azure CLI commands that grant the peering connection's `application_id` the permissions HCP requires on the peer VNet. Useful to complete the peering connection outside of Terraform. The Azure CLI must be logged in to the `peer_tenant_id` tenant, e.g. with `az login --tenant <peer_tenant_id>`.",
+				Computed:    true,
+			},
+			"acceptance_details_json": schema.StringAttribute{
+				Description: "The details needed to accept the peering connection outside of Terraform, encoded as JSON, e.g. for external tooling: `application_id`, `peer_subscription_id`, `peer_tenant_id`, `peer_resource_group_name`, `peer_vnet_name` and `peer_vnet_id`. `application_id` is empty until HCP has assigned the peering connection an application.",
+				Computed:    true,
+			},
+			"connectivity_state": schema.StringAttribute{
+				Description: "The connectivity state of the peering connection. `NOT_CONNECTED` if the peering connection is not `ACTIVE`, otherwise `UNKNOWN`, since HCP doesn't currently report whether traffic is flowing over a peering connection.",
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *DataSourceAzurePeeringConnection) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *DataSourceAzurePeeringConnection) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DataSourceAzurePeeringConnectionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HCP Client",
+			"Expected configured HCP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, peeringActiveTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	loc, hvnID, err := parseHvnLink(data.HvnLink.ValueString(), d.client.Config.OrganizationID)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("hvn_link"), "Invalid HVN link", err.Error())
+		return
+	}
+
+	// Query for the peering, either by its HCP or its Azure ID.
+	var peering *networkmodels.HashicorpCloudNetwork20200907Peering
+	if azurePeeringID := data.AzurePeeringID.ValueString(); azurePeeringID != "" {
+		tflog.Info(ctx, "Reading peering connection by Azure peering ID", map[string]interface{}{"azure_peering_id": azurePeeringID})
+		peering, err = clients.GetPeeringByAzureID(ctx, d.client, azurePeeringID, hvnID, loc)
+		if err != nil {
+			if errors.Is(err, clients.ErrPeeringNotFound) {
+				resp.Diagnostics.AddError("Peering connection does not exist", fmt.Sprintf("unable to find a peering connection with Azure peering ID (%s) for HVN (%s)", azurePeeringID, hvnID))
+				return
+			}
+
+			resp.Diagnostics.AddError("Error retrieving peering connection", fmt.Sprintf("unable to list the peering connections of HVN (%s): %v", hvnID, err))
+			return
+		}
+	} else {
+		peeringID := data.PeeringID.ValueString()
+		tflog.Info(ctx, "Reading peering connection", map[string]interface{}{"peering_id": peeringID})
+		peering, err = clients.GetPeeringByID(ctx, d.client, peeringID, hvnID, loc)
+		if err != nil {
+			if clients.IsResponseCodeNotFound(err) {
+				resp.Diagnostics.AddError("Peering connection does not exist", fmt.Sprintf("unable to find peering connection (%s) for HVN (%s)", peeringID, hvnID))
+				return
+			}
+
+			resp.Diagnostics.AddError("Error retrieving peering connection", fmt.Sprintf("unable to retrieve peering connection (%s): %v", peeringID, err))
+			return
+		}
+	}
+
+	data.setPeering(peering)
+
+	acceptableStates := []string{clients.PeeringStateActive}
+	if !data.AcceptableStates.IsNull() {
+		resp.Diagnostics.Append(data.AcceptableStates.ElementsAs(ctx, &acceptableStates, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	state := string(*peering.State)
+	if data.WaitForActiveState.ValueBool() && state != clients.PeeringStateActive && !slices.Contains(acceptableStates, state) {
+		d.waitForActive(ctx, &data, peering, loc, hvnID, acceptableStates, readTimeout, resp)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// waitForActive waits for the peering connection to become active, or to reach
+// any of the acceptable states, updating the model with the latest state of
+// the peering connection. If the peering connection is in a state from which
+// it can't become active, a warning is issued instead.
+func (d *DataSourceAzurePeeringConnection) waitForActive(ctx context.Context, data *DataSourceAzurePeeringConnectionModel, peering *networkmodels.HashicorpCloudNetwork20200907Peering,
+	loc *sharedmodels.HashicorpCloudLocationLocation, hvnID string, acceptableStates []string, timeout time.Duration, resp *datasource.ReadResponse) {
+	// If it's not in a state where it could later become ACTIVE, we're going to bail.
+	terminalState := true
+	for _, state := range clients.WaitForPeeringToBeActiveStates {
+		if state == string(*peering.State) {
+			terminalState = false
+			break
+		}
+	}
+
+	if terminalState {
+		resp.Diagnostics.AddWarning(
+			fmt.Sprintf("Peering is in an unexpected state, connections may fail: %q", string(*peering.State)),
+			"Expected a CREATING, PENDING_ACCEPTANCE, ACCEPTED, or ACTIVE state",
+		)
+		return
+	}
+
+	peering, err := clients.WaitForPeeringToBeAnyOf(acceptableStates)(ctx, d.client, peering.ID, hvnID, loc, timeout)
+	if peering != nil {
+		data.setPeering(peering)
+	}
+
+	// If we didn't reach the desired state, throw a diagnostic err.
+	if err != nil {
+		resp.Diagnostics.AddError("Error waiting for peering connection", err.Error())
+	}
+}
+
+// setPeering sets the model's computed attributes from the peering connection.
+func (m *DataSourceAzurePeeringConnectionModel) setPeering(peering *networkmodels.HashicorpCloudNetwork20200907Peering) {
+	selfLink := peeringSelfLink(peering.Hvn.Location.ProjectID, peering.ID)
+
+	m.ID = types.StringValue(selfLink)
+	m.SelfLink = types.StringValue(selfLink)
+	m.OrganizationID = types.StringValue(peering.Hvn.Location.OrganizationID)
+	m.ProjectID = types.StringValue(peering.Hvn.Location.ProjectID)
+	m.PeeringID = types.StringValue(peering.ID)
+	m.AzurePeeringID = types.StringValue(peering.ProviderPeeringID)
+	m.CreatedAt = types.StringValue(peering.CreatedAt.String())
+	m.ExpiresAt = types.StringValue(peering.ExpiresAt.String())
+	m.SecondsToExpiry = types.Int64Value(clients.PeeringSecondsToExpiry(peering, time.Now()))
+	m.LastRefreshed = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+	m.State = types.StringValue(string(*peering.State))
+	m.ConnectivityState = types.StringValue(clients.PeeringConnectivityState(peering))
+	m.AcceptanceCommand = types.StringValue(clients.AzurePeeringAcceptanceCommand(peering))
+	m.AcceptanceDetailsJSON = types.StringValue(clients.AzurePeeringAcceptanceDetailsJSON(peering))
+
+	target := peering.Target.AzureTarget
+	m.PeerSubscriptionID = types.StringValue(target.SubscriptionID)
+	m.PeerVnetName = types.StringValue(target.VnetName)
+	m.PeerVnetRegion = types.StringValue(target.Region)
+	m.PeerResourceGroupName = types.StringValue(target.ResourceGroupName)
+	m.PeerResourceGroupID = types.StringValue(clients.AzureResourceGroupID(target))
+	m.PeerTenantID = types.StringValue(target.TenantID)
+	m.PeerVnetID = types.StringValue(clients.AzureVnetResourceID(target))
+	m.ApplicationID = types.StringValue(target.ApplicationID)
+	m.AllowForwardedTraffic = types.BoolValue(target.AllowForwardedTraffic)
+	m.UseRemoteGateways = types.BoolValue(target.UseRemoteGateways)
+}
+
+// peeringSelfLink builds the self_link of a peering connection.
+func peeringSelfLink(projectID, peeringID string) string {
+	return fmt.Sprintf("/project/%s/%s/%s", projectID, peeringResourceType, peeringID)
+}
+
+// parseHvnLink parses the self_link of an HVN into its location and ID. A link
+// only contains the project ID of its location, so the organization ID, which
+// is required for most requests, has to be provided.
+func parseHvnLink(link string, organizationID string) (*sharedmodels.HashicorpCloudLocationLocation, string, error) {
+	matches := hvnLinkRegex.FindStringSubmatch(link)
+	if matches == nil {
+		return nil, "", fmt.Errorf("url %q is not in the correct format: /project/{project_id}/%s/{id}", link, hvnResourceType)
+	}
+
+	return &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: organizationID,
+		ProjectID:      matches[1],
+	}, matches[2], nil
+}
@@ -0,0 +1,186 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-provider-hcp/internal/provider/acctest"
+)
+
+func TestAcc_Platform_dataSourceAzurePeeringConnection(t *testing.T) {
+	resID := "p-az-peer-ds-" + acctest.RandString(8)
+	dataSourceAddress := "data.hcp_azure_peering_connection.peering"
+	resourceAddress := "hcp_azure_peering_connection.peering"
+
+	azureProviders := map[string]resource.ExternalProvider{
+		"azurerm": {VersionConstraint: "~> 3.63"},
+		"azuread": {VersionConstraint: "~> 2.39"},
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccAzurePreCheck(t) },
+		Steps: []resource.TestStep{
+			// The data source should wait for the peering to become active and
+			// expose the same attributes as the resource.
+			{
+				ProtoV6ProviderFactories: acctest.ProtoV6MuxedProviderFactories,
+				ExternalProviders:        azureProviders,
+				Config:                   testAccAzurePeeringConnectionDataSourceConfig(resID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceAddress, "state", "ACTIVE"),
+					resource.TestCheckResourceAttr(dataSourceAddress, "connectivity_state", "UNKNOWN"),
+					resource.TestCheckResourceAttrSet(dataSourceAddress, "azure_peering_id"),
+					resource.TestCheckResourceAttrPair(dataSourceAddress, "self_link", resourceAddress, "self_link"),
+					resource.TestCheckResourceAttrPair(dataSourceAddress, "organization_id", resourceAddress, "organization_id"),
+					resource.TestCheckResourceAttrPair(dataSourceAddress, "project_id", resourceAddress, "project_id"),
+					resource.TestCheckResourceAttrPair(dataSourceAddress, "application_id", resourceAddress, "application_id"),
+					resource.TestCheckResourceAttrPair(dataSourceAddress, "peer_subscription_id", resourceAddress, "peer_subscription_id"),
+					resource.TestCheckResourceAttrPair(dataSourceAddress, "peer_tenant_id", resourceAddress, "peer_tenant_id"),
+					resource.TestCheckResourceAttrPair(dataSourceAddress, "peer_vnet_name", resourceAddress, "peer_vnet_name"),
+					resource.TestCheckResourceAttrPair(dataSourceAddress, "peer_vnet_region", resourceAddress, "peer_vnet_region"),
+					resource.TestCheckResourceAttrPair(dataSourceAddress, "peer_resource_group_name", resourceAddress, "peer_resource_group_name"),
+					resource.TestCheckResourceAttrPair(dataSourceAddress, "peer_resource_group_id", "azurerm_resource_group.rg", "id"),
+					resource.TestCheckResourceAttrPair(dataSourceAddress, "peer_vnet_id", "azurerm_virtual_network.vnet", "id"),
+					resource.TestCheckResourceAttrPair(dataSourceAddress, "allow_forwarded_traffic", resourceAddress, "allow_forwarded_traffic"),
+					resource.TestCheckResourceAttrPair(dataSourceAddress, "use_remote_gateways", resourceAddress, "use_remote_gateways"),
+					resource.TestCheckResourceAttrPair(dataSourceAddress, "created_at", resourceAddress, "created_at"),
+					resource.TestCheckResourceAttrPair(dataSourceAddress, "expires_at", resourceAddress, "expires_at"),
+					resource.TestCheckResourceAttrSet(dataSourceAddress, "last_refreshed"),
+					resource.TestCheckResourceAttrPair("data.hcp_azure_peering_connection.by_azure_id", "peering_id", resourceAddress, "peering_id"),
+				),
+			},
+		},
+	})
+}
+
+// TestAcc_Platform_dataSourceAzurePeeringConnectionMigration ensures that
+// configurations using the SDKv2 implementation of the data source keep working
+// once it's served by the framework provider.
+func TestAcc_Platform_dataSourceAzurePeeringConnectionMigration(t *testing.T) {
+	resID := "p-az-peer-mg-" + acctest.RandString(8)
+	config := testAccAzurePeeringConnectionDataSourceConfig(resID)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccAzurePreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				ExternalProviders: map[string]resource.ExternalProvider{
+					"hcp": {
+						VersionConstraint: "~> 0.100",
+						Source:            "hashicorp/hcp",
+					},
+					"azurerm": {VersionConstraint: "~> 3.63"},
+					"azuread": {VersionConstraint: "~> 2.39"},
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.hcp_azure_peering_connection.peering", "state", "ACTIVE"),
+				),
+			},
+			{
+				ProtoV6ProviderFactories: acctest.ProtoV6MuxedProviderFactories,
+				ExternalProviders: map[string]resource.ExternalProvider{
+					"azurerm": {VersionConstraint: "~> 3.63"},
+					"azuread": {VersionConstraint: "~> 2.39"},
+				},
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccAzurePreCheck(t *testing.T) {
+	acctest.PreCheck(t)
+
+	if os.Getenv("ARM_SUBSCRIPTION_ID") == "" {
+		t.Fatal("ARM_SUBSCRIPTION_ID must be set for Azure acceptance tests")
+	}
+
+	if os.Getenv("ARM_TENANT_ID") == "" {
+		t.Fatal("ARM_TENANT_ID must be set for Azure acceptance tests")
+	}
+}
+
+// testAccAzurePeeringConnectionDataSourceConfig is also applied with released
+// versions of the provider by the migration test, so it lists the required
+// Azure actions rather than reading them from the
+// hcp_azure_peering_required_permissions data source.
+func testAccAzurePeeringConnectionDataSourceConfig(resID string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "hcp_hvn" "test" {
+  hvn_id         = %[1]q
+  cloud_provider = "azure"
+  region         = "eastus"
+  cidr_block     = "172.25.16.0/20"
+}
+
+resource "hcp_azure_peering_connection" "peering" {
+  hvn_link                 = hcp_hvn.test.self_link
+  peering_id               = %[1]q
+  peer_subscription_id     = %[2]q
+  peer_tenant_id           = %[3]q
+  peer_vnet_name           = azurerm_virtual_network.vnet.name
+  peer_resource_group_name = azurerm_resource_group.rg.name
+  peer_vnet_region         = "eastus"
+}
+
+data "hcp_azure_peering_connection" "peering" {
+  hvn_link              = hcp_hvn.test.self_link
+  peering_id            = hcp_azure_peering_connection.peering.peering_id
+  wait_for_active_state = true
+
+  depends_on = [azurerm_role_assignment.assignment]
+}
+
+data "hcp_azure_peering_connection" "by_azure_id" {
+  hvn_link         = hcp_hvn.test.self_link
+  azure_peering_id = data.hcp_azure_peering_connection.peering.azure_peering_id
+}
+
+resource "azurerm_resource_group" "rg" {
+  name     = %[1]q
+  location = "East US"
+}
+
+resource "azurerm_virtual_network" "vnet" {
+  name                = %[1]q
+  location            = azurerm_resource_group.rg.location
+  resource_group_name = azurerm_resource_group.rg.name
+  address_space       = ["10.0.0.0/16"]
+}
+
+resource "azuread_service_principal" "principal" {
+  application_id = hcp_azure_peering_connection.peering.application_id
+}
+
+resource "azurerm_role_definition" "definition" {
+  name              = %[1]q
+  scope             = azurerm_virtual_network.vnet.id
+  assignable_scopes = [azurerm_virtual_network.vnet.id]
+
+  permissions {
+    actions = [
+      "Microsoft.Network/virtualNetworks/peer/action",
+      "Microsoft.Network/virtualNetworks/virtualNetworkPeerings/read",
+      "Microsoft.Network/virtualNetworks/virtualNetworkPeerings/write"
+    ]
+  }
+}
+
+resource "azurerm_role_assignment" "assignment" {
+  principal_id       = azuread_service_principal.principal.id
+  scope              = azurerm_virtual_network.vnet.id
+  role_definition_id = azurerm_role_definition.definition.role_definition_resource_id
+}
+`, resID, os.Getenv("ARM_SUBSCRIPTION_ID"), os.Getenv("ARM_TENANT_ID"))
+}
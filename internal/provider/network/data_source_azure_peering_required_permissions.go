@@ -0,0 +1,55 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+type DataSourceAzurePeeringRequiredPermissions struct{}
+
+type DataSourceAzurePeeringRequiredPermissionsModel struct {
+	Actions types.List `tfsdk:"actions"`
+}
+
+func NewAzurePeeringRequiredPermissionsDataSource() datasource.DataSource {
+	return &DataSourceAzurePeeringRequiredPermissions{}
+}
+
+func (d *DataSourceAzurePeeringRequiredPermissions) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_azure_peering_required_permissions"
+}
+
+func (d *DataSourceAzurePeeringRequiredPermissions) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The Azure peering required permissions data source provides the Azure actions that HCP must be allowed to perform on a peer VNet " +
+			"to complete an Azure peering connection. They should be granted to the service principal of the peering connection's `application_id`.",
+		Attributes: map[string]schema.Attribute{
+			"actions": schema.ListAttribute{
+				Description: "The Azure actions that HCP must be allowed to perform on the peer VNet.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *DataSourceAzurePeeringRequiredPermissions) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DataSourceAzurePeeringRequiredPermissionsModel
+
+	actions, diags := types.ListValueFrom(ctx, types.StringType, clients.AzurePeeringRequiredActions())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Actions = actions
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,31 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-provider-hcp/internal/provider/acctest"
+)
+
+func TestAcc_Platform_dataSourceAzurePeeringRequiredPermissions(t *testing.T) {
+	dataSourceAddress := "data.hcp_azure_peering_required_permissions.required"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "hcp_azure_peering_required_permissions" "required" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceAddress, "actions.#", "3"),
+					resource.TestCheckResourceAttr(dataSourceAddress, "actions.0", "Microsoft.Network/virtualNetworks/peer/action"),
+					resource.TestCheckResourceAttr(dataSourceAddress, "actions.1", "Microsoft.Network/virtualNetworks/virtualNetworkPeerings/read"),
+					resource.TestCheckResourceAttr(dataSourceAddress, "actions.2", "Microsoft.Network/virtualNetworks/virtualNetworkPeerings/write"),
+				),
+			},
+		},
+	})
+}
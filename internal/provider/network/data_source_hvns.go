@@ -0,0 +1,197 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"context"
+	"fmt"
+
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+type DataSourceHvns struct {
+	client *clients.Client
+}
+
+type DataSourceHvnsModel struct {
+	ProjectID     types.String `tfsdk:"project_id"`
+	CloudProvider types.String `tfsdk:"cloud_provider"`
+	PageSize      types.Int64  `tfsdk:"page_size"`
+	Hvns          []HvnModel   `tfsdk:"hvns"`
+}
+
+type HvnModel struct {
+	HvnID         types.String `tfsdk:"hvn_id"`
+	CidrBlock     types.String `tfsdk:"cidr_block"`
+	CloudProvider types.String `tfsdk:"cloud_provider"`
+	Region        types.String `tfsdk:"region"`
+	State         types.String `tfsdk:"state"`
+	SelfLink      types.String `tfsdk:"self_link"`
+}
+
+func NewHvnsDataSource() datasource.DataSource {
+	return &DataSourceHvns{}
+}
+
+func (d *DataSourceHvns) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hvns"
+}
+
+func (d *DataSourceHvns) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The HVNs data source lists the HashiCorp Virtual Networks (HVNs) in a project, e.g. to audit them or to iterate over them with `for_each`.",
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				Description: `
+The ID of the HCP project to list the HVNs of.
+If not specified, the project specified in the HCP Provider config block will be used, if configured.
+If a project is not configured in the HCP Provider config block, the organization's only project will be used.`,
+				Optional: true,
+				Computed: true,
+			},
+			"cloud_provider": schema.StringAttribute{
+				Description: "If set, only the HVNs of this cloud provider are listed. Valid options are `aws` and `azure`.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("aws", "azure"),
+				},
+			},
+			"page_size": schema.Int64Attribute{
+				Description: fmt.Sprintf("The number of HVNs to request per page while listing them. Smaller pages use less memory, but need more requests. Defaults to `%d`, and must be at most `%d`.", clients.DefaultListPageSize, clients.MaxListPageSize),
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, clients.MaxListPageSize),
+				},
+			},
+			"hvns": schema.ListNestedAttribute{
+				Description: "The HVNs in the project.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"hvn_id": schema.StringAttribute{
+							Description: "The ID of the HVN.",
+							Computed:    true,
+						},
+						"cidr_block": schema.StringAttribute{
+							Description: "The CIDR range of the HVN.",
+							Computed:    true,
+						},
+						"cloud_provider": schema.StringAttribute{
+							Description: "The provider where the HVN is located.",
+							Computed:    true,
+						},
+						"region": schema.StringAttribute{
+							Description: "The region where the HVN is located.",
+							Computed:    true,
+						},
+						"state": schema.StringAttribute{
+							Description: "The state of the HVN.",
+							Computed:    true,
+						},
+						"self_link": schema.StringAttribute{
+							Description: "A unique URL identifying the HVN.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DataSourceHvns) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *DataSourceHvns) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DataSourceHvnsModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HCP Client",
+			"Expected configured HCP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	projectID := d.client.Config.ProjectID
+	if !data.ProjectID.IsNull() && !data.ProjectID.IsUnknown() {
+		projectID = data.ProjectID.ValueString()
+	}
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: d.client.Config.OrganizationID,
+		ProjectID:      projectID,
+	}
+
+	pageSize := int64(clients.DefaultListPageSize)
+	if !data.PageSize.IsNull() {
+		pageSize = data.PageSize.ValueInt64()
+	}
+
+	tflog.Info(ctx, "Listing HVNs", map[string]interface{}{"project_id": projectID, "page_size": pageSize})
+	hvns, err := clients.ListHvns(ctx, d.client, loc, pageSize)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing HVNs", fmt.Sprintf("unable to list the HVNs of project (%s): %v", projectID, err))
+		return
+	}
+
+	data.ProjectID = types.StringValue(projectID)
+	data.Hvns = make([]HvnModel, 0, len(hvns))
+	for _, hvn := range hvns {
+		var cloudProvider, region string
+		if hvn.Location != nil && hvn.Location.Region != nil {
+			cloudProvider = hvn.Location.Region.Provider
+			region = hvn.Location.Region.Region
+		}
+		if !data.CloudProvider.IsNull() && cloudProvider != data.CloudProvider.ValueString() {
+			continue
+		}
+
+		var state string
+		if hvn.State != nil {
+			state = string(*hvn.State)
+		}
+
+		data.Hvns = append(data.Hvns, HvnModel{
+			HvnID:         types.StringValue(hvn.ID),
+			CidrBlock:     types.StringValue(hvn.CidrBlock),
+			CloudProvider: types.StringValue(cloudProvider),
+			Region:        types.StringValue(region),
+			State:         types.StringValue(state),
+			SelfLink:      types.StringValue(hvnSelfLink(projectID, hvn.ID)),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// hvnSelfLink builds the self_link of an HVN.
+func hvnSelfLink(projectID, hvnID string) string {
+	return fmt.Sprintf("/project/%s/%s/%s", projectID, hvnResourceType, hvnID)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+
+	"github.com/hashicorp/terraform-provider-hcp/internal/provider/acctest"
+)
+
+func TestAcc_Platform_dataSourceHvns(t *testing.T) {
+	resID := "p-hvns-" + acctest.RandString(8)
+	dataSourceAddress := "data.hcp_hvns.hvns"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6MuxedProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "hcp_hvn" "aws" {
+  hvn_id         = "%[1]s-aws"
+  cloud_provider = "aws"
+  region         = "us-west-2"
+  cidr_block     = "172.25.16.0/20"
+}
+
+resource "hcp_hvn" "azure" {
+  hvn_id         = "%[1]s-azure"
+  cloud_provider = "azure"
+  region         = "eastus"
+  cidr_block     = "172.25.32.0/20"
+}
+
+data "hcp_hvns" "hvns" {
+  depends_on = [hcp_hvn.aws, hcp_hvn.azure]
+}
+
+data "hcp_hvns" "aws" {
+  cloud_provider = "aws"
+
+  depends_on = [hcp_hvn.aws, hcp_hvn.azure]
+}
+
+data "hcp_hvns" "paged" {
+  page_size = 1
+
+  depends_on = [hcp_hvn.aws, hcp_hvn.azure]
+}
+`, resID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceAddress, "project_id", "hcp_hvn.aws", "project_id"),
+					resource.TestCheckTypeSetElemNestedAttrs(dataSourceAddress, "hvns.*", map[string]string{
+						"hvn_id":         resID + "-aws",
+						"cidr_block":     "172.25.16.0/20",
+						"cloud_provider": "aws",
+						"region":         "us-west-2",
+						"state":          "STABLE",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(dataSourceAddress, "hvns.*", map[string]string{
+						"hvn_id":         resID + "-azure",
+						"cidr_block":     "172.25.32.0/20",
+						"cloud_provider": "azure",
+						"region":         "eastus",
+					}),
+					resource.TestCheckTypeSetElemAttrPair(dataSourceAddress, "hvns.*.self_link", "hcp_hvn.aws", "self_link"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.hcp_hvns.aws", "hvns.*", map[string]string{
+						"hvn_id": resID + "-aws",
+					}),
+					testAccCheckHvnsCloudProvider("data.hcp_hvns.aws", "aws"),
+					resource.TestCheckResourceAttrPair("data.hcp_hvns.paged", "hvns.#", dataSourceAddress, "hvns.#"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.hcp_hvns.paged", "hvns.*", map[string]string{
+						"hvn_id": resID + "-azure",
+					}),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckHvnsCloudProvider checks that all the HVNs listed by an hcp_hvns
+// data source are in the given cloud provider.
+func testAccCheckHvnsCloudProvider(address, cloudProvider string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[address]
+		if !ok {
+			return fmt.Errorf("not found: %s", address)
+		}
+
+		for k, v := range rs.Primary.Attributes {
+			if strings.HasPrefix(k, "hvns.") && strings.HasSuffix(k, ".cloud_provider") && v != cloudProvider {
+				return fmt.Errorf("%s: expected only %s HVNs, got %s = %s", address, cloudProvider, k, v)
+			}
+		}
+		return nil
+	}
+}
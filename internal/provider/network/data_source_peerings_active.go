@@ -0,0 +1,155 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/datasource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+type DataSourcePeeringsActive struct {
+	client *clients.Client
+}
+
+type DataSourcePeeringsActiveModel struct {
+	Peerings  []PeeringModel `tfsdk:"peerings"`
+	SelfLinks types.List     `tfsdk:"self_links"`
+	Timeouts  timeouts.Value `tfsdk:"timeouts"`
+}
+
+type PeeringModel struct {
+	HvnLink   types.String `tfsdk:"hvn_link"`
+	PeeringID types.String `tfsdk:"peering_id"`
+}
+
+func NewPeeringsActiveDataSource() datasource.DataSource {
+	return &DataSourcePeeringsActive{}
+}
+
+func (d *DataSourcePeeringsActive) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_peerings_active"
+}
+
+func (d *DataSourcePeeringsActive) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The peerings active data source waits for a set of peering connections to all reach an `ACTIVE` state. " +
+			"The peering connections are waited on concurrently, so depending on this data source is faster than waiting on each peering connection in turn.",
+		Attributes: map[string]schema.Attribute{
+			"peerings": schema.ListNestedAttribute{
+				Description: "The peering connections to wait for.",
+				Required:    true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"hvn_link": schema.StringAttribute{
+							Description: "The `self_link` of the HashiCorp Virtual Network (HVN) of the peering connection.",
+							Required:    true,
+						},
+						"peering_id": schema.StringAttribute{
+							Description: "The ID of the peering connection.",
+							Required:    true,
+						},
+					},
+				},
+			},
+			"self_links": schema.ListAttribute{
+				Description: "The `self_link`s of the peering connections, in the same order as `peerings`.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx),
+		},
+	}
+}
+
+func (d *DataSourcePeeringsActive) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *DataSourcePeeringsActive) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DataSourcePeeringsActiveModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.client == nil {
+		resp.Diagnostics.AddError(
+			"Unconfigured HCP Client",
+			"Expected configured HCP client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	readTimeout, diags := data.Timeouts.Read(ctx, peeringActiveTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	locators := make([]clients.PeeringLocator, len(data.Peerings))
+	for i, p := range data.Peerings {
+		loc, hvnID, err := parseHvnLink(p.HvnLink.ValueString(), d.client.Config.OrganizationID)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("peerings").AtListIndex(i).AtName("hvn_link"), "Invalid HVN link", err.Error())
+			continue
+		}
+
+		locators[i] = clients.PeeringLocator{
+			PeeringID: p.PeeringID.ValueString(),
+			HvnID:     hvnID,
+			Location:  loc,
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, "Waiting for peering connections to become active", map[string]interface{}{"count": len(locators)})
+	peerings, err := clients.WaitForPeeringsToBeActive(ctx, d.client, locators, readTimeout)
+	if err != nil {
+		resp.Diagnostics.AddError("Error waiting for peering connections", err.Error())
+		return
+	}
+
+	selfLinks := make([]string, len(peerings))
+	for i, peering := range peerings {
+		selfLinks[i] = peeringSelfLink(locators[i].Location.ProjectID, peering.ID)
+	}
+
+	data.SelfLinks, diags = types.ListValueFrom(ctx, types.StringType, selfLinks)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
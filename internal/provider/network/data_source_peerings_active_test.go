@@ -0,0 +1,43 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package network_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-provider-hcp/internal/provider/acctest"
+)
+
+func TestAcc_Platform_dataSourcePeeringsActive(t *testing.T) {
+	resID := "p-az-peer-act-" + acctest.RandString(8)
+	dataSourceAddress := "data.hcp_peerings_active.peerings"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccAzurePreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6MuxedProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {VersionConstraint: "~> 3.63"},
+			"azuread": {VersionConstraint: "~> 2.39"},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAzurePeeringConnectionDataSourceConfig(resID) + `
+data "hcp_peerings_active" "peerings" {
+  peerings = [{
+    hvn_link   = hcp_hvn.test.self_link
+    peering_id = hcp_azure_peering_connection.peering.peering_id
+  }]
+
+  depends_on = [azurerm_role_assignment.assignment]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceAddress, "self_links.#", "1"),
+					resource.TestCheckResourceAttrPair(dataSourceAddress, "self_links.0", "hcp_azure_peering_connection.peering", "self_link"),
+				),
+			},
+		},
+	})
+}
@@ -7,12 +7,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"regexp"
 	"strings"
 
 	packerservice "github.com/hashicorp/hcp-sdk-go/clients/cloud-packer-service/stable/2023-01-01/client/packer_service"
 	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -72,6 +74,15 @@ func (r *resourcePackerBucket) Schema(_ context.Context, _ resource.SchemaReques
 				},
 			},
 
+			"labels": schema.MapAttribute{
+				Description: "Key:value labels attached to the bucket, e.g. to describe its artifacts. " +
+					"Changing the labels updates the bucket in place. " +
+					"The provider also attaches the `terraform-managed = \"true\"` label in HCP, to tell the buckets managed by Terraform apart, " +
+					"but leaves it out of this attribute unless it is configured.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+
 			"resource_name": schema.StringAttribute{
 				Computed: true,
 				Description: fmt.Sprintf("The buckets's HCP resource name in the format `%s`.",
@@ -89,11 +100,87 @@ func (r *resourcePackerBucket) Schema(_ context.Context, _ resource.SchemaReques
 	}
 }
 
-// This function is required by the interface but should be unreachable
+// Update updates the labels of the bucket in place. The other user modifiable
+// fields require the bucket to be re-created.
 func (r *resourcePackerBucket) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// In-place update is not supported, the bucket must be re-created to change any user modifable fields
-	resp.Diagnostics.AddError("Unexpected provider error", "This is an internal error, please report this issue to the provider developers")
+	var plan, state bucket
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
+	var labels map[string]string
+	resp.Diagnostics.Append(plan.Labels.ElementsAs(ctx, &labels, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: state.OrganizationID.ValueString(),
+		ProjectID:      state.ProjectID.ValueString(),
+	}
+	name := state.Name.ValueString()
+
+	res, err := packerv2.UpdateBucketLabels(ctx, r.client, loc, name, withManagedLabel(labels))
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating bucket labels", err.Error())
+		return
+	}
+
+	plan.ResourceName = types.StringValue(res.ResourceName)
+	plan.ProjectID = types.StringValue(res.Location.ProjectID)
+	plan.OrganizationID = types.StringValue(res.Location.OrganizationID)
+	plan.CreatedAt = types.StringValue(res.CreatedAt.String())
+	resp.Diagnostics.Append(setBucketLabels(ctx, &plan, res.Labels)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// managedLabel is added, with the value "true", to the labels of the buckets
+// created or updated by the provider, so that they can be told apart from the
+// ones created by Packer or in the HCP Portal, e.g. when looking for buckets
+// to clean up.
+const managedLabel = "terraform-managed"
+
+// withManagedLabel returns the labels to send to HCP for the configured
+// labels, i.e. the configured labels and managedLabel. A configured
+// managedLabel is sent as configured.
+func withManagedLabel(labels map[string]string) map[string]string {
+	if _, ok := labels[managedLabel]; ok {
+		return labels
+	}
+
+	withLabel := make(map[string]string, len(labels)+1)
+	maps.Copy(withLabel, labels)
+	withLabel[managedLabel] = "true"
+	return withLabel
+}
+
+// setBucketLabels sets the labels of the bucket model to those returned by
+// the API. managedLabel is left out, so that it isn't reported as drift,
+// unless the labels of the model, i.e. of the plan or prior state, configure
+// it explicitly. The API doesn't distinguish between no labels and an empty
+// map, so an empty map in the configuration is kept as is.
+func setBucketLabels(ctx context.Context, b *bucket, labels map[string]string) diag.Diagnostics {
+	if _, ok := b.Labels.Elements()[managedLabel]; !ok {
+		labels = maps.Clone(labels)
+		delete(labels, managedLabel)
+	}
+
+	if len(labels) == 0 {
+		if b.Labels.IsNull() || b.Labels.IsUnknown() || len(b.Labels.Elements()) != 0 {
+			b.Labels = types.MapNull(types.StringType)
+		}
+		return nil
+	}
+
+	value, diags := types.MapValueFrom(ctx, types.StringType, labels)
+	b.Labels = value
+	return diags
 }
 
 func (r *resourcePackerBucket) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -125,6 +212,7 @@ type bucket struct {
 	Name           types.String `tfsdk:"name"`
 	ResourceName   types.String `tfsdk:"resource_name"`
 	CreatedAt      types.String `tfsdk:"created_at"`
+	Labels         types.Map    `tfsdk:"labels"`
 }
 
 func (r *resourcePackerBucket) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -147,7 +235,14 @@ func (r *resourcePackerBucket) Create(ctx context.Context, req resource.CreateRe
 		ProjectID:      projectID,
 	}
 	name := plan.Name.ValueString()
-	res, err := packerv2.CreateBucket(ctx, r.client, loc, name)
+
+	var labels map[string]string
+	resp.Diagnostics.Append(plan.Labels.ElementsAs(ctx, &labels, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	res, err := packerv2.CreateBucket(ctx, r.client, loc, name, withManagedLabel(labels))
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating bucket", err.Error())
 		return
@@ -157,6 +252,10 @@ func (r *resourcePackerBucket) Create(ctx context.Context, req resource.CreateRe
 	plan.ProjectID = types.StringValue(res.Location.ProjectID)
 	plan.OrganizationID = types.StringValue(res.Location.OrganizationID)
 	plan.CreatedAt = types.StringValue(res.CreatedAt.String())
+	resp.Diagnostics.Append(setBucketLabels(ctx, &plan, res.Labels)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -193,6 +292,10 @@ func (r *resourcePackerBucket) Read(ctx context.Context, req resource.ReadReques
 	state.CreatedAt = types.StringValue(readBucket.CreatedAt.String())
 	state.ProjectID = types.StringValue(readBucket.Location.ProjectID)
 	state.OrganizationID = types.StringValue(readBucket.Location.OrganizationID)
+	resp.Diagnostics.Append(setBucketLabels(ctx, &state, readBucket.Labels)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Save updated state into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
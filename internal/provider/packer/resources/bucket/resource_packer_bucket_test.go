@@ -4,12 +4,18 @@
 package bucket_test
 
 import (
+	"context"
 	"fmt"
+	"maps"
 	"os"
+	"sort"
 	"testing"
 
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients/packerv2"
 	"github.com/hashicorp/terraform-provider-hcp/internal/provider/acctest"
 	"github.com/hashicorp/terraform-provider-hcp/internal/provider/packer/testutils/testclient"
 )
@@ -81,6 +87,138 @@ func TestAcc_Packer_BucketResource(t *testing.T) {
 	})
 }
 
+func TestAcc_Packer_BucketResource_labels(t *testing.T) {
+	t.Parallel()
+
+	bucketName := "test-bucket-labels"
+	var createdAt, updatedCreatedAt string
+	// A location is required to upsert the Packer Registry
+	// Because of this we have to verify that the acceptance test value is set here, because the acceptance test check normally only occurs inside of resource.Test
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set",
+			resource.EnvTfAcc)
+		return
+	}
+	loc := acctest.DefaultProjectLocation(t)
+	projectID := loc.GetProjectID()
+	resourceName := fmt.Sprintf("packer/project/%s/bucket/%s", projectID, bucketName)
+
+	// Labels are updated in place, so the bucket is never re-created.
+	checkNotRecreated := func(_ *terraform.State) error {
+		if updatedCreatedAt != createdAt {
+			return fmt.Errorf("created_at changed from %s to %s, indicating the bucket was re-created", createdAt, updatedCreatedAt)
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		PreCheck: func() {
+			acctest.PreCheck(t)
+			testclient.UpsertRegistry(t, loc, nil)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: NewPackerBucketResourceConfigBuilder("example").
+					WithName(bucketName).
+					Build(),
+				Check: resource.ComposeTestCheckFunc(
+					// The provider labels the bucket as managed by Terraform in
+					// HCP, without adding the label to the state, which would
+					// show up as drift on the next plan.
+					testAccCheckPackerBucketLabels(t, "hcp_packer_bucket.example", map[string]string{"terraform-managed": "true"}),
+					resource.TestCheckNoResourceAttr("hcp_packer_bucket.example", "labels.%"),
+					testAccPackerBucketSaveCreatedAt("hcp_packer_bucket.example", &createdAt),
+				),
+			},
+			{
+				// Add labels.
+				Config: NewPackerBucketResourceConfigBuilder("example").
+					WithName(bucketName).
+					WithLabels(map[string]string{"os": "alpine", "team": "platform"}).
+					Build(),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("hcp_packer_bucket.example", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("hcp_packer_bucket.example", "resource_name", resourceName),
+					testAccCheckPackerBucketLabels(t, "hcp_packer_bucket.example", map[string]string{"os": "alpine", "team": "platform", "terraform-managed": "true"}),
+					resource.TestCheckResourceAttr("hcp_packer_bucket.example", "labels.%", "2"),
+					resource.TestCheckResourceAttr("hcp_packer_bucket.example", "labels.os", "alpine"),
+					resource.TestCheckResourceAttr("hcp_packer_bucket.example", "labels.team", "platform"),
+					testAccPackerBucketSaveCreatedAt("hcp_packer_bucket.example", &updatedCreatedAt),
+					checkNotRecreated,
+				),
+			},
+			{
+				// Modify one label and remove the other.
+				Config: NewPackerBucketResourceConfigBuilder("example").
+					WithName(bucketName).
+					WithLabels(map[string]string{"os": "alpine-3.20"}).
+					Build(),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("hcp_packer_bucket.example", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("hcp_packer_bucket.example", "resource_name", resourceName),
+					resource.TestCheckResourceAttr("hcp_packer_bucket.example", "labels.%", "1"),
+					resource.TestCheckResourceAttr("hcp_packer_bucket.example", "labels.os", "alpine-3.20"),
+					testAccPackerBucketSaveCreatedAt("hcp_packer_bucket.example", &updatedCreatedAt),
+					checkNotRecreated,
+				),
+			},
+			{
+				// Remove all labels.
+				Config: NewPackerBucketResourceConfigBuilder("example").
+					WithName(bucketName).
+					Build(),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("hcp_packer_bucket.example", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("hcp_packer_bucket.example", "resource_name", resourceName),
+					resource.TestCheckNoResourceAttr("hcp_packer_bucket.example", "labels.%"),
+					testAccPackerBucketSaveCreatedAt("hcp_packer_bucket.example", &updatedCreatedAt),
+					checkNotRecreated,
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckPackerBucketLabels checks that the labels of the bucket in HCP,
+// rather than in the state, are the expected ones.
+func testAccCheckPackerBucketLabels(t *testing.T, resourceName string, expected map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+
+		client := acctest.HCPClients(t)
+		loc := &sharedmodels.HashicorpCloudLocationLocation{
+			OrganizationID: rs.Primary.Attributes["organization_id"],
+			ProjectID:      rs.Primary.Attributes["project_id"],
+		}
+
+		bucket, err := packerv2.GetBucket(context.Background(), client, loc, rs.Primary.Attributes["name"])
+		if err != nil {
+			return err
+		}
+
+		if !maps.Equal(bucket.Labels, expected) {
+			return fmt.Errorf("expected bucket labels to be %v, but got %v", expected, bucket.Labels)
+		}
+		return nil
+	}
+}
+
 // testAccPackerBucketImportID retrieves the resource_name so that it can be imported.
 func testAccPackerBucketImportID(s *terraform.State) (string, error) {
 	rs, ok := s.RootModule().Resources["hcp_packer_bucket.example"]
@@ -116,6 +254,7 @@ type PackerBucketResourceConfigBuilder struct {
 	terraformResourceName string
 	name                  string
 	projectID             string
+	labels                map[string]string
 }
 
 func NewPackerBucketResourceConfigBuilder(terraformResourceName string) PackerBucketResourceConfigBuilder {
@@ -132,20 +271,40 @@ func (b PackerBucketResourceConfigBuilder) WithProjectID(projectID string) Packe
 	return b
 }
 
+func (b PackerBucketResourceConfigBuilder) WithLabels(labels map[string]string) PackerBucketResourceConfigBuilder {
+	b.labels = labels
+	return b
+}
+
 func (b PackerBucketResourceConfigBuilder) Build() string {
 	projectIDText := ""
 	if b.projectID != "" {
 		projectIDText = fmt.Sprintf("project id %q", b.projectID)
 	}
+	labelsText := ""
+	if b.labels != nil {
+		keys := make([]string, 0, len(b.labels))
+		for key := range b.labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		labelsText = "labels = {\n"
+		for _, key := range keys {
+			labelsText += fmt.Sprintf("\t\t%q = %q\n", key, b.labels[key])
+		}
+		labelsText += "\t}"
+	}
 	config := fmt.Sprintf(`
 resource "hcp_packer_bucket" "%s" {
 	name = %q
 	%s
-
+	%s
 }`,
 		b.terraformResourceName,
 		b.name,
 		projectIDText,
+		labelsText,
 	)
 	return config
 }
@@ -6,7 +6,6 @@ package provider
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/client/organization_service"
 	"github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/client/project_service"
@@ -52,28 +51,15 @@ func getProjectFromCredentialsFramework(ctx context.Context, client *clients.Cli
 		diags.AddError(fmt.Sprintf("unable to fetch project id: %v", err), "")
 		return nil, diags
 	}
-	if len(listProjResp.Payload.Projects) == 0 {
+	projects := listProjResp.Payload.Projects
+	if len(projects) == 0 {
 		diags.AddError("The configured credentials does not have access to any project.", "Please assign at least one project to the configured credentials to use this provider.")
 		return nil, diags
 	}
-	if len(listProjResp.Payload.Projects) > 1 {
-		diags.AddWarning("There is more than one project associated with the organization of the configured credentials.", `The oldest project has been selected as the default. To configure which project is used as default, set a project in the HCP provider config block. Resources may also be configured with different projects.`)
-		return getOldestProject(listProjResp.Payload.Projects), diags
+	if len(projects) > 1 {
+		diags.AddError("There is more than one project associated with the organization of the configured credentials.", clients.MultipleProjectsDetail(projects))
+		return nil, diags
 	}
-	project = listProjResp.Payload.Projects[0]
+	project = projects[0]
 	return project, diags
 }
-
-// getOldestProject retrieves the oldest project from a list based on its created_at time.
-func getOldestProject(projects []*models.HashicorpCloudResourcemanagerProject) (oldestProj *models.HashicorpCloudResourcemanagerProject) {
-	oldestTime := time.Now()
-
-	for _, proj := range projects {
-		projTime := time.Time(proj.CreatedAt)
-		if projTime.Before(oldestTime) {
-			oldestProj = proj
-			oldestTime = projTime
-		}
-	}
-	return oldestProj
-}
@@ -8,8 +8,11 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"time"
 
 	"github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/client/project_service"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -20,10 +23,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
 	"github.com/hashicorp/terraform-provider-hcp/internal/provider/iam"
 	"github.com/hashicorp/terraform-provider-hcp/internal/provider/logstreaming"
+	"github.com/hashicorp/terraform-provider-hcp/internal/provider/network"
 	"github.com/hashicorp/terraform-provider-hcp/internal/provider/packer"
 	"github.com/hashicorp/terraform-provider-hcp/internal/provider/resourcemanager"
 	"github.com/hashicorp/terraform-provider-hcp/internal/provider/vaultradar"
@@ -45,11 +50,23 @@ type ProviderFrameworkConfiguration struct {
 }
 
 type ProviderFrameworkModel struct {
-	ClientSecret     types.String `tfsdk:"client_secret"`
-	ClientID         types.String `tfsdk:"client_id"`
-	CredentialFile   types.String `tfsdk:"credential_file"`
-	ProjectID        types.String `tfsdk:"project_id"`
-	WorkloadIdentity types.List   `tfsdk:"workload_identity"`
+	ClientSecret      types.String  `tfsdk:"client_secret"`
+	ClientID          types.String  `tfsdk:"client_id"`
+	ClientCertFile    types.String  `tfsdk:"client_certificate_file"`
+	ClientKeyFile     types.String  `tfsdk:"client_key_file"`
+	CredentialFile    types.String  `tfsdk:"credential_file"`
+	CredentialSource  types.String  `tfsdk:"credential_source"`
+	ProjectID         types.String  `tfsdk:"project_id"`
+	MaxRetries        types.Int64   `tfsdk:"max_retries"`
+	RequestTimeout    types.String  `tfsdk:"request_timeout"`
+	RequestsPerSecond types.Float64 `tfsdk:"requests_per_second"`
+	MaxIdleConns      types.Int64   `tfsdk:"max_idle_conns"`
+	MaxIdleConnsHost  types.Int64   `tfsdk:"max_idle_conns_per_host"`
+	ExtraHeaders      types.Map     `tfsdk:"extra_headers"`
+	SkipWaits         types.Bool    `tfsdk:"skip_waits"`
+	FailOnUnknown     types.Bool    `tfsdk:"fail_on_unknown_states"`
+	UserAgentSuffix   types.String  `tfsdk:"user_agent_suffix"`
+	WorkloadIdentity  types.List    `tfsdk:"workload_identity"`
 }
 
 type WorkloadIdentityFrameworkModel struct {
@@ -84,7 +101,21 @@ func (p *ProviderFramework) Schema(ctx context.Context, req provider.SchemaReque
 			},
 			"project_id": schema.StringAttribute{
 				Optional:    true,
-				Description: "The default project in which resources should be created.",
+				Description: "The default project in which resources should be created. If unset, the organization's only project is used, so it must be set when the organization has more than one project.",
+			},
+			"client_certificate_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path to a PEM encoded client certificate that the provider presents on its requests to the HCP API and auth endpoint, e.g. to a proxy or gateway that requires mutual TLS. Requires `client_key_file`.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key_file")),
+				},
+			},
+			"client_key_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "The path to the PEM encoded private key of `client_certificate_file`. Requires `client_certificate_file`.",
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_certificate_file")),
+				},
 			},
 			"credential_file": schema.StringAttribute{
 				Optional: true,
@@ -96,6 +127,66 @@ func (p *ProviderFramework) Schema(ctx context.Context, req provider.SchemaReque
 					stringvalidator.ConflictsWith(path.MatchRoot("workload_identity")),
 				},
 			},
+			"credential_source": schema.StringAttribute{
+				Optional:    true,
+				Description: "Selects the credentials that the provider authenticates with, rather than using the first ones found. One of `client_credentials` (`client_id` and `client_secret`, or the HCP_CLIENT_ID and HCP_CLIENT_SECRET environment variables), `token` (an access token set by the HCP_ACCESS_TOKEN environment variable), `file` (`credential_file`, or the HCP_CRED_FILE environment variable) or `workload_identity`. It is an error if the selected credentials aren't set.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(clients.CredentialSources...),
+				},
+			},
+			"fail_on_unknown_states": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, waiting for a peering connection to reach a state fails when HCP reports a state that the provider doesn't recognize, e.g. one introduced after this version of the provider was released. By default, such states are treated as transient: a warning is logged and the provider keeps waiting. Defaults to `false`.",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of times a read from HCP is retried when it is throttled or fails with a transient server error. Writes, such as creates, are only retried when throttled, and at most once, so that they aren't applied twice. Defaults to `3`.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"request_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "The maximum duration of a single request to HCP, as a duration string such as `\"45s\"` or `\"2m\"`. By default, requests are only bounded by the timeouts of the resources.",
+			},
+			"requests_per_second": schema.Float64Attribute{
+				Optional:    true,
+				Description: "The maximum number of requests per second that the provider makes to HCP. Useful for large configurations that would otherwise be throttled. Defaults to `0`, which means requests are not rate limited.",
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"max_idle_conns": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of idle connections to HCP that the provider keeps open for reuse. Increasing it can reduce latency for large applies with a high `-parallelism`. Defaults to `100`.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_idle_conns_per_host": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of idle connections per host that the provider keeps open for reuse. Nearly all requests go to the same HCP API host, so it should be at least Terraform's `-parallelism`. Defaults to `20`.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"extra_headers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Additional HTTP headers to set on every request to HCP, e.g. headers required by an egress proxy. The values are treated as sensitive and are never logged.",
+			},
+			"skip_waits": schema.BoolAttribute{
+				Optional:    true,
+				Description: "If true, the provider doesn't wait for operations to complete or for resources to reach a state, e.g. for a cluster to be running or a peering connection to be active, and returns their current state instead. Subsequent resources may then fail, because the resources they depend on aren't ready, and failed operations aren't reported. Defaults to `false`.",
+			},
+			"user_agent_suffix": schema.StringAttribute{
+				Optional:    true,
+				Description: "A product token, such as `my-tool/1.2.3`, appended to the `User-Agent` header of every request to HCP, after the provider's own, e.g. to attribute the requests to a tool that embeds the provider.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(clients.UserAgentSuffixRegexp, "must be a product token, such as \"my-tool/1.2.3\""),
+				},
+			},
 		},
 		Blocks: map[string]schema.Block{
 			// TODO migrate to SingleNestedAttribute once the providersdkv2 is
@@ -199,6 +290,8 @@ func (p *ProviderFramework) DataSources(ctx context.Context) []func() datasource
 		// Resource Manager
 		resourcemanager.NewProjectDataSource,
 		resourcemanager.NewOrganizationDataSource,
+		resourcemanager.NewProviderConfigDataSource,
+		resourcemanager.NewAuthCheckDataSource,
 		resourcemanager.NewIAMPolicyDataSource,
 		// Vault Secrets
 		vaultsecrets.NewVaultSecretsAppDataSource,
@@ -215,6 +308,11 @@ func (p *ProviderFramework) DataSources(ctx context.Context) []func() datasource
 		waypoint.NewTemplateDataSource,
 		waypoint.NewAddOnDataSource,
 		waypoint.NewAddOnDefinitionDataSource,
+		// Network
+		network.NewAzurePeeringConnectionDataSource,
+		network.NewAzurePeeringRequiredPermissionsDataSource,
+		network.NewPeeringsActiveDataSource,
+		network.NewHvnsDataSource,
 	}, packer.DataSourceSchemaBuilders...)
 }
 
@@ -237,6 +335,42 @@ func (p *ProviderFramework) Configure(ctx context.Context, req provider.Configur
 		CredentialFile: data.CredentialFile.ValueString(),
 		ProjectID:      data.ProjectID.ValueString(),
 		SourceChannel:  "terraform-provider-hcp",
+		MaxRetries:     clients.DefaultMaxRetries,
+		RequestTimeout: clients.DefaultRequestTimeout,
+
+		MaxIdleConns:        clients.DefaultMaxIdleConns,
+		MaxIdleConnsPerHost: clients.DefaultMaxIdleConnsPerHost,
+	}
+
+	if !data.MaxIdleConns.IsNull() {
+		clientConfig.MaxIdleConns = int(data.MaxIdleConns.ValueInt64())
+	}
+	if !data.MaxIdleConnsHost.IsNull() {
+		clientConfig.MaxIdleConnsPerHost = int(data.MaxIdleConnsHost.ValueInt64())
+	}
+	if !data.MaxRetries.IsNull() {
+		clientConfig.MaxRetries = int(data.MaxRetries.ValueInt64())
+	}
+	if !data.RequestTimeout.IsNull() {
+		requestTimeout, err := time.ParseDuration(data.RequestTimeout.ValueString())
+		if err != nil || requestTimeout <= 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("request_timeout"), "invalid request_timeout", "must be a positive duration, such as \"45s\" or \"2m\"")
+			return
+		}
+		clientConfig.RequestTimeout = requestTimeout
+	}
+	clientConfig.CredentialSource = data.CredentialSource.ValueString()
+	clientConfig.RequestsPerSecond = data.RequestsPerSecond.ValueFloat64()
+	clientConfig.UserAgentSuffix = data.UserAgentSuffix.ValueString()
+	clientConfig.SkipWaits = data.SkipWaits.ValueBool()
+	clientConfig.FailOnUnknownStates = data.FailOnUnknown.ValueBool()
+	clientConfig.ClientCertificateFile = data.ClientCertFile.ValueString()
+	clientConfig.ClientKeyFile = data.ClientKeyFile.ValueString()
+	if !data.ExtraHeaders.IsNull() {
+		resp.Diagnostics.Append(data.ExtraHeaders.ElementsAs(ctx, &clientConfig.ExtraHeaders, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
 	// Read the workload_identity configuration.
@@ -280,15 +414,15 @@ func (p *ProviderFramework) Configure(ctx context.Context, req provider.Configur
 
 	} else {
 		// For the initial release of the HCP TFP, since only one project was allowed per organization at the time,
-		// the provider handled used the single organization's single project by default, instead of requiring the
-		// user to set it. Once multiple projects are available, this helper issues a warning: when multiple projects exist within the org,
-		// a project ID should be set on the provider or on each resource. Otherwise, the oldest project will be used by default.
-		// This helper will eventually be deprecated after a migration period.
+		// the provider used the single organization's single project by default, instead of requiring the
+		// user to set it. This is still the case when the organization has a single project, but when it has
+		// several, a project ID must be set on the provider rather than one of them being picked.
 		project, projDiags := getProjectFromCredentialsFramework(ctx, client)
 		if projDiags != nil {
 			if !projDiags.HasError() {
 				resp.Diagnostics.Append(projDiags...)
 			} else {
+				resp.Diagnostics.Append(projDiags...)
 				resp.Diagnostics.AddError("unable to get project from credentials", "")
 				return
 			}
@@ -296,6 +430,10 @@ func (p *ProviderFramework) Configure(ctx context.Context, req provider.Configur
 
 		client.Config.OrganizationID = project.Parent.ID
 		client.Config.ProjectID = project.ID
+		tflog.Info(ctx, "No project configured, using the organization's only project", map[string]interface{}{
+			"organization_id": project.Parent.ID,
+			"project_id":      project.ID,
+		})
 	}
 
 	var config ProviderFrameworkConfiguration
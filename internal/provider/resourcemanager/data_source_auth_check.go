@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourcemanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	clients "github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+// authCheckStatusOK is the status of the auth check data source when the HCP
+// API can be reached with the provider's credentials.
+const authCheckStatusOK = "ok"
+
+type DataSourceAuthCheck struct {
+	client *clients.Client
+}
+
+type DataSourceAuthCheckModel struct {
+	Status types.String `tfsdk:"status"`
+	Error  types.String `tfsdk:"error"`
+}
+
+func NewAuthCheckDataSource() datasource.DataSource {
+	return &DataSourceAuthCheck{}
+}
+
+func (d *DataSourceAuthCheck) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auth_check"
+}
+
+func (d *DataSourceAuthCheck) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The auth check data source checks that the HCP API can be reached and accepts the provider's credentials, " +
+			"e.g. as a preflight check in CI. It doesn't fail if they don't, so that the result can be checked with a `check` block or a postcondition.",
+		Attributes: map[string]schema.Attribute{
+			"status": schema.StringAttribute{
+				Description: "The result of the check. One of `ok`, `auth` (the credentials are invalid, expired or not authorized), " +
+					"`network` (no response was received from the HCP API) or `api` (the HCP API failed to respond).",
+				Computed: true,
+			},
+			"error": schema.StringAttribute{
+				Description: "The error that caused the check to fail. Empty if the status is `ok`.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *DataSourceAuthCheck) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *DataSourceAuthCheck) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DataSourceAuthCheckModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Status = types.StringValue(authCheckStatusOK)
+	data.Error = types.StringValue("")
+
+	if err := clients.Ping(ctx, d.client); err != nil {
+		var pingErr *clients.PingError
+		if !errors.As(err, &pingErr) {
+			resp.Diagnostics.AddError("Unable to check the HCP credentials", err.Error())
+			return
+		}
+
+		tflog.Warn(ctx, "HCP auth check failed", map[string]interface{}{"kind": string(pingErr.Kind), "error": err.Error()})
+		data.Status = types.StringValue(string(pingErr.Kind))
+		data.Error = types.StringValue(err.Error())
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourcemanager_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	httptransport "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	cloud_iam "github.com/hashicorp/hcp-sdk-go/clients/cloud-iam/stable/2019-12-10/client"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/hashicorp/terraform-provider-hcp/internal/provider/resourcemanager"
+)
+
+// testAuthCheckTransport responds to every request with the given status code
+// and JSON body.
+type testAuthCheckTransport struct {
+	status int
+	body   string
+}
+
+func (t testAuthCheckTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: t.status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(t.body)),
+		Request:    req,
+	}, nil
+}
+
+func TestAuthCheckDataSource_Read(t *testing.T) {
+	tcs := map[string]struct {
+		transport      testAuthCheckTransport
+		expectedStatus string
+		expectedError  string
+	}{
+		"ok": {
+			transport:      testAuthCheckTransport{status: http.StatusOK, body: `{}`},
+			expectedStatus: "ok",
+		},
+		"invalid credentials": {
+			transport:      testAuthCheckTransport{status: http.StatusUnauthorized, body: `{"code": 16, "message": "unauthenticated"}`},
+			expectedStatus: string(clients.PingErrorAuth),
+			expectedError:  clients.AuthErrorMessage,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+			ctx := context.Background()
+
+			runtime := httptransport.NewWithClient("api.cloud.hashicorp.com", "", []string{"https"}, &http.Client{Transport: tc.transport})
+			client := &clients.Client{IAM: cloud_iam.New(runtime, strfmt.Default).IamService}
+
+			ds := resourcemanager.NewAuthCheckDataSource()
+			ds.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+
+			var schemaResp datasource.SchemaResponse
+			ds.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+			r.False(schemaResp.Diagnostics.HasError())
+
+			objType := schemaResp.Schema.Type().TerraformType(ctx)
+			req := datasource.ReadRequest{
+				Config: tfsdk.Config{
+					Schema: schemaResp.Schema,
+					Raw: tftypes.NewValue(objType, map[string]tftypes.Value{
+						"status": tftypes.NewValue(tftypes.String, nil),
+						"error":  tftypes.NewValue(tftypes.String, nil),
+					}),
+				},
+			}
+			resp := datasource.ReadResponse{
+				State: tfsdk.State{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(objType, nil),
+				},
+			}
+			ds.Read(ctx, req, &resp)
+
+			// Failed checks are reported in the state, rather than as errors.
+			r.False(resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+			var status, errMessage string
+			r.False(resp.State.GetAttribute(ctx, path.Root("status"), &status).HasError())
+			r.False(resp.State.GetAttribute(ctx, path.Root("error"), &errMessage).HasError())
+			r.Equal(tc.expectedStatus, status)
+			if tc.expectedError == "" {
+				r.Empty(errMessage)
+			} else {
+				r.Contains(errMessage, tc.expectedError)
+			}
+		})
+	}
+}
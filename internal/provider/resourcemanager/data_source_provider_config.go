@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourcemanager
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	clients "github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+type DataSourceProviderConfig struct {
+	client *clients.Client
+}
+
+type DataSourceProviderConfigModel struct {
+	OrganizationID types.String `tfsdk:"organization_id"`
+	ProjectID      types.String `tfsdk:"project_id"`
+	APIHost        types.String `tfsdk:"api_host"`
+}
+
+func NewProviderConfigDataSource() datasource.DataSource {
+	return &DataSourceProviderConfig{}
+}
+
+func (d *DataSourceProviderConfig) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_provider_config"
+}
+
+func (d *DataSourceProviderConfig) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "The provider config data source exposes the configuration that the provider resolved from its config block and the environment, " +
+			"e.g. to check which organization and project it uses. It doesn't expose any credentials.",
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.StringAttribute{
+				Description: "The ID of the HCP organization the provider is configured for.",
+				Computed:    true,
+			},
+			"project_id": schema.StringAttribute{
+				Description: "The ID of the HCP project that resources are created in when they don't set a project.",
+				Computed:    true,
+			},
+			"api_host": schema.StringAttribute{
+				Description: "The address of the HCP API that the provider makes requests to, in the format `<hostname>[:port]`.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *DataSourceProviderConfig) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*clients.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *clients.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func (d *DataSourceProviderConfig) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DataSourceProviderConfigModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.OrganizationID = types.StringValue(d.client.Config.OrganizationID)
+	data.ProjectID = types.StringValue(d.client.Config.ProjectID)
+	data.APIHost = types.StringValue(d.client.Config.APIAddress)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
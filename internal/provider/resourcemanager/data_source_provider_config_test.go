@@ -0,0 +1,98 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resourcemanager_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/hashicorp/terraform-provider-hcp/internal/provider/acctest"
+	"github.com/hashicorp/terraform-provider-hcp/internal/provider/resourcemanager"
+)
+
+func TestAccProviderConfigDataSource(t *testing.T) {
+	dataSourceAddress := "data.hcp_provider_config.config"
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "hcp_provider_config" "config" { }
+data "hcp_organization" "org" { }
+data "hcp_project" "project" { }
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(dataSourceAddress, "organization_id", "data.hcp_organization.org", "resource_id"),
+					resource.TestCheckResourceAttrPair(dataSourceAddress, "project_id", "data.hcp_project.project", "resource_id"),
+					resource.TestCheckResourceAttrSet(dataSourceAddress, "api_host"),
+				),
+			},
+		},
+	})
+}
+
+func TestProviderConfigDataSource_Read(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	client := &clients.Client{
+		Config: clients.ClientConfig{
+			ClientID:       "client-id",
+			ClientSecret:   "client-secret",
+			OrganizationID: "f709ec73-55d4-46d8-897d-816ebba28778",
+			ProjectID:      "e20ad934-b88a-4897-a58e-d8318dd43cc3",
+			APIAddress:     "api.hcp.example.com:443",
+		},
+	}
+
+	ds := resourcemanager.NewProviderConfigDataSource()
+	ds.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{ProviderData: client}, &datasource.ConfigureResponse{})
+
+	var schemaResp datasource.SchemaResponse
+	ds.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+	r.False(schemaResp.Diagnostics.HasError())
+
+	objType := schemaResp.Schema.Type().TerraformType(ctx)
+	req := datasource.ReadRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw: tftypes.NewValue(objType, map[string]tftypes.Value{
+				"organization_id": tftypes.NewValue(tftypes.String, nil),
+				"project_id":      tftypes.NewValue(tftypes.String, nil),
+				"api_host":        tftypes.NewValue(tftypes.String, nil),
+			}),
+		},
+	}
+	resp := datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(objType, nil),
+		},
+	}
+	ds.Read(ctx, req, &resp)
+	r.False(resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+	expected := map[string]string{
+		"organization_id": client.Config.OrganizationID,
+		"project_id":      client.Config.ProjectID,
+		"api_host":        client.Config.APIAddress,
+	}
+	for name, value := range expected {
+		var actual string
+		r.False(resp.State.GetAttribute(ctx, path.Root(name), &actual).HasError())
+		r.Equal(value, actual, name)
+	}
+
+	// No credentials are exposed.
+	r.Len(schemaResp.Schema.Attributes, len(expected))
+}
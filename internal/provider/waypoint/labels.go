@@ -0,0 +1,45 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package waypoint
+
+import (
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// managedLabel is added to the labels of the templates and add-on definitions
+// created by the provider, so that they can be told apart from the ones
+// created in the HCP Portal, e.g. when looking for resources to clean up.
+const managedLabel = "terraform-managed"
+
+// withManagedLabel returns the labels to send to HCP for the configured
+// labels, i.e. the configured labels and managedLabel.
+func withManagedLabel(labels []string) []string {
+	if slices.Contains(labels, managedLabel) {
+		return labels
+	}
+	return append(slices.Clip(labels), managedLabel)
+}
+
+// withoutManagedLabel returns the labels to set in the state for the labels
+// read from HCP. managedLabel is left out, so that it isn't reported as drift,
+// unless prior, the labels of the plan or prior state, configures it
+// explicitly. If prior is null and no other labels are left, nil is returned
+// so that the labels stay null rather than becoming an empty list.
+func withoutManagedLabel(labels []string, prior types.List) []string {
+	for _, label := range prior.Elements() {
+		if label, ok := label.(types.String); ok && label.ValueString() == managedLabel {
+			return labels
+		}
+	}
+
+	labels = slices.DeleteFunc(slices.Clone(labels), func(label string) bool {
+		return label == managedLabel
+	})
+	if len(labels) == 0 && prior.IsNull() {
+		return nil
+	}
+	return labels
+}
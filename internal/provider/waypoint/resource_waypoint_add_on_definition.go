@@ -103,7 +103,7 @@ func (r *AddOnDefinitionResource) Schema(ctx context.Context, req resource.Schem
 			"labels": schema.ListAttribute{
 				Computed:    true,
 				Optional:    true,
-				Description: "List of labels attached to this Add-on Definition.",
+				Description: "List of labels attached to this Add-on Definition. The provider also attaches the `terraform-managed` label in HCP, to tell the Add-on Definitions managed by Terraform apart, but leaves it out of this attribute unless it is configured.",
 				ElementType: types.StringType,
 				PlanModifiers: []planmodifier.List{
 					listplanmodifier.UseStateForUnknown(),
@@ -263,7 +263,7 @@ func (r *AddOnDefinitionResource) Create(ctx context.Context, req resource.Creat
 			Name:            plan.Name.ValueString(),
 			Summary:         plan.Summary.ValueString(),
 			Description:     plan.Description.ValueString(),
-			Labels:          stringLabels,
+			Labels:          withManagedLabel(stringLabels),
 			ModuleSource:    plan.TerraformNoCodeModuleSource.ValueString(),
 			VariableOptions: varOpts,
 			TfExecutionMode: plan.TerraformExecutionMode.ValueString(),
@@ -354,7 +354,7 @@ func (r *AddOnDefinitionResource) Create(ctx context.Context, req resource.Creat
 		plan.TerraformAgentPoolID = types.StringNull()
 	}
 
-	labels, diags := types.ListValueFrom(ctx, types.StringType, addOnDefinition.Labels)
+	labels, diags := types.ListValueFrom(ctx, types.StringType, withoutManagedLabel(addOnDefinition.Labels, plan.Labels))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -448,7 +448,7 @@ func (r *AddOnDefinitionResource) Read(ctx context.Context, req resource.ReadReq
 		state.TerraformAgentPoolID = types.StringNull()
 	}
 
-	labels, diags := types.ListValueFrom(ctx, types.StringType, definition.Labels)
+	labels, diags := types.ListValueFrom(ctx, types.StringType, withoutManagedLabel(definition.Labels, state.Labels))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -527,7 +527,7 @@ func (r *AddOnDefinitionResource) Update(ctx context.Context, req resource.Updat
 			Name:            plan.Name.ValueString(),
 			Summary:         plan.Summary.ValueString(),
 			Description:     plan.Description.ValueString(),
-			Labels:          stringLabels,
+			Labels:          withManagedLabel(stringLabels),
 			ModuleSource:    plan.TerraformNoCodeModuleSource.ValueString(),
 			ModuleID:        plan.TerraformNoCodeModuleSource.ValueString(),
 			VariableOptions: varOpts,
@@ -617,7 +617,7 @@ func (r *AddOnDefinitionResource) Update(ctx context.Context, req resource.Updat
 		plan.TerraformAgentPoolID = types.StringNull()
 	}
 
-	labels, diags := types.ListValueFrom(ctx, types.StringType, addOnDefinition.Labels)
+	labels, diags := types.ListValueFrom(ctx, types.StringType, withoutManagedLabel(addOnDefinition.Labels, plan.Labels))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
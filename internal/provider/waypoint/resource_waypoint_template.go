@@ -147,7 +147,7 @@ func (r *TemplateResource) Schema(ctx context.Context, req resource.SchemaReques
 			"labels": schema.ListAttribute{
 				// Computed:    true,
 				Optional:    true,
-				Description: "List of labels attached to this Template.",
+				Description: "List of labels attached to this Template. The provider also attaches the `terraform-managed` label in HCP, to tell the Templates managed by Terraform apart, but leaves it out of this attribute unless it is configured.",
 				ElementType: types.StringType,
 				PlanModifiers: []planmodifier.List{
 					listplanmodifier.UseStateForUnknown(),
@@ -325,7 +325,7 @@ func (r *TemplateResource) Create(ctx context.Context, req resource.CreateReques
 			ActionCfgRefs:                  actions,
 			Name:                           plan.Name.ValueString(),
 			Summary:                        plan.Summary.ValueString(),
-			Labels:                         strLabels,
+			Labels:                         withManagedLabel(strLabels),
 			Description:                    plan.Description.ValueString(),
 			ModuleSource:                   plan.TerraformNoCodeModuleSource.ValueString(),
 			ModuleID:                       plan.TerraformNoCodeModuleID.ValueString(),
@@ -385,7 +385,7 @@ func (r *TemplateResource) Create(ctx context.Context, req resource.CreateReques
 		plan.TerraformProjectID = types.StringValue(appTemplate.TerraformCloudWorkspaceDetails.ProjectID)
 	}
 
-	labels, diags := types.ListValueFrom(ctx, types.StringType, appTemplate.Labels)
+	labels, diags := types.ListValueFrom(ctx, types.StringType, withoutManagedLabel(appTemplate.Labels, plan.Labels))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -525,7 +525,7 @@ func (r *TemplateResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	labels, diags := types.ListValueFrom(ctx, types.StringType, appTemplate.Labels)
+	labels, diags := types.ListValueFrom(ctx, types.StringType, withoutManagedLabel(appTemplate.Labels, data.Labels))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -644,7 +644,7 @@ func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateReques
 			ActionCfgRefs:                  actions,
 			Name:                           plan.Name.ValueString(),
 			Summary:                        plan.Summary.ValueString(),
-			Labels:                         strLabels,
+			Labels:                         withManagedLabel(strLabels),
 			Description:                    plan.Description.ValueString(),
 			ModuleSource:                   plan.TerraformNoCodeModuleSource.ValueString(),
 			ModuleID:                       plan.TerraformNoCodeModuleID.ValueString(),
@@ -705,7 +705,7 @@ func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateReques
 		plan.TerraformProjectID = types.StringValue(appTemplate.TerraformCloudWorkspaceDetails.ProjectID)
 	}
 
-	labels, diags := types.ListValueFrom(ctx, types.StringType, appTemplate.Labels)
+	labels, diags := types.ListValueFrom(ctx, types.StringType, withoutManagedLabel(appTemplate.Labels, plan.Labels))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
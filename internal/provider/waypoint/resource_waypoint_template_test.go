@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"slices"
 	"testing"
 	"time"
 
@@ -41,6 +42,11 @@ func TestAcc_Waypoint_Template_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "name", name),
 					resource.TestCheckResourceAttr(resourceName, "terraform_no_code_module_id", "nocode-7ZQjQoaPXvzs6Hvp"),
 					resource.TestCheckResourceAttr(resourceName, "terraform_execution_mode", "remote"),
+					// The provider labels the template as managed by Terraform in
+					// HCP, without adding the label to the state, which would
+					// show up as drift on the next plan.
+					testAccCheckWaypointTemplateLabels(t, resourceName, []string{"one", "two", "terraform-managed"}),
+					resource.TestCheckResourceAttr(resourceName, "labels.#", "2"),
 				),
 			},
 			{
@@ -55,6 +61,32 @@ func TestAcc_Waypoint_Template_basic(t *testing.T) {
 	})
 }
 
+// A test that ensures a template without labels keeps them null in the state,
+// even though HCP reports the label added by the provider.
+func TestAcc_Waypoint_Template_noLabels(t *testing.T) {
+	t.Parallel()
+
+	var appTemplateModel waypoint.TemplateResourceModel
+	resourceName := "hcp_waypoint_template.no_labels_test"
+	name := generateRandomName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckWaypointTemplateDestroy(t, &appTemplateModel),
+		Steps: []resource.TestStep{
+			{
+				Config: testTemplateConfigNoLabels(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWaypointTemplateExists(t, resourceName, &appTemplateModel),
+					testAccCheckWaypointTemplateLabels(t, resourceName, []string{"terraform-managed"}),
+					resource.TestCheckNoResourceAttr(resourceName, "labels.#"),
+				),
+			},
+		},
+	})
+}
+
 // A test that ensures a template can be assigned with an action, and that
 // action gets created prior to the terraform resource. Creating a template
 // resource will fail if that action does not exist.
@@ -169,6 +201,33 @@ func testAccCheckWaypointTemplateExists(t *testing.T, resourceName string, appTe
 	}
 }
 
+// testAccCheckWaypointTemplateLabels checks that the labels of the template in
+// HCP, rather than in the state, are the expected ones.
+func testAccCheckWaypointTemplateLabels(t *testing.T, resourceName string, expected []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+
+		client := acctest.HCPClients(t)
+		loc := &sharedmodels.HashicorpCloudLocationLocation{
+			OrganizationID: client.Config.OrganizationID,
+			ProjectID:      rs.Primary.Attributes["project_id"],
+		}
+
+		template, err := clients.GetApplicationTemplateByID(context.Background(), client, loc, rs.Primary.Attributes["id"])
+		if err != nil {
+			return err
+		}
+
+		if !slices.Equal(template.Labels, expected) {
+			return fmt.Errorf("expected template labels to be %v, but got %v", expected, template.Labels)
+		}
+		return nil
+	}
+}
+
 func testAccCheckWaypointTemplateDestroy(t *testing.T, appTemplateModel *waypoint.TemplateResourceModel) resource.TestCheckFunc {
 	return func(_ *terraform.State) error {
 		client := acctest.HCPClients(t)
@@ -218,6 +277,23 @@ resource "hcp_waypoint_template" "test" {
 }`, name)
 }
 
+func testTemplateConfigNoLabels(name string) string {
+	return fmt.Sprintf(`
+resource "hcp_waypoint_template" "no_labels_test" {
+  name                     = "%s"
+  summary                  = "A template without labels."
+  readme_markdown_template = base64encode("# Some Readme")
+  terraform_no_code_module_source = "private/waypoint-tfc-testing/waypoint-template-starter/null"
+  terraform_no_code_module_id = "nocode-7ZQjQoaPXvzs6Hvp"
+  terraform_project_id = "prj-gfVyPJ2q2Aurn25o"
+  terraform_cloud_workspace_details = {
+    name                 = "Default Project"
+    terraform_project_id = "prj-gfVyPJ2q2Aurn25o"
+  }
+  terraform_execution_mode = "remote"
+}`, name)
+}
+
 func testTemplateWithActionsConfig(
 	templateName string,
 	actionName string,
@@ -0,0 +1,70 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+// apiErrorDiag returns an error diagnostic whose summary is built from format
+// and args, followed by err. If err is the result of a failed HCP API request,
+// the message reported by the API is used instead, and the HTTP and gRPC
+// status codes are included in the detail, so they can be quoted when
+// reporting the failure. Failures caused by the provider's credentials are
+// reported as such, since they can't be fixed by changing the configuration
+// of the resource. Timeouts name the timeout that was reached, so that the
+// right one can be increased.
+func apiErrorDiag(err error, format string, args ...interface{}) diag.Diagnostics {
+	summary := fmt.Sprintf(format, args...)
+
+	apiErr, ok := clients.ParseAPIError(err)
+	if !ok {
+		if timeoutSummary, detail, ok := clients.TimeoutSummary(err); ok {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("%s: %s", summary, timeoutSummary),
+				Detail:   detail,
+			}}
+		}
+		if clients.IsAuthError(err) {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("%s: %s", summary, clients.AuthErrorMessage),
+				Detail:   err.Error(),
+			}}
+		}
+		return diag.Errorf("%s: %v", summary, err)
+	}
+
+	message := apiErr.Message
+	if clients.IsAuthError(err) {
+		message = fmt.Sprintf("%s (%s)", clients.AuthErrorMessage, apiErr.Message)
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("%s: %s", summary, message),
+		Detail:   fmt.Sprintf("HTTP status code: %d\ngRPC status code: %d (%s)", apiErr.HTTPCode, apiErr.GRPCCode, apiErr.GRPCCode),
+	}}
+}
+
+// partialReadWarning returns a warning diagnostic for err, returned by a
+// secondary request made while reading a resource, e.g. to list the routes
+// that depend on it. Such failures shouldn't make the resource unmanageable,
+// so the read carries on and the affected attributes keep the values of the
+// last successful read. The warning is built like apiErrorDiag's error.
+func partialReadWarning(err error, format string, args ...interface{}) diag.Diagnostics {
+	diags := apiErrorDiag(err, format, args...)
+	log.Printf("[WARN] %s", diags[0].Summary)
+
+	diags[0].Severity = diag.Warning
+	diags[0].Detail = strings.TrimSpace("The other attributes were refreshed, while the affected ones keep the values of the last successful read.\n\n" + diags[0].Detail)
+	return diags
+}
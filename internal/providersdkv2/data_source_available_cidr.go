@@ -0,0 +1,212 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+func dataSourceAvailableCIDR() *schema.Resource {
+	return &schema.Resource{
+		Description: "The available CIDR data source suggests a CIDR block that doesn't overlap the CIDR blocks already in use, " +
+			"e.g. to pick the `cidr_block` of a new HVN or the `destination_cidr` of a new HVN route.",
+		ReadContext: dataSourceAvailableCIDRRead,
+		Timeouts: &schema.ResourceTimeout{
+			Default: &hvnDefaultTimeout,
+		},
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"prefix_length": {
+				Description:  "The prefix length of the suggested CIDR block, e.g. `20` for a `/20` block.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(1, 32),
+			},
+			// Optional inputs
+			"hvn_link": {
+				Description: "The `self_link` of an HVN. The CIDR blocks of the HVN and the destination CIDRs of its routes are in use, apart from default routes.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"existing_cidrs": {
+				Description: "CIDR blocks that are in use, e.g. those of the networks the HVN will be peered to.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDR,
+				},
+			},
+			"within": {
+				Description: "The CIDR blocks to suggest a CIDR block from, in order of preference. Defaults to the RFC 1918 ranges `10.0.0.0/8`, `192.168.0.0/16` and `172.16.0.0/12`.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsCIDR,
+				},
+			},
+			// Computed outputs
+			"cidr_block": {
+				Description: "The suggested CIDR block: the first block of `prefix_length` in `within` that doesn't overlap any CIDR block in use.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceAvailableCIDRRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client)
+
+	var used []string
+	for _, cidr := range d.Get("existing_cidrs").(*schema.Set).List() {
+		used = append(used, cidr.(string))
+	}
+
+	if hvn := d.Get("hvn_link").(string); hvn != "" {
+		hvnLink, err := buildLinkFromURL(hvn, HvnResourceType, client.Config.OrganizationID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		log.Printf("[INFO] Reading HVN (%s) [project_id=%s]", hvnLink.ID, hvnLink.Location.ProjectID)
+		network, err := clients.GetHvnByIDCached(ctx, client, hvnLink.Location, hvnLink.ID)
+		if err != nil {
+			return apiErrorDiag(err, "unable to retrieve HVN (%s)", hvnLink.ID)
+		}
+		if network.CidrBlock != "" {
+			used = append(used, network.CidrBlock)
+		}
+
+		log.Printf("[INFO] Listing HVN routes of HVN (%s)", hvnLink.ID)
+		routes, err := clients.ListHVNRoutes(ctx, client, hvnLink.ID, "", "", "", hvnLink.Location)
+		if err != nil {
+			return apiErrorDiag(err, "unable to list HVN routes of HVN (%s)", hvnLink.ID)
+		}
+		for _, route := range routes {
+			// A default route would leave no CIDR block available.
+			if isDefaultRouteCIDR(route.Destination) {
+				continue
+			}
+			used = append(used, route.Destination)
+		}
+	}
+
+	var within []string
+	for _, cidr := range d.Get("within").([]interface{}) {
+		within = append(within, cidr.(string))
+	}
+	if len(within) == 0 {
+		for _, network := range RFC1918Networks {
+			within = append(within, network.String())
+		}
+	}
+
+	prefixLength := d.Get("prefix_length").(int)
+	cidr, err := nextAvailableCIDR(within, used, prefixLength)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(cidr.String())
+	if err := d.Set("cidr_block", cidr.String()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// nextAvailableCIDR returns the first IPv4 CIDR block with the given prefix
+// length that is contained in one of the within blocks, tried in order, and
+// doesn't overlap any of the used blocks.
+func nextAvailableCIDR(within, used []string, prefixLength int) (netip.Prefix, error) {
+	parents, err := parseIPv4Prefixes(within)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid within: %v", err)
+	}
+	usedPrefixes, err := parseIPv4Prefixes(used)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid CIDR block in use: %v", err)
+	}
+
+	for _, parent := range parents {
+		if prefixLength < parent.Bits() {
+			continue
+		}
+
+		candidate := netip.PrefixFrom(parent.Addr(), prefixLength)
+		for parent.Contains(candidate.Addr()) {
+			overlapping, ok := firstOverlappingPrefix(candidate, usedPrefixes)
+			if !ok {
+				return candidate, nil
+			}
+
+			// Skip past whichever of the two blocks is larger. The next
+			// address after a block is aligned to its prefix length, so it
+			// is aligned to the candidate's too.
+			skip := candidate
+			if overlapping.Bits() < candidate.Bits() {
+				skip = overlapping
+			}
+			next := lastAddr(skip).Next()
+			if !next.IsValid() {
+				// The block ends at 255.255.255.255.
+				break
+			}
+			candidate = netip.PrefixFrom(next, prefixLength)
+		}
+	}
+
+	return netip.Prefix{}, fmt.Errorf("no /%d CIDR block in %s is available", prefixLength, strings.Join(within, ", "))
+}
+
+// parseIPv4Prefixes parses the IPv4 CIDR blocks in cidrs, masking any host
+// bits.
+func parseIPv4Prefixes(cidrs []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, err
+		}
+		if !prefix.Addr().Is4() {
+			return nil, fmt.Errorf("%s isn't an IPv4 CIDR block", cidr)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+
+	return prefixes, nil
+}
+
+// firstOverlappingPrefix returns the first of the prefixes that overlaps p.
+func firstOverlappingPrefix(p netip.Prefix, prefixes []netip.Prefix) (netip.Prefix, bool) {
+	for _, prefix := range prefixes {
+		if p.Overlaps(prefix) {
+			return prefix, true
+		}
+	}
+
+	return netip.Prefix{}, false
+}
+
+// lastAddr returns the last address of the masked IPv4 prefix p.
+func lastAddr(p netip.Prefix) netip.Addr {
+	first := p.Addr().As4()
+	last := binary.BigEndian.Uint32(first[:]) | (1<<(32-p.Bits()) - 1)
+
+	var addr [4]byte
+	binary.BigEndian.PutUint32(addr[:], last)
+	return netip.AddrFrom4(addr)
+}
@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/client/network_service"
+	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_nextAvailableCIDR(t *testing.T) {
+	tcs := map[string]struct {
+		within        []string
+		used          []string
+		prefixLength  int
+		expected      string
+		expectedError string
+	}{
+		"nothing in use": {
+			within:       []string{"10.0.0.0/8"},
+			prefixLength: 16,
+			expected:     "10.0.0.0/16",
+		},
+		"whole block": {
+			within:       []string{"10.0.0.0/8"},
+			prefixLength: 8,
+			expected:     "10.0.0.0/8",
+		},
+		"skips a block in use": {
+			within:       []string{"10.0.0.0/8"},
+			used:         []string{"10.0.0.0/16"},
+			prefixLength: 16,
+			expected:     "10.1.0.0/16",
+		},
+		"skips a smaller block in use": {
+			within:       []string{"10.0.0.0/8"},
+			used:         []string{"10.0.4.0/24"},
+			prefixLength: 16,
+			expected:     "10.1.0.0/16",
+		},
+		"skips a larger block in use": {
+			within:       []string{"172.16.0.0/12"},
+			used:         []string{"172.16.0.0/14"},
+			prefixLength: 20,
+			expected:     "172.20.0.0/20",
+		},
+		"fills gaps": {
+			within:       []string{"10.0.0.0/8"},
+			used:         []string{"10.0.0.0/24", "10.0.2.0/23", "10.0.1.0/25"},
+			prefixLength: 25,
+			expected:     "10.0.1.128/25",
+		},
+		"ignores blocks outside": {
+			within:       []string{"10.0.0.0/8"},
+			used:         []string{"172.25.16.0/20", "192.168.0.0/16"},
+			prefixLength: 20,
+			expected:     "10.0.0.0/20",
+		},
+		"masks host bits": {
+			within:       []string{"10.0.0.1/8"},
+			used:         []string{"10.0.0.1/16"},
+			prefixLength: 16,
+			expected:     "10.1.0.0/16",
+		},
+		"falls back to the next block": {
+			within:       []string{"192.168.0.0/16", "172.16.0.0/12"},
+			used:         []string{"192.168.0.0/17", "192.168.128.0/17"},
+			prefixLength: 20,
+			expected:     "172.16.0.0/20",
+		},
+		"skips blocks that are too small": {
+			within:       []string{"192.168.0.0/16", "10.0.0.0/8"},
+			prefixLength: 12,
+			expected:     "10.0.0.0/12",
+		},
+		"last block of the address space": {
+			within:       []string{"255.255.255.0/24"},
+			used:         []string{"255.255.255.0/25"},
+			prefixLength: 25,
+			expected:     "255.255.255.128/25",
+		},
+		"address space exhausted": {
+			within:        []string{"255.255.255.0/24"},
+			used:          []string{"255.255.255.0/25", "255.255.255.128/25"},
+			prefixLength:  25,
+			expectedError: "no /25 CIDR block in 255.255.255.0/24 is available",
+		},
+		"block in use contains within": {
+			within:        []string{"10.1.0.0/16"},
+			used:          []string{"10.0.0.0/8"},
+			prefixLength:  24,
+			expectedError: "no /24 CIDR block in 10.1.0.0/16 is available",
+		},
+		"prefix length shorter than within": {
+			within:        []string{"192.168.0.0/16"},
+			prefixLength:  8,
+			expectedError: "no /8 CIDR block in 192.168.0.0/16 is available",
+		},
+		"invalid within": {
+			within:        []string{"10.0.0.0"},
+			prefixLength:  16,
+			expectedError: "invalid within",
+		},
+		"ipv6": {
+			within:        []string{"10.0.0.0/8"},
+			used:          []string{"fd00::/8"},
+			prefixLength:  16,
+			expectedError: "fd00::/8 isn't an IPv4 CIDR block",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			cidr, err := nextAvailableCIDR(tc.within, tc.used, tc.prefixLength)
+			if tc.expectedError != "" {
+				r.ErrorContains(err, tc.expectedError)
+				return
+			}
+			r.NoError(err)
+			r.Equal(tc.expected, cidr.String())
+		})
+	}
+}
+
+func Test_dataSourceAvailableCIDRRead(t *testing.T) {
+	r := require.New(t)
+
+	client := &clients.Client{
+		Config: clients.ClientConfig{OrganizationID: testOrganizationID, ProjectID: testProjectID},
+		Network: &testNetworkClient{
+			get: func(params *network_service.GetParams) (*network_service.GetOK, error) {
+				r.Equal("test-hvn", params.ID)
+				return &network_service.GetOK{
+					Payload: &networkmodels.HashicorpCloudNetwork20200907GetResponse{
+						Network: &networkmodels.HashicorpCloudNetwork20200907Network{ID: params.ID, CidrBlock: "172.16.0.0/20", Location: testLocation()},
+					},
+				}, nil
+			},
+			listHVNRoutes: func(params *network_service.ListHVNRoutesParams) (*network_service.ListHVNRoutesOK, error) {
+				r.Equal("test-hvn", params.HvnID)
+				return &network_service.ListHVNRoutesOK{
+					Payload: &networkmodels.HashicorpCloudNetwork20200907ListHVNRoutesResponse{
+						Routes: []*networkmodels.HashicorpCloudNetwork20200907HVNRoute{
+							{ID: "route-a", Destination: "172.16.16.0/20"},
+							{ID: "route-b", Destination: defaultRouteCIDR},
+						},
+					},
+				}, nil
+			},
+		},
+	}
+
+	// The HVN's CIDR block, its routes' destinations, and the existing CIDRs
+	// are all in use, but its default route isn't.
+	d := schema.TestResourceDataRaw(t, dataSourceAvailableCIDR().Schema, map[string]interface{}{
+		"hvn_link":       testHvnLink("test-hvn"),
+		"existing_cidrs": []interface{}{"172.16.32.0/19"},
+		"within":         []interface{}{"172.16.0.0/12"},
+		"prefix_length":  20,
+	})
+	r.Empty(dataSourceAvailableCIDRRead(context.Background(), d, client))
+	r.Equal("172.16.64.0/20", d.Get("cidr_block"))
+	r.Equal("172.16.64.0/20", d.Id())
+
+	// within defaults to the RFC 1918 ranges.
+	d = schema.TestResourceDataRaw(t, dataSourceAvailableCIDR().Schema, map[string]interface{}{
+		"existing_cidrs": []interface{}{"10.0.0.0/9", "10.128.0.0/9"},
+		"prefix_length":  16,
+	})
+	r.Empty(dataSourceAvailableCIDRRead(context.Background(), d, client))
+	r.Equal("192.168.0.0/16", d.Get("cidr_block"))
+}
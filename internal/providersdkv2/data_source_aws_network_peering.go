@@ -7,8 +7,9 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"slices"
+	"time"
 
-	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
 	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -39,16 +40,26 @@ func dataSourceAwsNetworkPeering() *schema.Resource {
 			},
 			// Optional inputs
 			"wait_for_active_state": {
-				Description: "If `true`, Terraform will wait for the network peering to reach an `ACTIVE` state before continuing. Default `false`.",
+				Description: "If `true`, Terraform will wait for the network peering to reach an `ACTIVE` state before continuing, for at most the `read` timeout. If `false`, the default, the network peering is returned immediately in its current state, e.g. `PENDING_ACCEPTANCE`, without an error, which suits network peerings that are accepted outside of Terraform.",
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
 			},
+			"acceptable_states": {
+				Description: "The states that `wait_for_active_state` waits for the network peering to reach any of, for workflows that only need the network peering to be partially ready. Valid options are `CREATING`, `PENDING_ACCEPTANCE`, `ACCEPTED` and `ACTIVE`. Reaching `ACTIVE` always completes the wait. Defaults to `[\"ACTIVE\"]`.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(clients.PeeringAcceptableStates, false),
+				},
+			},
 			"project_id": {
 				Description: `
 The ID of the HCP project where the network peering is located. Always matches the HVN's project.
 If not specified, the project specified in the HCP Provider config block will be used, if configured.
-If a project is not configured in the HCP Provider config block, the oldest project in the organization will be used.`,
+If a project is not configured in the HCP Provider config block, the organization's only project will be used.`,
 				Type:         schema.TypeString,
 				Optional:     true,
 				ValidateFunc: validation.IsUUID,
@@ -60,6 +71,8 @@ If a project is not configured in the HCP Provider config block, the oldest proj
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"location":       peeringLocationSchema("network peering"),
+			"cloud_provider": peeringCloudProviderSchema("network peering"),
 			"peer_account_id": {
 				Description: "The account ID of the peer VPC in AWS.",
 				Type:        schema.TypeString,
@@ -90,6 +103,22 @@ If a project is not configured in the HCP Provider config block, the oldest proj
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"updated_at": {
+				Description: "The time that the peering connection was last updated.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"raw_json": peeringRawJSONSchema("network peering"),
+			"seconds_to_expiry": {
+				Description: "The number of seconds left to accept the network peering before it expires, as of the last refresh. `0` if the network peering isn't in a `PENDING_ACCEPTANCE` state.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"last_refreshed": {
+				Description: "The time that the network peering was last read from HCP, in RFC 3339 format. It changes on every refresh, e.g. to confirm that `state` is up to date.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 			"self_link": {
 				Description: "A unique URL identifying the network peering.",
 				Type:        schema.TypeString,
@@ -121,6 +150,7 @@ func dataSourceAwsNetworkPeeringRead(ctx context.Context, d *schema.ResourceData
 	hvnID := d.Get("hvn_id").(string)
 	peeringID := d.Get("peering_id").(string)
 	waitForActive := d.Get("wait_for_active_state").(bool)
+	acceptableStates := peeringAcceptableStates(d)
 
 	// Query for the peering.
 	log.Printf("[INFO] Reading network peering (%s)", peeringID)
@@ -141,9 +171,13 @@ func dataSourceAwsNetworkPeeringRead(ctx context.Context, d *schema.ResourceData
 	if err := setAwsPeeringResourceData(d, peering); err != nil {
 		return diag.FromErr(err)
 	}
+	if err := setPeeringLastRefreshed(d); err != nil {
+		return diag.FromErr(err)
+	}
 
 	// Skip waiting.
-	if !waitForActive || *peering.State == models.HashicorpCloudNetwork20200907PeeringStateACTIVE {
+	state := string(*peering.State)
+	if !waitForActive || state == clients.PeeringStateActive || slices.Contains(acceptableStates, state) {
 		return nil
 	}
 
@@ -167,10 +201,12 @@ func dataSourceAwsNetworkPeeringRead(ctx context.Context, d *schema.ResourceData
 
 	// Store resource data again, updating Peering state.
 	var result []diag.Diagnostic
-	peering, err = clients.WaitForPeeringToBeActive(ctx, client, peering.ID, hvnID, loc, peeringCreateTimeout)
+	peering, err = clients.WaitForPeeringToBeAnyOf(acceptableStates)(ctx, client, peering.ID, hvnID, loc, d.Timeout(schema.TimeoutRead))
 	if peering != nil {
 		if err := setAwsPeeringResourceData(d, peering); err != nil {
 			result = diag.FromErr(err)
+		} else if err := setPeeringLastRefreshed(d); err != nil {
+			result = diag.FromErr(err)
 		}
 	}
 
@@ -182,3 +218,27 @@ func dataSourceAwsNetworkPeeringRead(ctx context.Context, d *schema.ResourceData
 	}
 	return result
 }
+
+// peeringAcceptableStates returns the acceptable_states of a peering data
+// source, which default to ACTIVE.
+func peeringAcceptableStates(d *schema.ResourceData) []string {
+	configured := d.Get("acceptable_states").([]interface{})
+	if len(configured) == 0 {
+		return []string{clients.PeeringStateActive}
+	}
+
+	states := make([]string, 0, len(configured))
+	for _, state := range configured {
+		states = append(states, state.(string))
+	}
+	return states
+}
+
+// peeringLastRefreshedNow returns the time that is set as last_refreshed, and
+// is replaced by tests.
+var peeringLastRefreshedNow = time.Now
+
+// setPeeringLastRefreshed sets last_refreshed to the current time.
+func setPeeringLastRefreshed(d *schema.ResourceData) error {
+	return d.Set("last_refreshed", peeringLastRefreshedNow().UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,219 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/client/network_service"
+	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/stretchr/testify/require"
+)
+
+// testPendingAwsPeering returns an AWS network peering that is waiting to be
+// accepted.
+func testPendingAwsPeering() *networkmodels.HashicorpCloudNetwork20200907Peering {
+	return testPeering("test-peering", networkmodels.HashicorpCloudNetwork20200907PeeringStatePENDINGACCEPTANCE, &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{
+		AwsTarget: &networkmodels.HashicorpCloudNetwork20200907AWSPeeringTarget{
+			AccountID: "123456789012",
+			VpcID:     "vpc-0123456789",
+			Region:    "us-east-1",
+		},
+	})
+}
+
+func Test_dataSourceAwsNetworkPeeringRead_pendingAcceptance(t *testing.T) {
+	tcs := map[string]map[string]interface{}{
+		"default": {},
+		"wait_for_active_state disabled": {
+			"wait_for_active_state": false,
+		},
+	}
+
+	for n, raw := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			raw["hvn_id"] = "test-hvn"
+			raw["peering_id"] = "test-peering"
+
+			gets := 0
+			client := &clients.Client{
+				Config: clients.ClientConfig{ProjectID: testProjectID},
+				Network: &testNetworkClient{
+					getPeering: func(params *network_service.GetPeeringParams) (*network_service.GetPeeringOK, error) {
+						gets++
+						r.Equal("test-hvn", params.HvnID)
+						r.Equal("test-peering", params.ID)
+
+						return testPeeringOK(testPendingAwsPeering()), nil
+					},
+				},
+			}
+
+			d := schema.TestResourceDataRaw(t, dataSourceAwsNetworkPeering().Schema, raw)
+
+			diags := dataSourceAwsNetworkPeeringRead(context.Background(), d, client)
+			r.Empty(diags)
+			r.Equal(1, gets, "the peering should be read once, without waiting for it to become active")
+			r.Equal("PENDING_ACCEPTANCE", d.Get("state"))
+		})
+	}
+}
+
+func Test_dataSourceAwsNetworkPeeringRead_waitTimeout(t *testing.T) {
+	r := require.New(t)
+
+	// The peering is never accepted, so it never becomes active.
+	var gets atomic.Int32
+	client := &clients.Client{
+		Config: clients.ClientConfig{ProjectID: testProjectID, PollInterval: 10 * time.Millisecond},
+		Network: &testNetworkClient{
+			getPeering: func(_ *network_service.GetPeeringParams) (*network_service.GetPeeringOK, error) {
+				gets.Add(1)
+				return testPeeringOK(testPendingAwsPeering()), nil
+			},
+		},
+	}
+
+	res := dataSourceAwsNetworkPeering()
+	readTimeout := 200 * time.Millisecond
+	res.Timeouts = &schema.ResourceTimeout{Read: &readTimeout}
+	d := res.Data(&terraform.InstanceState{
+		Attributes: map[string]string{
+			"hvn_id":                "test-hvn",
+			"peering_id":            "test-peering",
+			"wait_for_active_state": "true",
+		},
+	})
+
+	start := time.Now()
+	diags := dataSourceAwsNetworkPeeringRead(context.Background(), d, client)
+	r.Less(time.Since(start), 10*time.Second, "the wait should be limited by the read timeout")
+
+	r.True(diags.HasError())
+	r.Contains(diags[0].Summary, "error waiting for peering connection (test-peering) to become 'ACTIVE'")
+	r.Contains(diags[0].Summary, "last state: 'PENDING_ACCEPTANCE'")
+	r.Greater(gets.Load(), int32(1))
+	r.Equal("PENDING_ACCEPTANCE", d.Get("state"))
+}
+
+func Test_dataSourceAwsNetworkPeeringRead_acceptableStates(t *testing.T) {
+	tcs := map[string]struct {
+		acceptableStates []interface{}
+		expectedState    string
+		expectedGets     int32
+	}{
+		"default": {
+			expectedState: "ACTIVE",
+			expectedGets:  5,
+		},
+		"pending acceptance": {
+			acceptableStates: []interface{}{"PENDING_ACCEPTANCE"},
+			expectedState:    "PENDING_ACCEPTANCE",
+			expectedGets:     3,
+		},
+		"accepted or active": {
+			acceptableStates: []interface{}{"ACCEPTED", "ACTIVE"},
+			expectedState:    "ACCEPTED",
+			expectedGets:     4,
+		},
+		"already acceptable": {
+			acceptableStates: []interface{}{"CREATING"},
+			expectedState:    "CREATING",
+			expectedGets:     1,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			sequence := []networkmodels.HashicorpCloudNetwork20200907PeeringState{
+				networkmodels.HashicorpCloudNetwork20200907PeeringStateCREATING,
+				networkmodels.HashicorpCloudNetwork20200907PeeringStateCREATING,
+				networkmodels.HashicorpCloudNetwork20200907PeeringStatePENDINGACCEPTANCE,
+				networkmodels.HashicorpCloudNetwork20200907PeeringStateACCEPTED,
+				networkmodels.HashicorpCloudNetwork20200907PeeringStateACTIVE,
+			}
+			var gets atomic.Int32
+			client := &clients.Client{
+				Config: clients.ClientConfig{ProjectID: testProjectID, PollInterval: time.Millisecond},
+				Network: &testNetworkClient{
+					getPeering: func(_ *network_service.GetPeeringParams) (*network_service.GetPeeringOK, error) {
+						peering := testPendingAwsPeering()
+						peering.State = sequence[min(int(gets.Add(1)), len(sequence))-1].Pointer()
+						return testPeeringOK(peering), nil
+					},
+				},
+			}
+
+			raw := map[string]interface{}{
+				"hvn_id":                "test-hvn",
+				"peering_id":            "test-peering",
+				"wait_for_active_state": true,
+			}
+			if tc.acceptableStates != nil {
+				raw["acceptable_states"] = tc.acceptableStates
+			}
+			d := schema.TestResourceDataRaw(t, dataSourceAwsNetworkPeering().Schema, raw)
+
+			diags := dataSourceAwsNetworkPeeringRead(context.Background(), d, client)
+			r.Empty(diags)
+			r.Equal(tc.expectedState, d.Get("state"))
+			r.Equal(tc.expectedGets, gets.Load())
+		})
+	}
+
+	t.Run("invalid state", func(t *testing.T) {
+		r := require.New(t)
+
+		diags := dataSourceAwsNetworkPeering().Validate(terraform.NewResourceConfigRaw(map[string]interface{}{
+			"hvn_id":                "test-hvn",
+			"peering_id":            "test-peering",
+			"wait_for_active_state": true,
+			"acceptable_states":     []interface{}{"ACCEPTED", "FAILED"},
+		}))
+		r.True(diags.HasError())
+		r.Contains(diags[0].Summary, "acceptable_states")
+	})
+}
+
+func Test_dataSourceAwsNetworkPeeringRead_lastRefreshed(t *testing.T) {
+	r := require.New(t)
+
+	client := &clients.Client{
+		Config: clients.ClientConfig{ProjectID: testProjectID},
+		Network: &testNetworkClient{
+			getPeering: func(_ *network_service.GetPeeringParams) (*network_service.GetPeeringOK, error) {
+				return testPeeringOK(testPendingAwsPeering()), nil
+			},
+		},
+	}
+
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	peeringLastRefreshedNow = func() time.Time { return now }
+	t.Cleanup(func() { peeringLastRefreshedNow = time.Now })
+
+	d := schema.TestResourceDataRaw(t, dataSourceAwsNetworkPeering().Schema, map[string]interface{}{
+		"hvn_id":                "test-hvn",
+		"peering_id":            "test-peering",
+		"wait_for_active_state": false,
+	})
+
+	// Every read updates last_refreshed, even if the peering hasn't changed.
+	r.Empty(dataSourceAwsNetworkPeeringRead(context.Background(), d, client))
+	r.Equal("2023-05-01T10:00:00Z", d.Get("last_refreshed"))
+
+	now = now.Add(time.Minute)
+	r.Empty(dataSourceAwsNetworkPeeringRead(context.Background(), d, client))
+	r.Equal("2023-05-01T10:01:00Z", d.Get("last_refreshed"))
+	r.Equal("PENDING_ACCEPTANCE", d.Get("state"))
+}
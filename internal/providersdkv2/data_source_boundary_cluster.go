@@ -34,7 +34,7 @@ func dataSourceBoundaryCluster() *schema.Resource {
 				Description: `
 The ID of the HCP project where the Boundary cluster is located. If not specified, the project configured in the HCP provider config block will be used.
 If not specified, the project specified in the HCP Provider config block will be used, if configured.
-If a project is not configured in the HCP Provider config block, the oldest project in the organization will be used.`,
+If a project is not configured in the HCP Provider config block, the organization's only project will be used.`,
 				Type:         schema.TypeString,
 				Computed:     true,
 				Optional:     true,
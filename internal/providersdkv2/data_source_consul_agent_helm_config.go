@@ -98,7 +98,7 @@ func dataSourceConsulAgentHelmConfig() *schema.Resource {
 				Description: `
 The ID of the HCP project where the HCP Consul cluster is located.
 If not specified, the project specified in the HCP Provider config block will be used, if configured.
-If a project is not configured in the HCP Provider config block, the oldest project in the organization will be used.`,
+If a project is not configured in the HCP Provider config block, the organization's only project will be used.`,
 				Type:         schema.TypeString,
 				Computed:     true,
 				Optional:     true,
@@ -6,7 +6,9 @@ package providersdkv2
 import (
 	"context"
 	"log"
+	"sort"
 
+	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
 	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -34,7 +36,7 @@ func dataSourceHvn() *schema.Resource {
 				Description: `
 The ID of the HCP project where the HVN is located.
 If not specified, the project specified in the HCP Provider config block will be used, if configured.
-If a project is not configured in the HCP Provider config block, the oldest project in the organization will be used.`,
+If a project is not configured in the HCP Provider config block, the organization's only project will be used.`,
 				Type:         schema.TypeString,
 				Computed:     true,
 				Optional:     true,
@@ -66,6 +68,8 @@ If a project is not configured in the HCP Provider config block, the oldest proj
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"aws_config":   hvnAwsConfigSchema(),
+			"azure_config": hvnAzureConfigSchema(),
 			"created_at": {
 				Description: "The time that the HVN was created.",
 				Type:        schema.TypeString,
@@ -81,6 +85,23 @@ If a project is not configured in the HCP Provider config block, the oldest proj
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"peering_count": {
+				Description: "The number of peering connections of the HVN, e.g. to alert before reaching the limit of peering connections per HVN.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"peered_account_ids": {
+				Description: "The IDs of the AWS accounts that the HVN is peered to, aggregated from its peering connections, e.g. to audit which external accounts can reach the HVN.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"peered_subscription_ids": {
+				Description: "The IDs of the Azure subscriptions that the HVN is peered to, aggregated from its peering connections, e.g. to audit which external subscriptions can reach the HVN.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
 		},
 	}
 }
@@ -120,5 +141,53 @@ func dataSourceHvnRead(ctx context.Context, d *schema.ResourceData, meta interfa
 		return diag.FromErr(err)
 	}
 
+	peerings, err := clients.ListPeerings(ctx, client, hvnID, loc)
+	if err != nil {
+		return apiErrorDiag(err, "unable to list the peering connections of HVN (%s)", hvnID)
+	}
+	if err := d.Set("peering_count", len(peerings)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	accountIDs, subscriptionIDs := peeredAccountIDs(peerings)
+	if err := d.Set("peered_account_ids", accountIDs); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("peered_subscription_ids", subscriptionIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
+
+// peeredAccountIDs returns the sorted, unique IDs of the AWS accounts and
+// Azure subscriptions that the peering connections are peered to.
+func peeredAccountIDs(peerings []*networkmodels.HashicorpCloudNetwork20200907Peering) (accountIDs, subscriptionIDs []string) {
+	accounts := make(map[string]struct{})
+	subscriptions := make(map[string]struct{})
+	for _, peering := range peerings {
+		if peering.Target == nil {
+			continue
+		}
+		if t := peering.Target.AwsTarget; t != nil && t.AccountID != "" {
+			accounts[t.AccountID] = struct{}{}
+		}
+		if t := peering.Target.AzureTarget; t != nil && t.SubscriptionID != "" {
+			subscriptions[t.SubscriptionID] = struct{}{}
+		}
+	}
+
+	accountIDs = make([]string, 0, len(accounts))
+	for id := range accounts {
+		accountIDs = append(accountIDs, id)
+	}
+	sort.Strings(accountIDs)
+
+	subscriptionIDs = make([]string, 0, len(subscriptions))
+	for id := range subscriptions {
+		subscriptionIDs = append(subscriptionIDs, id)
+	}
+	sort.Strings(subscriptionIDs)
+
+	return accountIDs, subscriptionIDs
+}
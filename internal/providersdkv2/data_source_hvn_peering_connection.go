@@ -69,6 +69,12 @@ Remove this attribute from the configuration for any affected resources.
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"updated_at": {
+				Description: "The time that the peering connection was last updated.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"raw_json": peeringRawJSONSchema("peering connection"),
 			"self_link": {
 				Description: "A unique URL identifying the peering connection",
 				Type:        schema.TypeString,
@@ -5,8 +5,12 @@ package providersdkv2
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"sort"
+	"strings"
 
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
@@ -14,7 +18,7 @@ import (
 
 func dataSourceHVNRoute() *schema.Resource {
 	return &schema.Resource{
-		Description: "The HVN route data source provides information about an existing HVN route.",
+		Description: "The HVN route data source provides information about an existing HVN route, looked up by its ID or by its destination CIDR.",
 		ReadContext: dataSourceHVNRouteRead,
 		Timeouts: &schema.ResourceTimeout{
 			Default: &hvnRouteDefaultTimeout,
@@ -26,10 +30,20 @@ func dataSourceHVNRoute() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 			},
+			// Exactly one of these is required
 			"hvn_route_id": {
-				Description: "The ID of the HVN route.",
-				Type:        schema.TypeString,
-				Required:    true,
+				Description:  "The ID of the HVN route. Exactly one of `hvn_route_id` or `destination_cidr` must be set.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"hvn_route_id", "destination_cidr"},
+			},
+			"destination_cidr": {
+				Description:  "The destination CIDR of the HVN route. When set, the HVN route with this destination CIDR is looked up, and it's an error if more than one HVN route of the HVN has it. Exactly one of `hvn_route_id` or `destination_cidr` must be set.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"hvn_route_id", "destination_cidr"},
 			},
 			// Computed outputs
 			"project_id": {
@@ -48,11 +62,6 @@ Remove this attribute from the configuration for any affected resources.
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
-			"destination_cidr": {
-				Description: "The destination CIDR of the HVN route.",
-				Type:        schema.TypeString,
-				Computed:    true,
-			},
 			"target_link": {
 				Description: "A unique URL identifying the target of the HVN route.",
 				Type:        schema.TypeString,
@@ -101,6 +110,16 @@ func dataSourceHVNRouteRead(ctx context.Context, d *schema.ResourceData, meta in
 	}
 
 	routeID := d.Get("hvn_route_id").(string)
+	if routeID == "" {
+		destination := d.Get("destination_cidr").(string)
+
+		log.Printf("[INFO] Looking up HVN route with destination CIDR (%s)", destination)
+		routeID, err = findHVNRouteIDByDestination(ctx, client, hvnLink, destination)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	routeLink := newLink(hvnLink.Location, HVNRouteResourceType, routeID)
 	routeURL, err := linkURL(routeLink)
 	if err != nil {
@@ -121,3 +140,29 @@ func dataSourceHVNRouteRead(ctx context.Context, d *schema.ResourceData, meta in
 
 	return nil
 }
+
+// findHVNRouteIDByDestination returns the ID of the only route of the HVN
+// whose destination CIDR is destination.
+func findHVNRouteIDByDestination(ctx context.Context, client *clients.Client, hvnLink *sharedmodels.HashicorpCloudLocationLink, destination string) (string, error) {
+	routes, err := clients.ListHVNRoutes(ctx, client, hvnLink.ID, destination, "", "", hvnLink.Location)
+	if err != nil {
+		return "", fmt.Errorf("unable to list HVN routes of HVN (%s): %v", hvnLink.ID, err)
+	}
+
+	var ids []string
+	for _, route := range routes {
+		if route.Destination == destination {
+			ids = append(ids, route.ID)
+		}
+	}
+	sort.Strings(ids)
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no HVN route with destination CIDR (%s) found in HVN (%s)", destination, hvnLink.ID)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("multiple HVN routes with destination CIDR (%s) found in HVN (%s): %s; set hvn_route_id to select one", destination, hvnLink.ID, strings.Join(ids, ", "))
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/client/network_service"
+	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_dataSourceHVNRouteRead_destinationCIDR(t *testing.T) {
+	newRoute := func(id, destination string) *networkmodels.HashicorpCloudNetwork20200907HVNRoute {
+		return &networkmodels.HashicorpCloudNetwork20200907HVNRoute{
+			ID:          id,
+			Destination: destination,
+			State:       networkmodels.HashicorpCloudNetwork20200907HVNRouteStateACTIVE.Pointer(),
+			CreatedAt:   strfmt.NewDateTime(),
+			Target: &networkmodels.HashicorpCloudNetwork20200907HVNRouteTarget{
+				HvnConnection: &sharedmodels.HashicorpCloudLocationLink{
+					ID:   "test-peering",
+					Type: PeeringResourceType,
+				},
+			},
+		}
+	}
+
+	tcs := map[string]struct {
+		routes        []*networkmodels.HashicorpCloudNetwork20200907HVNRoute
+		expectedID    string
+		expectedError string
+	}{
+		"single match": {
+			routes: []*networkmodels.HashicorpCloudNetwork20200907HVNRoute{
+				newRoute("route-a", "10.0.0.0/16"),
+				newRoute("route-b", "10.0.0.0/8"),
+			},
+			expectedID: "route-a",
+		},
+		"no match": {
+			routes: []*networkmodels.HashicorpCloudNetwork20200907HVNRoute{
+				newRoute("route-b", "10.0.0.0/8"),
+			},
+			expectedError: "no HVN route with destination CIDR (10.0.0.0/16) found in HVN (test-hvn)",
+		},
+		"multiple matches": {
+			routes: []*networkmodels.HashicorpCloudNetwork20200907HVNRoute{
+				newRoute("route-c", "10.0.0.0/16"),
+				newRoute("route-a", "10.0.0.0/16"),
+			},
+			expectedError: "multiple HVN routes with destination CIDR (10.0.0.0/16) found in HVN (test-hvn): route-a, route-c",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			client := &clients.Client{
+				Network: &testNetworkClient{
+					listHVNRoutes: func(params *network_service.ListHVNRoutesParams) (*network_service.ListHVNRoutesOK, error) {
+						r.Equal("test-hvn", params.HvnID)
+						r.Equal("10.0.0.0/16", *params.Destination)
+
+						return &network_service.ListHVNRoutesOK{
+							Payload: &networkmodels.HashicorpCloudNetwork20200907ListHVNRoutesResponse{Routes: tc.routes},
+						}, nil
+					},
+					getHVNRoute: func(params *network_service.GetHVNRouteParams) (*network_service.GetHVNRouteOK, error) {
+						for _, route := range tc.routes {
+							if route.ID == params.ID {
+								return &network_service.GetHVNRouteOK{
+									Payload: &networkmodels.HashicorpCloudNetwork20200907GetHVNRouteResponse{Route: route},
+								}, nil
+							}
+						}
+						return nil, fmt.Errorf("unexpected route %q", params.ID)
+					},
+				},
+			}
+
+			d := schema.TestResourceDataRaw(t, dataSourceHVNRoute().Schema, map[string]interface{}{
+				"hvn_link":         testHvnLink("test-hvn"),
+				"destination_cidr": "10.0.0.0/16",
+			})
+
+			diags := dataSourceHVNRouteRead(context.Background(), d, client)
+			if tc.expectedError != "" {
+				r.True(diags.HasError())
+				r.Contains(diags[0].Summary, tc.expectedError)
+				return
+			}
+
+			r.False(diags.HasError(), "unexpected diagnostics: %v", diags)
+			r.Equal(tc.expectedID, d.Get("hvn_route_id"))
+			r.Equal(fmt.Sprintf("/project/%s/%s/%s", testProjectID, HVNRouteResourceType, tc.expectedID), d.Id())
+			r.Equal(testPeeringLink("test-peering"), d.Get("target_link"))
+			r.Equal("ACTIVE", d.Get("state"))
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+// dataSourceLink is the data source that parses a self_link into its
+// components.
+func dataSourceLink() *schema.Resource {
+	return &schema.Resource{
+		Description: "The link data source parses the `self_link` of any HCP resource into its components, " +
+			"e.g. for modules that are passed links without knowing the type of the resources they refer to. " +
+			"It doesn't read the resource, so the resource doesn't have to exist.",
+		ReadContext: dataSourceLinkRead,
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"self_link": {
+				Description: "The `self_link` to parse, in the format `/project/{project_id}/{type}/{resource_id}`.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			// Computed outputs
+			"type": {
+				Description: "The type of the resource that the link refers to, e.g. `hashicorp.network.hvn` for an HVN or `hashicorp.network.peering` for a peering connection.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"resource_id": {
+				Description: "The ID of the resource that the link refers to, e.g. the `hvn_id` of an HVN.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"organization_id": {
+				Description: "The ID of the HCP organization where the resource is located. Links don't contain the organization, so it is the organization of the provider.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"project_id": {
+				Description: "The ID of the HCP project where the resource is located.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceLinkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client)
+
+	selfLink := d.Get("self_link").(string)
+	link, err := buildLinkFromURL(selfLink, "", client.Config.OrganizationID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !slices.Contains(linkResourceTypes, link.Type) {
+		return diag.Errorf("unable to parse self_link %q: unknown resource type %q, must be one of %s", selfLink, link.Type, strings.Join(linkResourceTypes, ", "))
+	}
+
+	d.SetId(selfLink)
+	if err := d.Set("type", link.Type); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("resource_id", link.ID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("organization_id", link.Location.OrganizationID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("project_id", link.Location.ProjectID); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
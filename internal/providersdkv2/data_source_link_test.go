@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_dataSourceLinkRead(t *testing.T) {
+	tcs := map[string]struct {
+		selfLink           string
+		expectedType       string
+		expectedResourceID string
+		expectedError      string
+	}{
+		"hvn": {
+			selfLink:           testHvnLink("test-hvn"),
+			expectedType:       HvnResourceType,
+			expectedResourceID: "test-hvn",
+		},
+		"peering": {
+			selfLink:           testPeeringLink("test-peering"),
+			expectedType:       PeeringResourceType,
+			expectedResourceID: "test-peering",
+		},
+		"unknown type": {
+			selfLink:      "/project/" + testProjectID + "/hashicorp.network.subnet/test-subnet",
+			expectedError: `unknown resource type "hashicorp.network.subnet", must be one of`,
+		},
+		"malformed": {
+			selfLink:      "/project/" + testProjectID + "/test-hvn",
+			expectedError: "is not in the correct format: /project/{project_id}/{resource_type}/{id}",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			client := &clients.Client{Config: clients.ClientConfig{OrganizationID: testOrganizationID}}
+			d := schema.TestResourceDataRaw(t, dataSourceLink().Schema, map[string]interface{}{
+				"self_link": tc.selfLink,
+			})
+
+			diags := dataSourceLinkRead(context.Background(), d, client)
+			if tc.expectedError != "" {
+				r.True(diags.HasError())
+				r.Contains(diags[0].Summary, tc.expectedError)
+				return
+			}
+			r.False(diags.HasError(), "unexpected diagnostics: %v", diags)
+
+			r.Equal(tc.selfLink, d.Id())
+			r.Equal(tc.expectedType, d.Get("type"))
+			r.Equal(tc.expectedResourceID, d.Get("resource_id"))
+			r.Equal(testOrganizationID, d.Get("organization_id"))
+			r.Equal(testProjectID, d.Get("project_id"))
+		})
+	}
+}
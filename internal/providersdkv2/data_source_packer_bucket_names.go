@@ -5,6 +5,7 @@ package providersdkv2
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"time"
 
@@ -33,6 +34,13 @@ func dataSourcePackerBucketNames() *schema.Resource {
 				Optional:     true,
 				ValidateFunc: validation.IsUUID,
 			},
+			"page_size": {
+				Description:  fmt.Sprintf("The number of buckets to request per page while listing them. Smaller pages use less memory, but need more requests. Defaults to `%d`, and must be at most `%d`.", clients.DefaultListPageSize, clients.MaxListPageSize),
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      clients.DefaultListPageSize,
+				ValidateFunc: validation.IntBetween(1, clients.MaxListPageSize),
+			},
 			// Computed outputs
 			"organization_id": {
 				Description: "The ID of the organization where the HCP Packer registry is located.",
@@ -58,9 +66,10 @@ func dataSourcePackerBucketsRead(ctx context.Context, d *schema.ResourceData, me
 		return diag.FromErr(err)
 	}
 
-	log.Printf("[INFO] Reading HCP Packer registry buckets [project_id=%s, organization_id=%s]", loc.ProjectID, loc.OrganizationID)
+	pageSize := d.Get("page_size").(int)
+	log.Printf("[INFO] Reading HCP Packer registry buckets [project_id=%s, organization_id=%s, page_size=%d]", loc.ProjectID, loc.OrganizationID, pageSize)
 
-	bucketData, err := packerv2.ListBuckets(ctx, client, loc)
+	bucketData, err := packerv2.ListBuckets(ctx, client, loc, int64(pageSize))
 	if err != nil {
 		return diag.FromErr(err)
 	}
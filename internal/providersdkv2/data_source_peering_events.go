@@ -0,0 +1,200 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+const (
+	// peeringEventCreated is the event of a peering connection being created.
+	peeringEventCreated = "created"
+
+	// peeringEventUpdated is the event of a peering connection last being
+	// updated, e.g. by changing state.
+	peeringEventUpdated = "updated"
+)
+
+func dataSourcePeeringEvents() *schema.Resource {
+	return &schema.Resource{
+		Description: "The peering events data source provides the recorded history of a peering connection of an HVN, e.g. to debug a peering connection that keeps changing state. " +
+			"HCP doesn't expose the full history of a peering connection's state changes, so the history is limited to when the peering connection was created and when it was last updated.",
+		ReadContext: dataSourcePeeringEventsRead,
+		Timeouts: &schema.ResourceTimeout{
+			Default: &peeringDefaultTimeout,
+		},
+		Schema: map[string]*schema.Schema{
+			// Required inputs
+			"hvn_id": {
+				Description:      "The ID of the HashiCorp Virtual Network (HVN).",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateSlugID,
+			},
+			"peering_id": {
+				Description:      "The ID of the peering connection.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateSlugID,
+			},
+			// Optional inputs
+			"project_id": {
+				Description: `
+The ID of the HCP project where the peering connection is located. Always matches the HVN's project.
+If not specified, the project specified in the HCP Provider config block will be used, if configured.
+If a project is not configured in the HCP Provider config block, the organization's only project will be used.`,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsUUID,
+				Computed:     true,
+			},
+			// Computed outputs
+			"state": {
+				Description: "The current state of the peering connection.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"created_at": {
+				Description: "The time that the peering connection was created.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"updated_at": {
+				Description: "The time that the peering connection was last updated.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"time_to_last_update": {
+				Description: "The time between `created_at` and `updated_at`, as a duration such as `1h30m0s`. A peering connection that keeps changing state is updated long after it was created.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"events": {
+				Description: "The events of the peering connection, oldest first.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"timestamp": {
+							Description: "The time of the event.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"event": {
+							Description: "The event, either `created` or `updated`.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"state": {
+							Description: "The state of the peering connection after the event, if known. It is only known for the latest event.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePeeringEventsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client)
+
+	projectID, err := GetProjectID(d.Get("project_id").(string), client.Config.ProjectID)
+	if err != nil {
+		return diag.Errorf("unable to retrieve project ID: %v", err)
+	}
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: client.Config.OrganizationID,
+		ProjectID:      projectID,
+	}
+	hvnID := d.Get("hvn_id").(string)
+	peeringID := d.Get("peering_id").(string)
+
+	log.Printf("[INFO] Reading peering connection (%s)", peeringID)
+	peering, err := clients.GetPeeringByID(ctx, client, peeringID, hvnID, loc)
+	if err != nil {
+		return apiErrorDiag(err, "unable to retrieve peering connection (%s)", peeringID)
+	}
+
+	link := newLink(peering.Hvn.Location, PeeringResourceType, peering.ID)
+	url, err := linkURL(link)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(url)
+
+	if err := setPeeringEventsResourceData(d, peering); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func setPeeringEventsResourceData(d *schema.ResourceData, peering *networkmodels.HashicorpCloudNetwork20200907Peering) error {
+	var state string
+	if peering.State != nil {
+		state = string(*peering.State)
+	}
+	createdAt := time.Time(peering.CreatedAt)
+	updatedAt := time.Time(peering.UpdatedAt)
+
+	if err := d.Set("project_id", peering.Hvn.Location.ProjectID); err != nil {
+		return err
+	}
+	if err := d.Set("state", state); err != nil {
+		return err
+	}
+	if err := d.Set("created_at", peering.CreatedAt.String()); err != nil {
+		return err
+	}
+	if err := d.Set("updated_at", peering.UpdatedAt.String()); err != nil {
+		return err
+	}
+
+	var timeToLastUpdate string
+	if !createdAt.IsZero() && !updatedAt.IsZero() {
+		timeToLastUpdate = updatedAt.Sub(createdAt).String()
+	}
+	if err := d.Set("time_to_last_update", timeToLastUpdate); err != nil {
+		return err
+	}
+
+	return d.Set("events", peeringEvents(peering.CreatedAt, peering.UpdatedAt, state))
+}
+
+// peeringEvents returns the events of a peering connection that HCP records:
+// its creation and its last update, after which it is in state. A peering
+// connection that was never updated is in state since it was created.
+func peeringEvents(createdAt, updatedAt strfmt.DateTime, state string) []map[string]interface{} {
+	var events []map[string]interface{}
+	if !time.Time(createdAt).IsZero() {
+		events = append(events, map[string]interface{}{
+			"timestamp": createdAt.String(),
+			"event":     peeringEventCreated,
+		})
+	}
+	if time.Time(updatedAt).After(time.Time(createdAt)) {
+		events = append(events, map[string]interface{}{
+			"timestamp": updatedAt.String(),
+			"event":     peeringEventUpdated,
+		})
+	}
+
+	if len(events) > 0 {
+		events[len(events)-1]["state"] = state
+	}
+	return events
+}
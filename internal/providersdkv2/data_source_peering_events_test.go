@@ -0,0 +1,78 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/client/network_service"
+	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_dataSourcePeeringEventsRead(t *testing.T) {
+	createdAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tcs := map[string]struct {
+		state                    networkmodels.HashicorpCloudNetwork20200907PeeringState
+		updatedAt                time.Time
+		expectedEvents           []interface{}
+		expectedTimeToLastUpdate string
+	}{
+		"active": {
+			state:     networkmodels.HashicorpCloudNetwork20200907PeeringStateACTIVE,
+			updatedAt: createdAt.Add(90 * time.Minute),
+			expectedEvents: []interface{}{
+				map[string]interface{}{"timestamp": "2024-05-01T10:00:00.000Z", "event": "created", "state": ""},
+				map[string]interface{}{"timestamp": "2024-05-01T11:30:00.000Z", "event": "updated", "state": "ACTIVE"},
+			},
+			expectedTimeToLastUpdate: "1h30m0s",
+		},
+		"never updated": {
+			state:     networkmodels.HashicorpCloudNetwork20200907PeeringStatePENDINGACCEPTANCE,
+			updatedAt: createdAt,
+			expectedEvents: []interface{}{
+				map[string]interface{}{"timestamp": "2024-05-01T10:00:00.000Z", "event": "created", "state": "PENDING_ACCEPTANCE"},
+			},
+			expectedTimeToLastUpdate: "0s",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			client := &clients.Client{
+				Config: clients.ClientConfig{OrganizationID: testOrganizationID, ProjectID: testProjectID},
+				Network: &testNetworkClient{
+					getPeering: func(params *network_service.GetPeeringParams) (*network_service.GetPeeringOK, error) {
+						r.Equal("test-peering", params.ID)
+						r.Equal("test-hvn", params.HvnID)
+
+						peering := testPeering(params.ID, tc.state, nil)
+						peering.CreatedAt = strfmt.DateTime(createdAt)
+						peering.UpdatedAt = strfmt.DateTime(tc.updatedAt)
+						return testPeeringOK(peering), nil
+					},
+				},
+			}
+
+			d := schema.TestResourceDataRaw(t, dataSourcePeeringEvents().Schema, map[string]interface{}{
+				"hvn_id":     "test-hvn",
+				"peering_id": "test-peering",
+			})
+			r.Empty(dataSourcePeeringEventsRead(context.Background(), d, client))
+
+			r.Equal(string(tc.state), d.Get("state"))
+			r.Equal(testProjectID, d.Get("project_id"))
+			r.Equal(tc.expectedEvents, d.Get("events"))
+			r.Equal(tc.expectedTimeToLastUpdate, d.Get("time_to_last_update"))
+		})
+	}
+}
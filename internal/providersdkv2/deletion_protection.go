@@ -0,0 +1,63 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// deletionProtectionSchema returns the schema of the deletion_protection
+// attribute for the given kind of resource (e.g. "HVN").
+func deletionProtectionSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Description: fmt.Sprintf("If `true`, Terraform will refuse to delete the %[1]s, including when it needs to be replaced. It must be set to `false` and applied before the %[1]s can be deleted. Defaults to `false`.", kind),
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	}
+}
+
+// checkDeletionProtection returns an error diagnostic if deletion protection
+// is enabled for the resource.
+func checkDeletionProtection(d *schema.ResourceData, kind, id string) diag.Diagnostics {
+	if !d.Get("deletion_protection").(bool) {
+		return nil
+	}
+
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("unable to delete %s (%s): deletion protection is enabled", kind, id),
+			Detail:   fmt.Sprintf("Set deletion_protection to false and apply the configuration before deleting the %s.", kind),
+		},
+	}
+}
+
+// resourceStateOnlyUpdate is the update function of resources whose only
+// updatable attributes, e.g. deletion_protection or allow_default_route, only
+// exist in the Terraform state, so there is nothing to update in HCP.
+func resourceStateOnlyUpdate(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+// setStateOnlyDefaults sets the attributes in defaults that are missing from
+// the Terraform state to their default values. The attributes only exist in
+// the state, so resources created by provider versions that didn't have them
+// would otherwise plan an update to set them after the provider is upgraded.
+func setStateOnlyDefaults(d *schema.ResourceData, defaults map[string]interface{}) error {
+	for key, value := range defaults {
+		if _, ok := d.GetOkExists(key); ok { //nolint:staticcheck // GetOk can't tell false apart from missing.
+			continue
+		}
+		if err := d.Set(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
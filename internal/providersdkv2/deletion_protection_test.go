@@ -0,0 +1,191 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+func Test_deletionProtection(t *testing.T) {
+	// None of the network API calls are stubbed, so any attempt to delete the
+	// resource panics.
+	client := &clients.Client{Network: &testNetworkClient{}}
+
+	tcs := map[string]struct {
+		resource *schema.Resource
+		raw      map[string]interface{}
+		id       string
+		expected string
+	}{
+		"hvn": {
+			resource: resourceHvn(),
+			raw: map[string]interface{}{
+				"hvn_id":              "test-hvn",
+				"deletion_protection": true,
+			},
+			id:       testHvnLink("test-hvn"),
+			expected: "unable to delete HVN (test-hvn): deletion protection is enabled",
+		},
+		"aws network peering": {
+			resource: resourceAwsNetworkPeering(),
+			raw: map[string]interface{}{
+				"hvn_id":              "test-hvn",
+				"peering_id":          "test-peering",
+				"deletion_protection": true,
+			},
+			id:       testPeeringLink("test-peering"),
+			expected: "unable to delete network peering (test-peering): deletion protection is enabled",
+		},
+		"azure peering connection": {
+			resource: resourceAzurePeeringConnection(),
+			raw: map[string]interface{}{
+				"hvn_link":            testHvnLink("test-hvn"),
+				"peering_id":          "test-peering",
+				"deletion_protection": true,
+			},
+			id:       testPeeringLink("test-peering"),
+			expected: "unable to delete peering connection (test-peering): deletion protection is enabled",
+		},
+		"hvn peering connection": {
+			resource: resourceHvnPeeringConnection(),
+			raw: map[string]interface{}{
+				"hvn_1":               testHvnLink("test-hvn"),
+				"deletion_protection": true,
+			},
+			id:       testPeeringLink("test-peering"),
+			expected: "unable to delete peering connection (test-peering): deletion protection is enabled",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			d := schema.TestResourceDataRaw(t, tc.resource.Schema, tc.raw)
+			d.SetId(tc.id)
+
+			diags := tc.resource.DeleteContext(context.Background(), d, client)
+			r.True(diags.HasError())
+			r.Equal(tc.expected, diags[0].Summary)
+		})
+	}
+}
+
+func Test_checkDeletionProtection(t *testing.T) {
+	r := require.New(t)
+
+	d := schema.TestResourceDataRaw(t, resourceHvn().Schema, map[string]interface{}{
+		"hvn_id": "test-hvn",
+	})
+	r.Nil(checkDeletionProtection(d, "HVN", "test-hvn"))
+
+	r.NoError(d.Set("deletion_protection", true))
+	r.Equal(diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "unable to delete HVN (test-hvn): deletion protection is enabled",
+			Detail:   "Set deletion_protection to false and apply the configuration before deleting the HVN.",
+		},
+	}, checkDeletionProtection(d, "HVN", "test-hvn"))
+}
+
+func Test_setStateOnlyDefaults(t *testing.T) {
+	tcs := map[string]struct {
+		resource *schema.Resource
+		defaults map[string]interface{}
+		config   map[string]string
+	}{
+		"hvn": {
+			resource: resourceHvn(),
+			defaults: hvnStateOnlyDefaults,
+			config: map[string]string{
+				"hvn_id":         "test-hvn",
+				"cloud_provider": "aws",
+				"region":         "us-west-2",
+			},
+		},
+		"aws network peering": {
+			resource: resourceAwsNetworkPeering(),
+			defaults: awsNetworkPeeringStateOnlyDefaults,
+			config: map[string]string{
+				"hvn_id":          "test-hvn",
+				"peering_id":      "test-peering",
+				"peer_account_id": "123456789012",
+				"peer_vpc_id":     "vpc-0123456789",
+				"peer_vpc_region": "us-west-2",
+			},
+		},
+		"azure peering connection": {
+			resource: resourceAzurePeeringConnection(),
+			defaults: azurePeeringConnectionStateOnlyDefaults,
+			config: map[string]string{
+				"hvn_link":                 testHvnLink("test-hvn"),
+				"peering_id":               "test-peering",
+				"peer_vnet_name":           "test-vnet",
+				"peer_vnet_region":         "westus",
+				"peer_tenant_id":           "b9ae18e6-ba32-4a52-9a3a-3bc4b9a7dfd0",
+				"peer_subscription_id":     "ba1d1d4c-3fd1-4ac1-9ab2-9d2bd0e7d8a8",
+				"peer_resource_group_name": "test-rg",
+			},
+		},
+		"hvn peering connection": {
+			resource: resourceHvnPeeringConnection(),
+			defaults: hvnPeeringConnectionStateOnlyDefaults,
+			config: map[string]string{
+				"hvn_1": testHvnLink("test-hvn"),
+				"hvn_2": testHvnLink("test-hvn-2"),
+			},
+		},
+		"hvn route": {
+			resource: resourceHvnRoute(),
+			defaults: hvnRouteStateOnlyDefaults,
+			config: map[string]string{
+				"hvn_link":         testHvnLink("test-hvn"),
+				"hvn_route_id":     "test-route",
+				"destination_cidr": "172.31.0.0/16",
+				"target_link":      testPeeringLink("test-peering"),
+			},
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+			ctx := context.Background()
+
+			raw := make(map[string]interface{}, len(tc.config))
+			for k, v := range tc.config {
+				raw[k] = v
+			}
+			config := terraform.NewResourceConfigRaw(raw)
+
+			// The state of a resource created by a provider version that
+			// didn't have the state-only attributes yet.
+			state := &terraform.InstanceState{ID: "test", Attributes: tc.config}
+
+			diff, err := tc.resource.SimpleDiff(ctx, state, config, &clients.Client{})
+			r.NoError(err)
+			for key := range tc.defaults {
+				r.Contains(diff.Attributes, key)
+			}
+
+			d := tc.resource.Data(state)
+			r.NoError(setStateOnlyDefaults(d, tc.defaults))
+
+			diff, err = tc.resource.SimpleDiff(ctx, d.State(), config, &clients.Client{})
+			r.NoError(err)
+			for key := range tc.defaults {
+				r.NotContains(diff.Attributes, key)
+			}
+		})
+	}
+}
@@ -55,6 +55,19 @@ const (
 	BoundaryClusterResourceType = "hashicorp.boundary.cluster"
 )
 
+// linkResourceTypes are the resource types of the links that the provider
+// produces, e.g. as the self_link of a resource.
+var linkResourceTypes = []string{
+	BoundaryClusterResourceType,
+	ConsulClusterResourceType,
+	ConsulSnapshotResourceType,
+	HvnResourceType,
+	HVNRouteResourceType,
+	PeeringResourceType,
+	TgwAttachmentResourceType,
+	VaultClusterResourceType,
+}
+
 // newLink constructs a new Link from the passed arguments. ID should be the
 // user specified resource ID.
 //
@@ -4,9 +4,22 @@
 package providersdkv2
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"sort"
 	"strings"
 	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-cty/cty"
+	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/hashicorp/terraform-provider-hcp/internal/input"
 )
 
 var peeringDefaultTimeout = time.Minute * 1
@@ -34,3 +47,505 @@ func parsePeeringResourceID(resourceID, clientProjectID string) (projectID, hvnI
 		return "", "", "", fmt.Errorf("unexpected format of ID (%q), expected {hvn_id}:{peering_id} or {project_id}:{hvn_id}:{peering_id}", resourceID)
 	}
 }
+
+// importPeering parses the import ID of a peering resource, and returns the
+// project, HVN and peering IDs it identifies once the peering connection
+// exists. Peering connections created in the HCP Portal may not be visible to
+// the API straight away, so the import waits for the peering connection,
+// rather than letting the refresh that follows it drop the resource.
+func importPeering(ctx context.Context, client *clients.Client, importID string) (projectID, hvnID, peeringID string, err error) {
+	projectID, hvnID, peeringID, err = parsePeeringResourceID(importID, client.Config.ProjectID)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if _, err := clients.GetPeeringAfterImport(ctx, client, peeringID, hvnID, &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: client.Config.OrganizationID,
+		ProjectID:      projectID,
+	}); err != nil {
+		return "", "", "", fmt.Errorf("unable to import peering connection (%s): %v", peeringID, err)
+	}
+
+	return projectID, hvnID, peeringID, nil
+}
+
+// peeringExpiredWarning returns a warning diagnostic if the peering connection
+// expired before being accepted.
+func peeringExpiredWarning(d *schema.ResourceData, kind, peeringID string) diag.Diagnostics {
+	if d.Get("state").(string) != clients.PeeringStateExpired {
+		return nil
+	}
+
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s (%s) has expired", kind, peeringID),
+			Detail:   fmt.Sprintf("The %s was not accepted before it expired at %s, and can no longer be accepted. It will be replaced on the next apply.", kind, d.Get("expires_at")),
+		},
+	}
+}
+
+// peeringStuckCreatingWarning returns a clients.PeeringStuckCreatingFunc that
+// adds a warning to diags if the peering connection of the given kind was
+// CREATING for longer than expected, so that it is reported alongside the
+// outcome of the wait.
+func peeringStuckCreatingWarning(diags *diag.Diagnostics, kind, peeringID, hvnID string) clients.PeeringStuckCreatingFunc {
+	return func(elapsed time.Duration) {
+		*diags = append(*diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s (%s) was CREATING for longer than expected", kind, peeringID),
+			Detail: fmt.Sprintf("The %s was still CREATING after %s, while they are usually created within minutes, so something may be wrong. "+
+				"Check its status on the Peering connections page of HVN (%s) in the HCP Portal, and contact HashiCorp support if it doesn't progress.",
+				kind, elapsed.Round(time.Second), hvnID),
+		})
+	}
+}
+
+// peeringReplaceIfExpired is a CustomizeDiffFunc that replaces peering
+// connections that expired before being accepted, since they can't be accepted
+// anymore.
+func peeringReplaceIfExpired(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || d.Get("state").(string) != clients.PeeringStateExpired {
+		return nil
+	}
+
+	if err := d.SetNewComputed("state"); err != nil {
+		return err
+	}
+
+	return d.ForceNew("state")
+}
+
+// peeringIDInUseDiag returns an error diagnostic if err, returned by a request
+// to create a peering connection of the given kind, is a conflict caused by
+// another peering connection of the HVN already using peeringID. It returns nil
+// otherwise, so that the caller can report err as is.
+func peeringIDInUseDiag(ctx context.Context, client *clients.Client, err error, kind, peeringID, hvnID string, loc *sharedmodels.HashicorpCloudLocationLocation) diag.Diagnostics {
+	if peeringID == "" || !clients.IsResponseCodeConflict(err) {
+		return nil
+	}
+
+	// Conflicts are also reported for other reasons, e.g. while the HVN is
+	// being updated, so only blame the peering ID if it's actually in use.
+	peerings, listErr := clients.ListPeerings(ctx, client, hvnID, loc)
+	if listErr != nil {
+		log.Printf("[WARN] Unable to list the peering connections of HVN (%s): %v", hvnID, listErr)
+		return nil
+	}
+
+	for _, peering := range peerings {
+		if peering.ID == peeringID {
+			return diag.Errorf("peering_id (%s) already in use: another peering connection of HVN (%s) in project (%s) has this ID. Choose a different peering_id, or import the existing %s into the state", peeringID, hvnID, loc.ProjectID, kind)
+		}
+	}
+
+	return nil
+}
+
+// peeringDescriptionSchema returns the schema of the description attribute of
+// a peering resource of the given kind. HCP doesn't store a description for
+// peering connections, so it only exists in the Terraform state.
+func peeringDescriptionSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Description: fmt.Sprintf("A human-readable description of the %[1]s. HCP doesn't store a description for peering connections, so it's only kept in the Terraform state: it can be changed without replacing the %[1]s, but isn't set on import.", kind),
+		Type:        schema.TypeString,
+		Optional:    true,
+	}
+}
+
+// peeringLocationSchema returns the schema of the location attribute of a
+// peering resource of the given kind.
+func peeringLocationSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Description: fmt.Sprintf("The location of the %s, decomposed from its `self_link`.", kind),
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"organization_id": {
+					Description: fmt.Sprintf("The ID of the HCP organization where the %s is located.", kind),
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"project_id": {
+					Description: fmt.Sprintf("The ID of the HCP project where the %s is located.", kind),
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"cloud_provider": {
+					Description: fmt.Sprintf("The provider where the HVN of the %s is located.", kind),
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"region": {
+					Description: fmt.Sprintf("The region where the HVN of the %s is located.", kind),
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+// peeringCloudProviderSchema returns the schema of the cloud_provider
+// attribute of a peering resource of the given kind.
+func peeringCloudProviderSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Description: fmt.Sprintf("The cloud provider of the HVN of the %s, e.g. `aws` or `azure`. Useful for modules that handle peering resources of several cloud providers.", kind),
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+}
+
+// setPeeringLocation sets the location and cloud_provider attributes of a
+// peering resource from its self_link. The self_link only identifies the
+// project, so the organization and region are those of the peering
+// connection's HVN.
+func setPeeringLocation(d *schema.ResourceData, hvnLocation *sharedmodels.HashicorpCloudLocationLocation) error {
+	link, err := buildLinkFromURL(d.Get("self_link").(string), PeeringResourceType, hvnLocation.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	location := map[string]interface{}{
+		"organization_id": link.Location.OrganizationID,
+		"project_id":      link.Location.ProjectID,
+		"cloud_provider":  "",
+		"region":          "",
+	}
+	if region := hvnLocation.Region; region != nil {
+		location["cloud_provider"] = region.Provider
+		location["region"] = region.Region
+	}
+
+	if err := d.Set("cloud_provider", location["cloud_provider"]); err != nil {
+		return err
+	}
+	return d.Set("location", []interface{}{location})
+}
+
+// dependentRouteIDsSchema returns the schema of the dependent_route_ids
+// attribute of a peering resource of the given kind.
+func dependentRouteIDsSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Description: fmt.Sprintf("The IDs of the HVN routes whose `target_link` is the %s, as of the last refresh. They should be deleted or retargeted before the %s is deleted.", kind, kind),
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+// setDependentRouteIDs sets dependent_route_ids to the IDs of the HVN routes
+// that target the peering connection.
+func setDependentRouteIDs(ctx context.Context, client *clients.Client, d *schema.ResourceData, hvnID, peeringID string, loc *sharedmodels.HashicorpCloudLocationLocation) error {
+	routeIDs, err := dependentRouteIDs(ctx, client, hvnID, peeringID, loc)
+	if err != nil {
+		return err
+	}
+
+	return d.Set("dependent_route_ids", routeIDs)
+}
+
+// dependentRouteIDs returns the sorted IDs of the HVN routes that target the
+// peering connection.
+func dependentRouteIDs(ctx context.Context, client *clients.Client, hvnID, peeringID string, loc *sharedmodels.HashicorpCloudLocationLocation) ([]string, error) {
+	routes, err := clients.ListHVNRoutes(ctx, client, hvnID, "", peeringID, PeeringResourceType, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	routeIDs := []string{}
+	for _, route := range routes {
+		if route.Target == nil || route.Target.HvnConnection == nil || route.Target.HvnConnection.ID != peeringID {
+			continue
+		}
+		if t := route.Target.HvnConnection.Type; t != "" && t != PeeringResourceType {
+			continue
+		}
+		routeIDs = append(routeIDs, route.ID)
+	}
+	sort.Strings(routeIDs)
+
+	return routeIDs, nil
+}
+
+// cascadeDeleteSchema returns the schema of the cascade_delete attribute of a
+// peering resource of the given kind.
+func cascadeDeleteSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Description: fmt.Sprintf("If `true`, the HVN routes that target the %[1]s are deleted before it. Otherwise, the %[1]s can't be deleted while HVN routes target it. Defaults to `false`.", kind),
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	}
+}
+
+// waitForDeletionSchema returns the schema of the wait_for_deletion attribute
+// of a peering resource of the given kind. It only exists in the Terraform
+// state.
+func waitForDeletionSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Description: fmt.Sprintf("If `true`, deleting the %[1]s waits until HCP no longer reports it, rather than only until the delete operation completes, so that a %[1]s with the same ID can be created straight away, e.g. when it is replaced. Defaults to `false`.", kind),
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	}
+}
+
+// waitForPeeringDeletion is called once a peering connection of the given kind
+// has been deleted. If wait_for_deletion is set, it waits for HCP to no longer
+// report the peering connection, within the delete timeout.
+func waitForPeeringDeletion(ctx context.Context, client *clients.Client, d *schema.ResourceData, kind, hvnID, peeringID string, loc *sharedmodels.HashicorpCloudLocationLocation) diag.Diagnostics {
+	if !d.Get("wait_for_deletion").(bool) {
+		return nil
+	}
+
+	log.Printf("[INFO] Waiting for %s (%s) to be deleted", kind, peeringID)
+	if err := clients.WaitForPeeringToBeDeleted(ctx, client, peeringID, hvnID, loc, d.Timeout(schema.TimeoutDelete)); err != nil {
+		return apiErrorDiag(err, "unable to delete %s (%s)", kind, peeringID)
+	}
+	return nil
+}
+
+// deleteDependentRoutes is called before deleting a peering connection of the
+// given kind. If HVN routes target the peering connection, they are deleted if
+// cascade_delete is set, and an error diagnostic listing them is returned
+// otherwise, since HCP won't delete a peering connection that routes depend on.
+func deleteDependentRoutes(ctx context.Context, client *clients.Client, d *schema.ResourceData, kind, hvnID, peeringID string, loc *sharedmodels.HashicorpCloudLocationLocation) diag.Diagnostics {
+	routeIDs, err := dependentRouteIDs(ctx, client, hvnID, peeringID, loc)
+	if err != nil {
+		// HCP still refuses the delete if routes depend on the peering
+		// connection, so there is no need to block it here.
+		log.Printf("[WARN] Unable to list the HVN routes that target %s (%s): %v", kind, peeringID, err)
+		return nil
+	}
+	if len(routeIDs) == 0 {
+		return nil
+	}
+
+	if !d.Get("cascade_delete").(bool) {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("unable to delete %s (%s): HVN routes target it", kind, peeringID),
+				Detail:   fmt.Sprintf("The HVN routes of HVN (%s) with the IDs %s target the %s. Delete or retarget them before deleting the %s, or set cascade_delete to true and apply the configuration to delete them along with it.", hvnID, strings.Join(routeIDs, ", "), kind, kind),
+			},
+		}
+	}
+
+	for _, routeID := range routeIDs {
+		if diags := deletePeeringRoute(ctx, client, kind, hvnID, routeID, peeringID, loc); diags != nil {
+			return diags
+		}
+	}
+
+	return nil
+}
+
+// peeringRawJSONSchema returns the schema of the raw_json attribute of a
+// peering resource or data source of the given kind.
+func peeringRawJSONSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Description: fmt.Sprintf("The %s as returned by the HCP API, encoded as JSON, e.g. to read fields with `jsondecode` that aren't exposed as attributes yet. Sensitive fields are redacted. Its format follows the HCP API, so it isn't covered by the provider's compatibility guarantees.", kind),
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+}
+
+// setPeeringRawJSON sets the raw_json attribute of a peering resource or data
+// source.
+func setPeeringRawJSON(d *schema.ResourceData, peering *networkmodels.HashicorpCloudNetwork20200907Peering) error {
+	rawJSON, err := clients.PeeringRawJSON(peering)
+	if err != nil {
+		return fmt.Errorf("unable to encode peering connection (%s): %w", peering.ID, err)
+	}
+	return d.Set("raw_json", rawJSON)
+}
+
+// peeringIDSuffixLength is the length of the unique suffix that
+// generatePeeringID appends to a name prefix.
+const peeringIDSuffixLength = 8
+
+// validatePeeringNamePrefix validates that the string value can prefix the
+// IDs generated by generatePeeringID, i.e. that the IDs are valid HCP slugs.
+func validatePeeringNamePrefix(v interface{}, path cty.Path) diag.Diagnostics {
+	prefix := v.(string)
+	if input.IsSlug(prefix + strings.Repeat("a", peeringIDSuffixLength)) {
+		return nil
+	}
+
+	msg := fmt.Sprintf("must be at most %d characters in length, begin with a letter or number, and contain only letters, numbers or hyphens", 36-peeringIDSuffixLength)
+	return diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       msg,
+		Detail:        msg,
+		AttributePath: path,
+	}}
+}
+
+// generatePeeringID returns a unique peering ID that starts with prefix.
+func generatePeeringID(prefix string) (string, error) {
+	id := prefix + strings.ReplaceAll(uuid.New().String(), "-", "")[:peeringIDSuffixLength]
+	if !input.IsSlug(id) {
+		return "", fmt.Errorf("generated peering ID (%s) isn't a valid HCP slug, check name_prefix (%s)", id, prefix)
+	}
+	return id, nil
+}
+
+// autoRouteIDSuffix is appended to the ID of a peering connection to derive
+// the ID of its auto route, so that the auto route doesn't collide with an HVN
+// route named after the peering connection, e.g. by an hcp_hvn_route resource.
+const autoRouteIDSuffix = "-auto"
+
+// autoRouteID returns the ID of the auto route of a peering connection. The
+// peering ID is truncated if necessary for the route ID to be a valid HCP
+// slug.
+func autoRouteID(peeringID string) string {
+	if maxLength := 36 - len(autoRouteIDSuffix); len(peeringID) > maxLength {
+		peeringID = strings.TrimRight(peeringID[:maxLength], "-")
+	}
+	return peeringID + autoRouteIDSuffix
+}
+
+// autoRouteDestinationCIDRSchema returns the schema of the
+// auto_route_destination_cidr attribute of a peering resource of the given
+// kind.
+func autoRouteDestinationCIDRSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Description: fmt.Sprintf("If set, an HVN route to this CIDR block that targets the %[1]s is managed along with it, as a convenience over a separate `hcp_hvn_route` resource. "+
+			"HCP only routes traffic to `ACTIVE` peering connections, so the route is created by the first apply after the %[1]s becomes `ACTIVE`, which is usually a later apply than the one creating it. "+
+			"The route's ID is the ID of the %[1]s followed by `-auto`, with the %[1]s's ID truncated if needed to keep it at most 36 characters long, and it is deleted before the %[1]s is. Changing it replaces the route, but not the %[1]s. "+
+			"Routes that need more configuration, e.g. an Azure next hop, or several routes to the same %[1]s, should use `hcp_hvn_route` instead.", kind),
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: validateCIDRBlockHVNRoute,
+	}
+}
+
+// autoRouteIDSchema returns the schema of the auto_route_id attribute of a
+// peering resource of the given kind.
+func autoRouteIDSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Description: fmt.Sprintf("The ID of the HVN route created for `auto_route_destination_cidr`. Empty until the %s is `ACTIVE` and the route has been created.", kind),
+		Type:        schema.TypeString,
+		Computed:    true,
+	}
+}
+
+// autoRouteCustomizeDiff is a CustomizeDiffFunc that plans an update of a
+// peering resource when its auto route must be created, replaced or deleted:
+// when auto_route_destination_cidr changes, or once the peering connection is
+// ACTIVE if the route hasn't been created yet.
+func autoRouteCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if d.HasChange("auto_route_destination_cidr") {
+		return d.SetNewComputed("auto_route_id")
+	}
+
+	if d.Get("auto_route_destination_cidr").(string) != "" && d.Get("auto_route_id").(string) == "" &&
+		d.Get("state").(string) == string(networkmodels.HashicorpCloudNetwork20200907PeeringStateACTIVE) {
+		return d.SetNewComputed("auto_route_id")
+	}
+
+	return nil
+}
+
+// syncAutoRoute creates, replaces or deletes the auto route of a peering
+// resource of the given kind so that it matches auto_route_destination_cidr,
+// and sets auto_route_id. The route is only created once the peering
+// connection is ACTIVE.
+func syncAutoRoute(ctx context.Context, client *clients.Client, d *schema.ResourceData, kind string, hvnLink *sharedmodels.HashicorpCloudLocationLink, peeringID string) diag.Diagnostics {
+	// auto_route_id is unknown while an update is planned, so use the ID of
+	// the route as of the last refresh.
+	oldRouteID, _ := d.GetChange("auto_route_id")
+	routeID := oldRouteID.(string)
+	destination := d.Get("auto_route_destination_cidr").(string)
+
+	if routeID != "" && (destination == "" || d.HasChange("auto_route_destination_cidr")) {
+		if diags := deletePeeringRoute(ctx, client, kind, hvnLink.ID, routeID, peeringID, hvnLink.Location); diags != nil {
+			return diags
+		}
+		routeID = ""
+	}
+
+	if routeID != "" || destination == "" || d.Get("state").(string) != string(networkmodels.HashicorpCloudNetwork20200907PeeringStateACTIVE) {
+		if err := d.Set("auto_route_id", routeID); err != nil {
+			return diag.FromErr(err)
+		}
+		return nil
+	}
+
+	hvn, err := clients.GetHvnByIDCached(ctx, client, hvnLink.Location, hvnLink.ID)
+	if err != nil {
+		return apiErrorDiag(err, "unable to retrieve HVN (%s) to route traffic to %s (%s)", hvnLink.ID, kind, peeringID)
+	}
+	if err := validateHvnRouteDestination(destination, hvn.CidrBlock); err != nil {
+		return diag.Errorf("invalid auto_route_destination_cidr: %v", err)
+	}
+
+	targetLink := newLink(&sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: hvnLink.Location.OrganizationID,
+		ProjectID:      hvnLink.Location.ProjectID,
+		Region:         hvn.Location.Region,
+	}, PeeringResourceType, peeringID)
+
+	routeID = autoRouteID(peeringID)
+	log.Printf("[INFO] Creating HVN route (%s) that targets %s (%s)", routeID, kind, peeringID)
+	resp, err := clients.CreateHVNRoute(ctx, client, routeID, hvnLink, destination, targetLink, hvnLink.Location, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := clients.WaitForOperation(ctx, client, "create HVN route", hvnLink.Location, resp.Operation.ID); err != nil {
+		return apiErrorDiag(err, "unable to create HVN route (%s) that targets %s (%s)", routeID, kind, peeringID)
+	}
+
+	if err := d.Set("auto_route_id", routeID); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// readAutoRoute refreshes auto_route_destination_cidr from the auto route of
+// a peering resource. If the route was deleted outside of Terraform,
+// auto_route_id is cleared so that the route is created again.
+func readAutoRoute(ctx context.Context, client *clients.Client, d *schema.ResourceData, hvnID string, loc *sharedmodels.HashicorpCloudLocationLocation) error {
+	routeID := d.Get("auto_route_id").(string)
+	if routeID == "" {
+		return nil
+	}
+
+	route, err := clients.GetHVNRoute(ctx, client, hvnID, routeID, loc)
+	if err != nil {
+		if !clients.IsResponseCodeNotFound(err) {
+			return err
+		}
+
+		log.Printf("[WARN] HVN route (%s) not found, removing it from auto_route_id", routeID)
+		return d.Set("auto_route_id", "")
+	}
+
+	return d.Set("auto_route_destination_cidr", route.Destination)
+}
+
+// deletePeeringRoute deletes an HVN route that targets a peering connection of
+// the given kind, ignoring routes that are already deleted.
+func deletePeeringRoute(ctx context.Context, client *clients.Client, kind, hvnID, routeID, peeringID string, loc *sharedmodels.HashicorpCloudLocationLocation) diag.Diagnostics {
+	log.Printf("[INFO] Deleting HVN route (%s) that targets %s (%s)", routeID, kind, peeringID)
+	resp, err := clients.DeleteHVNRouteByID(ctx, client, hvnID, routeID, loc)
+	if err != nil {
+		if clients.IsResponseCodeNotFound(err) {
+			return nil
+		}
+		return apiErrorDiag(err, "unable to delete HVN route (%s) that targets %s (%s)", routeID, kind, peeringID)
+	}
+
+	if err := clients.WaitForOperation(ctx, client, "delete HVN route", loc, resp.Operation.ID); err != nil {
+		return apiErrorDiag(err, "unable to delete HVN route (%s) that targets %s (%s)", routeID, kind, peeringID)
+	}
+
+	return nil
+}
@@ -4,13 +4,30 @@
 package providersdkv2
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/client/network_service"
+	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/stretchr/testify/require"
+
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/hashicorp/terraform-provider-hcp/internal/input"
 )
 
 func Test_parsePeeringResourceID(t *testing.T) {
-	defaultProjectID := "e20ad934-b88a-4897-a58e-d8318dd43cc3"
+	defaultProjectID := testProjectID
 	tests := map[string]struct {
 		input             string
 		expectedHvnID     string
@@ -66,3 +83,640 @@ func Test_parsePeeringResourceID(t *testing.T) {
 		})
 	}
 }
+
+func Test_setDependentRouteIDs(t *testing.T) {
+	r := require.New(t)
+
+	loc := testLocation()
+
+	route := func(id, targetID, targetType string) *networkmodels.HashicorpCloudNetwork20200907HVNRoute {
+		return &networkmodels.HashicorpCloudNetwork20200907HVNRoute{
+			ID: id,
+			Target: &networkmodels.HashicorpCloudNetwork20200907HVNRouteTarget{
+				HvnConnection: &sharedmodels.HashicorpCloudLocationLink{ID: targetID, Type: targetType, Location: loc},
+			},
+		}
+	}
+
+	client := &clients.Client{
+		Network: &testNetworkClient{
+			listHVNRoutes: func(params *network_service.ListHVNRoutesParams) (*network_service.ListHVNRoutesOK, error) {
+				r.Equal("test-hvn", params.HvnID)
+				r.Equal("test-peering", *params.TargetID)
+				r.Equal(PeeringResourceType, *params.TargetType)
+				r.Nil(params.Destination)
+
+				return &network_service.ListHVNRoutesOK{
+					Payload: &networkmodels.HashicorpCloudNetwork20200907ListHVNRoutesResponse{
+						Routes: []*networkmodels.HashicorpCloudNetwork20200907HVNRoute{
+							route("route-b", "test-peering", PeeringResourceType),
+							route("route-other-peering", "other-peering", PeeringResourceType),
+							route("route-tgw", "test-peering", TgwAttachmentResourceType),
+							route("route-a", "test-peering", PeeringResourceType),
+						},
+					},
+				}, nil
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceAzurePeeringConnection().Schema, map[string]interface{}{})
+	r.NoError(setDependentRouteIDs(context.Background(), client, d, "test-hvn", "test-peering", loc))
+	r.Equal([]interface{}{"route-a", "route-b"}, d.Get("dependent_route_ids"))
+}
+
+func Test_peeringIDInUseDiag(t *testing.T) {
+	loc := testLocation()
+
+	tcs := map[string]struct {
+		err           error
+		peerings      []string
+		expectedError string
+	}{
+		"peering ID in use": {
+			err:           network_service.NewCreatePeeringDefault(http.StatusConflict),
+			peerings:      []string{"other-peering", "test-peering"},
+			expectedError: "peering_id (test-peering) already in use",
+		},
+		"conflict for another reason": {
+			err:      network_service.NewCreatePeeringDefault(http.StatusConflict),
+			peerings: []string{"other-peering"},
+		},
+		"not a conflict": {
+			err:      network_service.NewCreatePeeringDefault(http.StatusBadRequest),
+			peerings: []string{"test-peering"},
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			client := &clients.Client{
+				Network: &testNetworkClient{
+					listPeerings: func(params *network_service.ListPeeringsParams) (*network_service.ListPeeringsOK, error) {
+						r.Equal("test-hvn", params.HvnID)
+
+						var peerings []*networkmodels.HashicorpCloudNetwork20200907Peering
+						for _, id := range tc.peerings {
+							peerings = append(peerings, &networkmodels.HashicorpCloudNetwork20200907Peering{ID: id})
+						}
+						return &network_service.ListPeeringsOK{
+							Payload: &networkmodels.HashicorpCloudNetwork20200907ListPeeringsResponse{Peerings: peerings},
+						}, nil
+					},
+				},
+			}
+
+			diags := peeringIDInUseDiag(context.Background(), client, tc.err, "peering connection", "test-peering", "test-hvn", loc)
+			if tc.expectedError == "" {
+				r.Nil(diags)
+				return
+			}
+
+			r.True(diags.HasError())
+			r.Contains(diags[0].Summary, tc.expectedError)
+		})
+	}
+}
+
+func Test_peeringStuckCreatingWarning(t *testing.T) {
+	r := require.New(t)
+
+	var diags diag.Diagnostics
+	warn := peeringStuckCreatingWarning(&diags, "peering connection", "test-peering", "test-hvn")
+	warn(17*time.Minute + 400*time.Millisecond)
+
+	r.Len(diags, 1)
+	r.False(diags.HasError())
+	r.Equal(diag.Warning, diags[0].Severity)
+	r.Equal("peering connection (test-peering) was CREATING for longer than expected", diags[0].Summary)
+	r.Contains(diags[0].Detail, "still CREATING after 17m0s")
+	r.Contains(diags[0].Detail, "HVN (test-hvn) in the HCP Portal")
+}
+
+func Test_setAwsPeeringResourceData_location(t *testing.T) {
+	r := require.New(t)
+
+	hvnLocation := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: testOrganizationID,
+		ProjectID:      testProjectID,
+		Region: &sharedmodels.HashicorpCloudLocationRegion{
+			Provider: "aws",
+			Region:   "us-west-2",
+		},
+	}
+	peering := &networkmodels.HashicorpCloudNetwork20200907Peering{
+		ID:  "test-peering",
+		Hvn: newLink(hvnLocation, HvnResourceType, "test-hvn"),
+		Target: &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{
+			AwsTarget: &networkmodels.HashicorpCloudNetwork20200907AWSPeeringTarget{
+				AccountID: "123456789012",
+				VpcID:     "vpc-0123456789",
+				Region:    "us-east-1",
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceAwsNetworkPeering().Schema, map[string]interface{}{})
+	r.NoError(setAwsPeeringResourceData(d, peering))
+
+	r.Equal(testPeeringLink("test-peering"), d.Get("self_link"))
+	r.Equal([]interface{}{map[string]interface{}{
+		"organization_id": hvnLocation.OrganizationID,
+		"project_id":      hvnLocation.ProjectID,
+		"cloud_provider":  hvnLocation.Region.Provider,
+		"region":          hvnLocation.Region.Region,
+	}}, d.Get("location"))
+	r.Equal("aws", d.Get("cloud_provider"))
+}
+
+func Test_peeringDescription(t *testing.T) {
+	tcs := map[string]struct {
+		resource *schema.Resource
+		config   map[string]interface{}
+	}{
+		"aws network peering": {
+			resource: resourceAwsNetworkPeering(),
+			config: map[string]interface{}{
+				"hvn_id":          "test-hvn",
+				"peering_id":      "test-peering",
+				"peer_account_id": "123456789012",
+				"peer_vpc_id":     "vpc-0123456789",
+				"peer_vpc_region": "us-east-1",
+			},
+		},
+		"azure peering connection": {
+			resource: resourceAzurePeeringConnection(),
+			config: map[string]interface{}{
+				"hvn_link":                 testHvnLink("test-hvn"),
+				"peering_id":               "test-peering",
+				"peer_subscription_id":     "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b",
+				"peer_tenant_id":           "0d7b3c1e-8a4f-4e6d-b2c9-5f1a3e7d9c2b",
+				"peer_resource_group_name": "test-rg",
+				"peer_vnet_name":           "test-vnet",
+				"peer_vnet_region":         "eastus",
+			},
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			// None of the network API calls are stubbed, so any attempt to
+			// update the peering in HCP panics.
+			client := &clients.Client{Network: &testNetworkClient{}}
+
+			tc.config["description"] = "Shared services"
+			d := schema.TestResourceDataRaw(t, tc.resource.Schema, tc.config)
+			d.SetId(testPeeringLink("test-peering"))
+			r.Equal("Shared services", d.Get("description"))
+
+			tc.config["description"] = "Shared services, owned by the platform team"
+			diff, err := tc.resource.Diff(context.Background(), d.State(), sdkterraform.NewResourceConfigRaw(tc.config), client)
+			r.NoError(err)
+			r.False(diff.RequiresNew())
+			r.Equal("Shared services, owned by the platform team", diff.Attributes["description"].New)
+
+			state, diags := tc.resource.Apply(context.Background(), d.State(), diff, client)
+			r.False(diags.HasError(), "unexpected diagnostics: %v", diags)
+			r.Equal("Shared services, owned by the platform team", state.Attributes["description"])
+		})
+	}
+}
+
+func Test_peeringValidate_allErrors(t *testing.T) {
+	tcs := map[string]struct {
+		resource *schema.Resource
+		config   map[string]interface{}
+		valid    []string
+	}{
+		"aws network peering": {
+			resource: resourceAwsNetworkPeering(),
+			config: map[string]interface{}{
+				"hvn_id":          "Test_HVN",
+				"peering_id":      "-test-peering",
+				"project_id":      "not-a-project",
+				"peer_account_id": "1234",
+				"peer_vpc_id":     "vpc_123",
+				"peer_vpc_region": "westus2",
+			},
+			valid: []string{"peer_account_id", "peer_vpc_id", "peer_vpc_region"},
+		},
+		"azure peering connection": {
+			resource: resourceAzurePeeringConnection(),
+			config: map[string]interface{}{
+				"hvn_link":               testHvnLink("test-hvn"),
+				"peering_id":             "-test-peering",
+				"peer_tenant_id":         "not-a-tenant",
+				"peer_resource_group_id": "test-rg",
+				"peer_vnet_name":         "test-vnet",
+				"peer_vnet_region":       "us-west-2",
+			},
+			// Unknown regions are only warned about.
+			valid: []string{"hvn_link", "peer_tenant_id", "peer_vnet_name", "peer_vnet_region"},
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			// Each invalid attribute is reported rather than only the first,
+			// so that they can all be fixed at once.
+			diags := tc.resource.Validate(sdkterraform.NewResourceConfigRaw(tc.config))
+			r.True(diags.HasError())
+
+			invalid := make(map[string]bool)
+			for _, d := range diags {
+				if d.Severity != diag.Error {
+					continue
+				}
+				if len(d.AttributePath) > 0 {
+					invalid[d.AttributePath[0].(cty.GetAttrStep).Name] = true
+				}
+			}
+			for attr := range tc.config {
+				r.Equal(!slices.Contains(tc.valid, attr), invalid[attr], "unexpected diagnostics for %s: %v", attr, diags)
+			}
+		})
+	}
+}
+
+func Test_peeringUpdatedAt(t *testing.T) {
+	hvnLocation := testLocation()
+
+	tcs := map[string]struct {
+		resource *schema.Resource
+		set      func(d *schema.ResourceData, peering *networkmodels.HashicorpCloudNetwork20200907Peering) error
+		target   *networkmodels.HashicorpCloudNetwork20200907PeeringTarget
+	}{
+		"aws network peering": {
+			resource: resourceAwsNetworkPeering(),
+			set:      setAwsPeeringResourceData,
+			target: &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{
+				AwsTarget: &networkmodels.HashicorpCloudNetwork20200907AWSPeeringTarget{AccountID: "123456789012"},
+			},
+		},
+		"azure peering connection": {
+			resource: resourceAzurePeeringConnection(),
+			set:      setAzurePeeringResourceData,
+			target: &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{
+				AzureTarget: &networkmodels.HashicorpCloudNetwork20200907AzurePeeringTarget{VnetName: "test-vnet"},
+			},
+		},
+		"hvn peering connection": {
+			resource: resourceHvnPeeringConnection(),
+			set:      setHvnPeeringResourceData,
+			target: &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{
+				HvnTarget: &networkmodels.HashicorpCloudNetwork20200907NetworkTarget{Hvn: newLink(hvnLocation, HvnResourceType, "other-hvn")},
+			},
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			createdAt := strfmt.DateTime(time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC))
+			peering := &networkmodels.HashicorpCloudNetwork20200907Peering{
+				ID:        "test-peering",
+				Hvn:       newLink(hvnLocation, HvnResourceType, "test-hvn"),
+				Target:    tc.target,
+				CreatedAt: createdAt,
+				UpdatedAt: createdAt,
+				State:     networkmodels.HashicorpCloudNetwork20200907PeeringStatePENDINGACCEPTANCE.Pointer(),
+			}
+
+			d := schema.TestResourceDataRaw(t, tc.resource.Schema, map[string]interface{}{})
+			r.NoError(tc.set(d, peering))
+			r.Equal("2024-03-01T12:00:00.000Z", d.Get("updated_at"))
+			r.Contains(d.Get("raw_json"), `"id":"test-peering"`)
+
+			// Accepting the peering connection updates it in place.
+			peering.State = networkmodels.HashicorpCloudNetwork20200907PeeringStateACTIVE.Pointer()
+			peering.UpdatedAt = strfmt.DateTime(time.Date(2024, time.March, 1, 12, 30, 0, 0, time.UTC))
+
+			r.NoError(tc.set(d, peering))
+			r.Equal("2024-03-01T12:30:00.000Z", d.Get("updated_at"))
+			r.Equal(createdAt.String(), d.Get("created_at"))
+		})
+	}
+}
+
+func Test_peeringImport_delayedVisibility(t *testing.T) {
+	importID := testProjectID + ":test-hvn:test-peering"
+
+	tcs := map[string]struct {
+		importer      schema.StateContextFunc
+		schema        map[string]*schema.Schema
+		notFound      int
+		err           error
+		expectedCalls int
+		expectedError string
+	}{
+		"aws network peering visible after a delay": {
+			importer:      resourceAwsNetworkPeeringImport,
+			schema:        resourceAwsNetworkPeering().Schema,
+			notFound:      2,
+			expectedCalls: 3,
+		},
+		"azure peering connection visible after a delay": {
+			importer:      resourceAzurePeeringConnectionImport,
+			schema:        resourceAzurePeeringConnection().Schema,
+			notFound:      2,
+			expectedCalls: 3,
+		},
+		"hvn peering connection visible after a delay": {
+			importer:      resourceHvnPeeringConnectionImport,
+			schema:        resourceHvnPeeringConnection().Schema,
+			notFound:      2,
+			expectedCalls: 3,
+		},
+		"other errors are not retried": {
+			importer:      resourceAwsNetworkPeeringImport,
+			schema:        resourceAwsNetworkPeering().Schema,
+			err:           network_service.NewGetPeeringDefault(http.StatusForbidden),
+			expectedCalls: 1,
+			expectedError: "unable to import peering connection (test-peering)",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			var calls int
+			client := &clients.Client{
+				Config: clients.ClientConfig{OrganizationID: testOrganizationID, ProjectID: testProjectID, PollInterval: time.Millisecond},
+				Network: &testNetworkClient{
+					getPeering: func(params *network_service.GetPeeringParams) (*network_service.GetPeeringOK, error) {
+						calls++
+						r.Equal(testOrganizationID, params.LocationOrganizationID)
+						r.Equal(testProjectID, params.LocationProjectID)
+						r.Equal("test-hvn", params.HvnID)
+
+						if tc.err != nil {
+							return nil, tc.err
+						}
+						if calls <= tc.notFound {
+							return nil, network_service.NewGetPeeringDefault(http.StatusNotFound)
+						}
+						return testPeeringOK(&networkmodels.HashicorpCloudNetwork20200907Peering{ID: params.ID}), nil
+					},
+				},
+			}
+
+			d := schema.TestResourceDataRaw(t, tc.schema, map[string]interface{}{})
+			d.SetId(importID)
+			_, err := tc.importer(context.Background(), d, client)
+			r.Equal(tc.expectedCalls, calls)
+			if tc.expectedError != "" {
+				r.ErrorContains(err, tc.expectedError)
+				return
+			}
+
+			r.NoError(err)
+			r.Equal(testPeeringLink("test-peering"), d.Id())
+		})
+	}
+}
+
+func Test_peeringDelete_dependentRoutes(t *testing.T) {
+	loc := &sharedmodels.HashicorpCloudLocationLocation{OrganizationID: testOrganizationID, ProjectID: testProjectID}
+
+	resources := map[string]struct {
+		resource *schema.Resource
+		config   map[string]interface{}
+		kind     string
+	}{
+		"aws network peering": {
+			resource: resourceAwsNetworkPeering(),
+			config: map[string]interface{}{
+				"hvn_id":     "test-hvn",
+				"peering_id": "test-peering",
+			},
+			kind: "network peering",
+		},
+		"azure peering connection": {
+			resource: resourceAzurePeeringConnection(),
+			config: map[string]interface{}{
+				"hvn_link":   testHvnLink("test-hvn"),
+				"peering_id": "test-peering",
+			},
+			kind: "peering connection",
+		},
+	}
+
+	for n, res := range resources {
+		for _, cascade := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s cascade_delete=%t", n, cascade), func(t *testing.T) {
+				r := require.New(t)
+
+				routes := map[string]bool{"route-a": true, "route-b": true}
+				var peeringDeleted bool
+				client := &clients.Client{
+					Config:    clients.ClientConfig{OrganizationID: testOrganizationID},
+					Operation: &testOperationClient{},
+					Network: &testNetworkClient{
+						listHVNRoutes: func(params *network_service.ListHVNRoutesParams) (*network_service.ListHVNRoutesOK, error) {
+							r.Equal("test-hvn", params.HvnID)
+
+							var list []*networkmodels.HashicorpCloudNetwork20200907HVNRoute
+							for id := range routes {
+								list = append(list, &networkmodels.HashicorpCloudNetwork20200907HVNRoute{
+									ID: id,
+									Target: &networkmodels.HashicorpCloudNetwork20200907HVNRouteTarget{
+										HvnConnection: &sharedmodels.HashicorpCloudLocationLink{ID: "test-peering", Type: PeeringResourceType, Location: loc},
+									},
+								})
+							}
+							return &network_service.ListHVNRoutesOK{
+								Payload: &networkmodels.HashicorpCloudNetwork20200907ListHVNRoutesResponse{Routes: list},
+							}, nil
+						},
+						deleteHVNRoute: func(params *network_service.DeleteHVNRouteParams) (*network_service.DeleteHVNRouteOK, error) {
+							r.Equal("test-hvn", params.HvnID)
+							r.True(routes[params.ID])
+							delete(routes, params.ID)
+
+							return &network_service.DeleteHVNRouteOK{
+								Payload: &networkmodels.HashicorpCloudNetwork20200907DeleteHVNRouteResponse{
+									Operation: &sharedmodels.HashicorpCloudOperationOperation{ID: "delete-" + params.ID},
+								},
+							}, nil
+						},
+						deletePeering: func(params *network_service.DeletePeeringParams) (*network_service.DeletePeeringOK, error) {
+							r.Empty(routes, "the peering connection was deleted before its dependent routes")
+							peeringDeleted = true
+
+							return &network_service.DeletePeeringOK{
+								Payload: &networkmodels.HashicorpCloudNetwork20200907DeletePeeringResponse{
+									Operation: &sharedmodels.HashicorpCloudOperationOperation{ID: "delete-peering"},
+								},
+							}, nil
+						},
+					},
+				}
+
+				config := map[string]interface{}{"cascade_delete": cascade}
+				for k, v := range res.config {
+					config[k] = v
+				}
+				d := schema.TestResourceDataRaw(t, res.resource.Schema, config)
+				d.SetId(testPeeringLink("test-peering"))
+				r.NoError(d.Set("state", clients.PeeringStateActive))
+
+				diags := res.resource.DeleteContext(context.Background(), d, client)
+				if !cascade {
+					r.True(diags.HasError())
+					r.Equal(fmt.Sprintf("unable to delete %s (test-peering): HVN routes target it", res.kind), diags[0].Summary)
+					r.Contains(diags[0].Detail, "with the IDs route-a, route-b target the "+res.kind)
+					r.Contains(diags[0].Detail, "set cascade_delete to true")
+					r.Len(routes, 2)
+					r.False(peeringDeleted)
+					return
+				}
+
+				r.False(diags.HasError(), "unexpected diagnostics: %v", diags)
+				r.Empty(routes)
+				r.True(peeringDeleted)
+			})
+		}
+	}
+}
+
+func Test_generatePeeringID(t *testing.T) {
+	tcs := map[string]string{
+		"prefix":                "team-a-",
+		"prefix without hyphen": "teama",
+		"single character":      "t",
+		"longest prefix":        strings.Repeat("a", 28),
+		"longest hyphen-ended":  strings.Repeat("a", 27) + "-",
+		"uppercase and numbers": "Team1-",
+	}
+
+	for n, prefix := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			r.Empty(validatePeeringNamePrefix(prefix, nil))
+
+			id, err := generatePeeringID(prefix)
+			r.NoError(err)
+			r.True(strings.HasPrefix(id, prefix), "%q should start with %q", id, prefix)
+			r.Len(id, len(prefix)+peeringIDSuffixLength)
+			r.True(input.IsSlug(id), "%q should be a valid HCP slug", id)
+
+			other, err := generatePeeringID(prefix)
+			r.NoError(err)
+			r.NotEqual(id, other)
+		})
+	}
+}
+
+func Test_validatePeeringNamePrefix(t *testing.T) {
+	for _, prefix := range []string{
+		strings.Repeat("a", 29),
+		"-team",
+		"team_a-",
+		"team a",
+	} {
+		t.Run(prefix, func(t *testing.T) {
+			diags := validatePeeringNamePrefix(prefix, cty.GetAttrPath("name_prefix"))
+			require.True(t, diags.HasError())
+			require.Equal(t, "must be at most 28 characters in length, begin with a letter or number, and contain only letters, numbers or hyphens", diags[0].Summary)
+		})
+	}
+}
+
+func Test_peeringRead_deleting(t *testing.T) {
+	hvnLocation := testLocation()
+	hvnURL, err := linkURL(newLink(hvnLocation, HvnResourceType, "test-hvn"))
+	require.NoError(t, err)
+
+	tcs := map[string]struct {
+		resource *schema.Resource
+		raw      map[string]interface{}
+		target   *networkmodels.HashicorpCloudNetwork20200907PeeringTarget
+	}{
+		"aws network peering": {
+			resource: resourceAwsNetworkPeering(),
+			raw:      map[string]interface{}{"hvn_id": "test-hvn"},
+			target: &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{
+				AwsTarget: &networkmodels.HashicorpCloudNetwork20200907AWSPeeringTarget{AccountID: "123456789012"},
+			},
+		},
+		"azure peering connection": {
+			resource: resourceAzurePeeringConnection(),
+			raw:      map[string]interface{}{"hvn_link": hvnURL},
+			target: &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{
+				AzureTarget: &networkmodels.HashicorpCloudNetwork20200907AzurePeeringTarget{VnetName: "test-vnet"},
+			},
+		},
+		"hvn peering connection": {
+			resource: resourceHvnPeeringConnection(),
+			raw:      map[string]interface{}{"hvn_1": hvnURL},
+			target: &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{
+				HvnTarget: &networkmodels.HashicorpCloudNetwork20200907NetworkTarget{Hvn: newLink(hvnLocation, HvnResourceType, "other-hvn")},
+			},
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			// The peering connection is being deleted on the first read, and
+			// is gone on the second one.
+			var reads int
+			client := &clients.Client{
+				Config: clients.ClientConfig{OrganizationID: hvnLocation.OrganizationID, ProjectID: hvnLocation.ProjectID},
+				Network: &testNetworkClient{
+					getPeering: func(params *network_service.GetPeeringParams) (*network_service.GetPeeringOK, error) {
+						reads++
+						r.Equal("test-peering", params.ID)
+						r.Equal("test-hvn", params.HvnID)
+						if reads > 1 {
+							return nil, network_service.NewGetPeeringDefault(http.StatusNotFound)
+						}
+
+						return testPeeringOK(testPeering("test-peering", networkmodels.HashicorpCloudNetwork20200907PeeringStateDELETING, tc.target)), nil
+					},
+				},
+			}
+
+			id, err := linkURL(newLink(hvnLocation, PeeringResourceType, "test-peering"))
+			r.NoError(err)
+			d := schema.TestResourceDataRaw(t, tc.resource.Schema, tc.raw)
+			d.SetId(id)
+
+			// A peering connection that is being deleted still exists.
+			diags := tc.resource.ReadContext(context.Background(), d, client)
+			r.False(diags.HasError(), "unexpected diagnostics: %v", diags)
+			r.Equal(id, d.Id())
+			r.Equal("DELETING", d.Get("state"))
+
+			// Only once it isn't found is it removed from the state.
+			diags = tc.resource.ReadContext(context.Background(), d, client)
+			r.False(diags.HasError(), "unexpected diagnostics: %v", diags)
+			r.Empty(d.Id())
+			r.Equal(2, reads)
+		})
+	}
+}
+
+func Test_autoRouteID(t *testing.T) {
+	r := require.New(t)
+
+	r.Equal("test-peering-auto", autoRouteID("test-peering"))
+
+	// Long peering IDs are truncated for the route ID to be a valid slug.
+	id := autoRouteID("peering-0123456789abcdef0123456789ab")
+	r.Equal("peering-0123456789abcdef0123456-auto", id)
+	r.True(input.IsSlug(id))
+
+	// The truncated peering ID doesn't end with a hyphen.
+	r.Equal("peering-0123456789abcdef012345-auto", autoRouteID("peering-0123456789abcdef012345-xyz"))
+}
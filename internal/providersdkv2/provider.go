@@ -5,6 +5,7 @@ package providersdkv2
 
 import (
 	"context"
+	"log"
 	"os"
 	"time"
 
@@ -26,7 +27,7 @@ func New() func() *schema.Provider {
 			DataSourcesMap: map[string]*schema.Resource{
 				"hcp_aws_network_peering":            dataSourceAwsNetworkPeering(),
 				"hcp_aws_transit_gateway_attachment": dataSourceAwsTransitGatewayAttachment(),
-				"hcp_azure_peering_connection":       dataSourceAzurePeeringConnection(),
+				"hcp_available_cidr":                 dataSourceAvailableCIDR(),
 				"hcp_boundary_cluster":               dataSourceBoundaryCluster(),
 				"hcp_consul_agent_helm_config":       dataSourceConsulAgentHelmConfig(),
 				"hcp_consul_agent_kubernetes_secret": dataSourceConsulAgentKubernetesSecret(),
@@ -35,8 +36,10 @@ func New() func() *schema.Provider {
 				"hcp_hvn":                            dataSourceHvn(),
 				"hcp_hvn_peering_connection":         dataSourceHvnPeeringConnection(),
 				"hcp_hvn_route":                      dataSourceHVNRoute(),
+				"hcp_link":                           dataSourceLink(),
 				"hcp_packer_bucket_names":            dataSourcePackerBucketNames(),
 				"hcp_packer_run_task":                dataSourcePackerRunTask(),
+				"hcp_peering_events":                 dataSourcePeeringEvents(),
 				"hcp_vault_cluster":                  dataSourceVaultCluster(),
 				"hcp_vault_plugin":                   dataSourceVaultPlugin(),
 			},
@@ -73,7 +76,19 @@ func New() func() *schema.Provider {
 					Type:         schema.TypeString,
 					Optional:     true,
 					ValidateFunc: validation.IsUUID,
-					Description:  "The default project in which resources should be created.",
+					Description:  "The default project in which resources should be created. If unset, the organization's only project is used, so it must be set when the organization has more than one project.",
+				},
+				"client_certificate_file": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					RequiredWith: []string{"client_key_file"},
+					Description:  "The path to a PEM encoded client certificate that the provider presents on its requests to the HCP API and auth endpoint, e.g. to a proxy or gateway that requires mutual TLS. Requires `client_key_file`.",
+				},
+				"client_key_file": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					RequiredWith: []string{"client_certificate_file"},
+					Description:  "The path to the PEM encoded private key of `client_certificate_file`. Requires `client_certificate_file`.",
 				},
 				"credential_file": {
 					Type:     schema.TypeString,
@@ -83,6 +98,65 @@ func New() func() *schema.Provider {
 						"Using a credential file allows you to authenticate the provider as a service principal via client " +
 						"credentials or dynamically based on Workload Identity Federation.",
 				},
+				"credential_source": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringInSlice(clients.CredentialSources, false),
+					Description:  "Selects the credentials that the provider authenticates with, rather than using the first ones found. One of `client_credentials` (`client_id` and `client_secret`, or the HCP_CLIENT_ID and HCP_CLIENT_SECRET environment variables), `token` (an access token set by the HCP_ACCESS_TOKEN environment variable), `file` (`credential_file`, or the HCP_CRED_FILE environment variable) or `workload_identity`. It is an error if the selected credentials aren't set.",
+				},
+				"fail_on_unknown_states": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "If true, waiting for a peering connection to reach a state fails when HCP reports a state that the provider doesn't recognize, e.g. one introduced after this version of the provider was released. By default, such states are treated as transient: a warning is logged and the provider keeps waiting. Defaults to `false`.",
+				},
+				"max_retries": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "The maximum number of times a read from HCP is retried when it is throttled or fails with a transient server error. Writes, such as creates, are only retried when throttled, and at most once, so that they aren't applied twice. Defaults to `3`.",
+				},
+				"request_timeout": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					ValidateDiagFunc: validateDuration,
+					Description:      "The maximum duration of a single request to HCP, as a duration string such as `\"45s\"` or `\"2m\"`. By default, requests are only bounded by the timeouts of the resources.",
+				},
+				"requests_per_second": {
+					Type:         schema.TypeFloat,
+					Optional:     true,
+					ValidateFunc: validation.FloatAtLeast(0),
+					Description:  "The maximum number of requests per second that the provider makes to HCP. Useful for large configurations that would otherwise be throttled. Defaults to `0`, which means requests are not rate limited.",
+				},
+				"max_idle_conns": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "The maximum number of idle connections to HCP that the provider keeps open for reuse. Increasing it can reduce latency for large applies with a high `-parallelism`. Defaults to `100`.",
+				},
+				"max_idle_conns_per_host": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "The maximum number of idle connections per host that the provider keeps open for reuse. Nearly all requests go to the same HCP API host, so it should be at least Terraform's `-parallelism`. Defaults to `20`.",
+				},
+				"extra_headers": {
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Sensitive:   true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Additional HTTP headers to set on every request to HCP, e.g. headers required by an egress proxy. The values are treated as sensitive and are never logged.",
+				},
+				"skip_waits": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "If true, the provider doesn't wait for operations to complete or for resources to reach a state, e.g. for a cluster to be running or a peering connection to be active, and returns their current state instead. Subsequent resources may then fail, because the resources they depend on aren't ready, and failed operations aren't reported. Defaults to `false`.",
+				},
+				"user_agent_suffix": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringMatch(clients.UserAgentSuffixRegexp, "must be a product token, such as \"my-tool/1.2.3\""),
+					Description:  "A product token, such as `my-tool/1.2.3`, appended to the `User-Agent` header of every request to HCP, after the provider's own, e.g. to attribute the requests to a tool that embeds the provider.",
+				},
 				"workload_identity": {
 					Type:     schema.TypeList,
 					Optional: true,
@@ -134,6 +208,41 @@ func configure(p *schema.Provider) func(context.Context, *schema.ResourceData) (
 			CredentialFile: d.Get("credential_file").(string),
 			ProjectID:      d.Get("project_id").(string),
 			SourceChannel:  p.UserAgent("terraform-provider-hcp", version.ProviderVersion),
+			MaxRetries:     clients.DefaultMaxRetries,
+			RequestTimeout: clients.DefaultRequestTimeout,
+
+			MaxIdleConns:        clients.DefaultMaxIdleConns,
+			MaxIdleConnsPerHost: clients.DefaultMaxIdleConnsPerHost,
+		}
+
+		if v, ok := d.GetOk("max_idle_conns"); ok {
+			clientConfig.MaxIdleConns = v.(int)
+		}
+		if v, ok := d.GetOk("max_idle_conns_per_host"); ok {
+			clientConfig.MaxIdleConnsPerHost = v.(int)
+		}
+
+		// GetOk can't distinguish an explicit 0 from an unset value, so use the
+		// raw config to only apply the defaults when max_retries isn't set.
+		if v := d.GetRawConfig().GetAttr("max_retries"); !v.IsNull() {
+			clientConfig.MaxRetries = d.Get("max_retries").(int)
+		}
+		if v, ok := d.GetOk("request_timeout"); ok {
+			// The value has already been validated by the schema.
+			clientConfig.RequestTimeout, _ = time.ParseDuration(v.(string))
+		}
+		clientConfig.CredentialSource = d.Get("credential_source").(string)
+		clientConfig.RequestsPerSecond = d.Get("requests_per_second").(float64)
+		clientConfig.UserAgentSuffix = d.Get("user_agent_suffix").(string)
+		clientConfig.SkipWaits = d.Get("skip_waits").(bool)
+		clientConfig.FailOnUnknownStates = d.Get("fail_on_unknown_states").(bool)
+		clientConfig.ClientCertificateFile = d.Get("client_certificate_file").(string)
+		clientConfig.ClientKeyFile = d.Get("client_key_file").(string)
+		if v, ok := d.GetOk("extra_headers"); ok {
+			clientConfig.ExtraHeaders = make(map[string]string)
+			for name, value := range v.(map[string]interface{}) {
+				clientConfig.ExtraHeaders[name] = value.(string)
+			}
 		}
 
 		// Read the workload_identity configuration
@@ -171,10 +280,9 @@ func configure(p *schema.Provider) func(context.Context, *schema.ResourceData) (
 
 		} else {
 			// For the initial release of the HCP TFP, since only one project was allowed per organization at the time,
-			// the provider handled used the single organization's single project by default, instead of requiring the
-			// user to set it. Once multiple projects are available, this helper issues a warning: when multiple projects exist within the org,
-			// a project ID should be set on the provider or on each resource. Otherwise, the oldest project will be used by default.
-			// This helper will eventually be deprecated after a migration period.
+			// the provider used the single organization's single project by default, instead of requiring the
+			// user to set it. This is still the case when the organization has a single project, but when it has
+			// several, a project ID must be set on the provider rather than one of them being picked.
 			project, projDiags := getProjectFromCredentials(ctx, client)
 			if projDiags != nil {
 				if !projDiags.HasError() {
@@ -188,6 +296,7 @@ func configure(p *schema.Provider) func(context.Context, *schema.ResourceData) (
 
 			client.Config.OrganizationID = project.Parent.ID
 			client.Config.ProjectID = project.ID
+			log.Printf("[INFO] No project configured, using the only project (%s) of organization (%s)", project.ID, project.Parent.ID)
 		}
 
 		return client, diags
@@ -261,28 +370,23 @@ func getProjectFromCredentials(ctx context.Context, client *clients.Client) (pro
 		diags = append(diags, diag.Errorf("unable to fetch project id: %v", err)...)
 		return nil, diags
 	}
-	if len(listProjResp.Payload.Projects) > 1 {
+	projects := listProjResp.Payload.Projects
+	if len(projects) == 0 {
 		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Warning,
+			Severity: diag.Error,
+			Summary:  "The configured credentials do not have access to any project.",
+			Detail:   "Please assign at least one project to the configured credentials to use this provider.",
+		})
+		return nil, diags
+	}
+	if len(projects) > 1 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
 			Summary:  "There is more than one project associated with the organization of the configured credentials.",
-			Detail:   `The oldest project has been selected as the default. To configure which project is used as default, set a project in the HCP provider config block. Resources may also be configured with different projects.`,
+			Detail:   clients.MultipleProjectsDetail(projects),
 		})
-		return GetOldestProject(listProjResp.Payload.Projects), diags
+		return nil, diags
 	}
-	project = listProjResp.Payload.Projects[0]
+	project = projects[0]
 	return project, diags
 }
-
-// GetOldestProject retrieves the oldest project from a list based on its created_at time.
-func GetOldestProject(projects []*models.HashicorpCloudResourcemanagerProject) (oldestProj *models.HashicorpCloudResourcemanagerProject) {
-	oldestTime := time.Now()
-
-	for _, proj := range projects {
-		projTime := time.Time(proj.CreatedAt)
-		if projTime.Before(oldestTime) {
-			oldestProj = proj
-			oldestTime = projTime
-		}
-	}
-	return oldestProj
-}
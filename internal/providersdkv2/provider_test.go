@@ -9,9 +9,14 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/go-openapi/strfmt"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/client/organization_service"
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/client/project_service"
+	rmmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/models"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
@@ -23,6 +28,7 @@ import (
 	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
 	"github.com/hashicorp/terraform-provider-hcp/internal/provider"
 	"github.com/hashicorp/terraform-provider-hcp/version"
+	"github.com/stretchr/testify/require"
 )
 
 // testAccProvider is the "main" provider instance
@@ -228,3 +234,98 @@ func Test_readWorkloadIdentity(t *testing.T) {
 		})
 	}
 }
+
+// Test_getProjectFromCredentials covers the discovery of the organization and
+// project to use when no project is configured, from the organizations that
+// the credentials have access to.
+func Test_getProjectFromCredentials(t *testing.T) {
+	orgID := "f709ec73-55d4-46d8-897d-816ebba28778"
+	newProject := func(id string, createdAt time.Time) *rmmodels.HashicorpCloudResourcemanagerProject {
+		return &rmmodels.HashicorpCloudResourcemanagerProject{
+			ID:        id,
+			Name:      id + "-name",
+			CreatedAt: strfmt.DateTime(createdAt),
+			Parent: &rmmodels.HashicorpCloudResourcemanagerResourceID{
+				ID:   orgID,
+				Type: rmmodels.HashicorpCloudResourcemanagerResourceIDResourceTypeORGANIZATION.Pointer(),
+			},
+		}
+	}
+	now := time.Now()
+
+	tcs := map[string]struct {
+		orgs            []*rmmodels.HashicorpCloudResourcemanagerOrganization
+		projects        []*rmmodels.HashicorpCloudResourcemanagerProject
+		expectedProject string
+		expectedError   string
+		expectedDetail  string
+	}{
+		"single project": {
+			orgs:            []*rmmodels.HashicorpCloudResourcemanagerOrganization{{ID: orgID}},
+			projects:        []*rmmodels.HashicorpCloudResourcemanagerProject{newProject("project-a", now)},
+			expectedProject: "project-a",
+		},
+		"multiple projects": {
+			orgs: []*rmmodels.HashicorpCloudResourcemanagerOrganization{{ID: orgID}},
+			projects: []*rmmodels.HashicorpCloudResourcemanagerProject{
+				newProject("project-new", now.Add(-time.Hour)),
+				newProject("project-old", now.Add(-2*time.Hour)),
+			},
+			expectedError:  "There is more than one project associated with the organization of the configured credentials.",
+			expectedDetail: "The organization has the projects: project-new (project-new-name), project-old (project-old-name).",
+		},
+		"no project": {
+			orgs:          []*rmmodels.HashicorpCloudResourcemanagerOrganization{{ID: orgID}},
+			expectedError: "The configured credentials do not have access to any project.",
+		},
+		"no organization": {
+			expectedError: "The configured credentials do not have access to any organization.",
+		},
+		"multiple organizations": {
+			orgs: []*rmmodels.HashicorpCloudResourcemanagerOrganization{
+				{ID: orgID},
+				{ID: "0b5ba2b8-4d5a-4a4e-9d2e-3c1f7a8b9c0d"},
+			},
+			expectedError: "There is more than one organization associated with the configured credentials.",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			client := &clients.Client{
+				Organization: &testOrganizationClient{
+					list: func(_ *organization_service.OrganizationServiceListParams) (*organization_service.OrganizationServiceListOK, error) {
+						return &organization_service.OrganizationServiceListOK{
+							Payload: &rmmodels.HashicorpCloudResourcemanagerOrganizationListResponse{Organizations: tc.orgs},
+						}, nil
+					},
+				},
+				Project: &testProjectClient{
+					list: func(params *project_service.ProjectServiceListParams) (*project_service.ProjectServiceListOK, error) {
+						r.Equal(orgID, *params.ScopeID)
+						r.Equal("ORGANIZATION", *params.ScopeType)
+
+						return &project_service.ProjectServiceListOK{
+							Payload: &rmmodels.HashicorpCloudResourcemanagerProjectListResponse{Projects: tc.projects},
+						}, nil
+					},
+				},
+			}
+
+			project, diags := getProjectFromCredentials(context.Background(), client)
+			if tc.expectedError != "" {
+				r.True(diags.HasError())
+				r.Equal(tc.expectedError, diags[0].Summary)
+				r.Contains(diags[0].Detail, tc.expectedDetail)
+				r.Nil(project)
+				return
+			}
+
+			r.Empty(diags)
+			r.Equal(tc.expectedProject, project.ID)
+			r.Equal(orgID, project.Parent.ID)
+		})
+	}
+}
@@ -5,8 +5,10 @@ package providersdkv2
 
 import (
 	"context"
+	"errors"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/client/network_service"
 	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
@@ -17,13 +19,23 @@ import (
 	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
 )
 
+// awsNetworkPeeringStateOnlyDefaults are the default values of the network peering's attributes that only
+// exist in the Terraform state.
+var awsNetworkPeeringStateOnlyDefaults = map[string]interface{}{
+	"deletion_protection": false,
+	"cascade_delete":      false,
+	"wait_for_deletion":   false,
+}
+
 func resourceAwsNetworkPeering() *schema.Resource {
 	return &schema.Resource{
 		Description: "The AWS network peering resource allows you to manage a network peering between an HVN and a peer AWS VPC.",
 
 		CreateContext: resourceAwsNetworkPeeringCreate,
 		ReadContext:   resourceAwsNetworkPeeringRead,
+		UpdateContext: resourceStateOnlyUpdate,
 		DeleteContext: resourceAwsNetworkPeeringDelete,
+		CustomizeDiff: peeringReplaceIfExpired,
 		Timeouts: &schema.ResourceTimeout{
 			Default: &peeringDefaultTimeout,
 			Create:  &peeringCreateTimeout,
@@ -36,7 +48,7 @@ func resourceAwsNetworkPeering() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			// Required inputs
 			"hvn_id": {
-				Description:      "The ID of the HashiCorp Virtual Network (HVN).",
+				Description:      "The ID of the HashiCorp Virtual Network (HVN). Peering connections can't be moved to another HVN, so changing it replaces the peering connection.",
 				Type:             schema.TypeString,
 				Required:         true,
 				ForceNew:         true,
@@ -75,13 +87,20 @@ func resourceAwsNetworkPeering() *schema.Resource {
 				Description: `
 The ID of the HCP project where the network peering is located. Always matches the HVN's project.
 If not specified, the project specified in the HCP Provider config block will be used, if configured.
-If a project is not configured in the HCP Provider config block, the oldest project in the organization will be used.`,
+If a project is not configured in the HCP Provider config block, the organization's only project will be used.`,
 				Type:         schema.TypeString,
 				Optional:     true,
 				ForceNew:     true,
 				ValidateFunc: validation.IsUUID,
 				Computed:     true,
 			},
+			"description":         peeringDescriptionSchema("network peering"),
+			"cascade_delete":      cascadeDeleteSchema("network peering"),
+			"deletion_protection": deletionProtectionSchema("network peering"),
+			"wait_for_deletion":   waitForDeletionSchema("network peering"),
+			"dependent_route_ids": dependentRouteIDsSchema("network peering"),
+			"location":            peeringLocationSchema("network peering"),
+			"cloud_provider":      peeringCloudProviderSchema("network peering"),
 			// Computed outputs
 			"organization_id": {
 				Description: "The ID of the HCP organization where the network peering is located. Always matches the HVN's organization.",
@@ -103,6 +122,17 @@ If a project is not configured in the HCP Provider config block, the oldest proj
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"updated_at": {
+				Description: "The time that the peering connection was last updated.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"raw_json": peeringRawJSONSchema("network peering"),
+			"seconds_to_expiry": {
+				Description: "The number of seconds left to accept the network peering before it expires, as of the last refresh. `0` if the network peering isn't in a `PENDING_ACCEPTANCE` state.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
 			"self_link": {
 				Description: "A unique URL identifying the network peering.",
 				Type:        schema.TypeString,
@@ -144,7 +174,7 @@ func resourceAwsNetworkPeeringCreate(ctx context.Context, d *schema.ResourceData
 	}
 
 	// Check for an existing HVN
-	_, err = clients.GetHvnByID(ctx, client, loc, hvnID)
+	hvn, err := clients.GetHvnByIDCached(ctx, client, loc, hvnID)
 	if err != nil {
 		if clients.IsResponseCodeNotFound(err) {
 			return diag.Errorf("unable to find the HVN (%s) for the network peering", hvnID)
@@ -152,6 +182,10 @@ func resourceAwsNetworkPeeringCreate(ctx context.Context, d *schema.ResourceData
 
 		return diag.Errorf("unable to check for presence of an existing HVN (%s): %v", hvnID, err)
 	}
+
+	if err := clients.CheckHvnNotFailed(hvn); err != nil {
+		return diag.FromErr(err)
+	}
 	log.Printf("[INFO] HVN (%s) found, proceeding with network peering create", hvnID)
 
 	// Check if peering already exists
@@ -192,7 +226,11 @@ func resourceAwsNetworkPeeringCreate(ctx context.Context, d *schema.ResourceData
 	log.Printf("[INFO] Creating network peering between HVN (%s) and peer (%s)", hvnID, peerVpcID)
 	peeringResponse, err := client.Network.CreatePeering(peerNetworkParams, nil)
 	if err != nil {
-		return diag.Errorf("unable to create network peering between HVN (%s) and peer (%s): %v", hvnID, peerVpcID, err)
+		if diags := peeringIDInUseDiag(ctx, client, err, "network peering", peeringID, hvnID, loc); diags != nil {
+			return diags
+		}
+
+		return apiErrorDiag(err, "unable to create network peering between HVN (%s) and peer (%s)", hvnID, peerVpcID)
 	}
 
 	peering := peeringResponse.Payload.Peering
@@ -208,23 +246,29 @@ func resourceAwsNetworkPeeringCreate(ctx context.Context, d *schema.ResourceData
 
 	// Wait for network peering to be created
 	if err := clients.WaitForOperation(ctx, client, "create network peering", loc, peeringResponse.Payload.Operation.ID); err != nil {
-		return diag.Errorf("unable to create network peering (%s) between HVN (%s) and peer (%s): %v", peering.ID, peering.Hvn.ID, peering.Target.AwsTarget.VpcID, err)
+		return apiErrorDiag(err, "unable to create network peering (%s) between HVN (%s) and peer (%s)", peering.ID, peering.Hvn.ID, peering.Target.AwsTarget.VpcID)
 	}
 
 	log.Printf("[INFO] Created network peering (%s) between HVN (%s) and peer (%s)", peering.ID, peering.Hvn.ID, peering.Target.AwsTarget.VpcID)
 
-	peering, err = clients.WaitForPeeringToBePendingAcceptance(ctx, client, peering.ID, hvnID, loc, d.Timeout(schema.TimeoutCreate))
+	if _, err := clients.GetPeeringAfterCreate(ctx, client, peering.ID, hvnID, loc); err != nil {
+		return apiErrorDiag(err, "unable to retrieve network peering (%s)", peering.ID)
+	}
+
+	var diags diag.Diagnostics
+	peering, err = clients.WaitForPeeringToBePendingAcceptance(ctx, client, peering.ID, hvnID, loc, d.Timeout(schema.TimeoutCreate),
+		peeringStuckCreatingWarning(&diags, "network peering", peering.ID, hvnID))
 	if err != nil {
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
 
 	log.Printf("[INFO] Network peering (%s) is now in PENDING_ACCEPTANCE state", peering.ID)
 
 	if err := setAwsPeeringResourceData(d, peering); err != nil {
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
 
-	return nil
+	return diags
 }
 
 func resourceAwsNetworkPeeringRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -248,7 +292,11 @@ func resourceAwsNetworkPeeringRead(ctx context.Context, d *schema.ResourceData,
 			return nil
 		}
 
-		return diag.Errorf("unable to retrieve network peering (%s): %v", peeringID, err)
+		return apiErrorDiag(err, "unable to retrieve network peering (%s)", peeringID)
+	}
+
+	if err := setStateOnlyDefaults(d, awsNetworkPeeringStateOnlyDefaults); err != nil {
+		return diag.FromErr(err)
 	}
 
 	// Network peering found, update resource data
@@ -256,7 +304,14 @@ func resourceAwsNetworkPeeringRead(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	return nil
+	// The network peering itself was read, so failing to list the routes that
+	// target it only leaves dependent_route_ids stale.
+	var diags diag.Diagnostics
+	if err := setDependentRouteIDs(ctx, client, d, hvnID, peeringID, loc); err != nil {
+		diags = partialReadWarning(err, "unable to list the HVN routes targeting network peering (%s)", peeringID)
+	}
+
+	return append(diags, peeringExpiredWarning(d, "network peering", peeringID)...)
 }
 
 func resourceAwsNetworkPeeringDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -271,6 +326,32 @@ func resourceAwsNetworkPeeringDelete(ctx context.Context, d *schema.ResourceData
 	loc := link.Location
 	hvnID := d.Get("hvn_id").(string)
 
+	if diags := checkDeletionProtection(d, "network peering", peeringID); diags != nil {
+		return diags
+	}
+
+	if diags := deleteDependentRoutes(ctx, client, d, "network peering", hvnID, peeringID, loc); diags != nil {
+		return diags
+	}
+
+	if d.Get("state").(string) == clients.PeeringStatePendingAcceptance {
+		log.Printf("[INFO] Network peering (%s) is pending acceptance, canceling it", peeringID)
+		err := clients.CancelPeering(ctx, client, peeringID, hvnID, loc)
+		if err == nil {
+			if diags := waitForPeeringDeletion(ctx, client, d, "network peering", hvnID, peeringID, loc); diags != nil {
+				return diags
+			}
+			log.Printf("[INFO] Network peering (%s) canceled, removing from state", peeringID)
+			d.SetId("")
+			return nil
+		}
+		if !errors.Is(err, clients.ErrPeeringNotPending) {
+			return diag.Errorf("unable to cancel network peering (%s): %v", peeringID, err)
+		}
+
+		log.Printf("[INFO] Network peering (%s) has been accepted, deleting it", peeringID)
+	}
+
 	deletePeeringParams := network_service.NewDeletePeeringParams()
 	deletePeeringParams.Context = ctx
 	deletePeeringParams.ID = peeringID
@@ -282,10 +363,11 @@ func resourceAwsNetworkPeeringDelete(ctx context.Context, d *schema.ResourceData
 	if err != nil {
 		if clients.IsResponseCodeNotFound(err) {
 			log.Printf("[WARN] Network peering (%s) not found, so no action was taken", peeringID)
+			d.SetId("")
 			return nil
 		}
 
-		return diag.Errorf("unable to delete network peering (%s): %v", peeringID, err)
+		return apiErrorDiag(err, "unable to delete network peering (%s)", peeringID)
 	}
 
 	// Wait for peering to be deleted
@@ -297,7 +379,11 @@ func resourceAwsNetworkPeeringDelete(ctx context.Context, d *schema.ResourceData
 		if strings.Contains(err.Error(), "execution already started") {
 			return nil
 		}
-		return diag.Errorf("unable to delete network peering (%s): %v", peeringID, err)
+		return apiErrorDiag(err, "unable to delete network peering (%s)", peeringID)
+	}
+
+	if diags := waitForPeeringDeletion(ctx, client, d, "network peering", hvnID, peeringID, loc); diags != nil {
+		return diags
 	}
 
 	log.Printf("[INFO] Network peering (%s) deleted, removing from state", peeringID)
@@ -309,6 +395,9 @@ func setAwsPeeringResourceData(d *schema.ResourceData, peering *networkmodels.Ha
 	if err := d.Set("peering_id", peering.ID); err != nil {
 		return err
 	}
+	if err := d.Set("hvn_id", peering.Hvn.ID); err != nil {
+		return err
+	}
 	if err := d.Set("peer_account_id", peering.Target.AwsTarget.AccountID); err != nil {
 		return err
 	}
@@ -333,6 +422,15 @@ func setAwsPeeringResourceData(d *schema.ResourceData, peering *networkmodels.Ha
 	if err := d.Set("expires_at", peering.ExpiresAt.String()); err != nil {
 		return err
 	}
+	if err := d.Set("updated_at", peering.UpdatedAt.String()); err != nil {
+		return err
+	}
+	if err := setPeeringRawJSON(d, peering); err != nil {
+		return err
+	}
+	if err := d.Set("seconds_to_expiry", clients.PeeringSecondsToExpiry(peering, time.Now())); err != nil {
+		return err
+	}
 	if err := d.Set("state", peering.State); err != nil {
 		return err
 	}
@@ -345,6 +443,9 @@ func setAwsPeeringResourceData(d *schema.ResourceData, peering *networkmodels.Ha
 	if err := d.Set("self_link", selfLink); err != nil {
 		return err
 	}
+	if err := setPeeringLocation(d, peering.Hvn.Location); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -359,7 +460,7 @@ func resourceAwsNetworkPeeringImport(ctx context.Context, d *schema.ResourceData
 	//   terraform import hcp_aws_network_peering.test {hvn_id}:{peering_id}
 
 	client := meta.(*clients.Client)
-	projectID, hvnID, peeringID, err := parsePeeringResourceID(d.Id(), client.Config.ProjectID)
+	projectID, hvnID, peeringID, err := importPeering(ctx, client, d.Id())
 	if err != nil {
 		return nil, err
 	}
@@ -379,5 +480,17 @@ func resourceAwsNetworkPeeringImport(ctx context.Context, d *schema.ResourceData
 		return nil, err
 	}
 
+	if err := d.Set("deletion_protection", false); err != nil {
+		return nil, err
+	}
+
+	if err := d.Set("cascade_delete", false); err != nil {
+		return nil, err
+	}
+
+	if err := d.Set("wait_for_deletion", false); err != nil {
+		return nil, err
+	}
+
 	return []*schema.ResourceData{d}, nil
 }
@@ -6,11 +6,15 @@ package providersdkv2
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/client/network_service"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -107,8 +111,14 @@ func TestAcc_Platform_AwsPeering(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "project_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
 					resource.TestCheckResourceAttrSet(resourceName, "expires_at"),
+					resource.TestCheckResourceAttrSet(resourceName, "seconds_to_expiry"),
 					resource.TestCheckResourceAttrSet(resourceName, "state"),
 					testLink(resourceName, "self_link", hvnPeeringUniqueAWSName, PeeringResourceType, "hcp_hvn.test"),
+					resource.TestCheckResourceAttrPair(resourceName, "location.0.organization_id", "hcp_hvn.test", "organization_id"),
+					resource.TestCheckResourceAttrPair(resourceName, "location.0.project_id", "hcp_hvn.test", "project_id"),
+					resource.TestCheckResourceAttrPair(resourceName, "location.0.cloud_provider", "hcp_hvn.test", "cloud_provider"),
+					resource.TestCheckResourceAttr(resourceName, "cloud_provider", "aws"),
+					resource.TestCheckResourceAttrPair(resourceName, "location.0.region", "hcp_hvn.test", "region"),
 				),
 			},
 			// Testing that we can import HVN route created in the previous step and that the
@@ -127,7 +137,7 @@ func TestAcc_Platform_AwsPeering(t *testing.T) {
 					return fmt.Sprintf("%s:%s", hvnID, peerID), nil
 				},
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"state"},
+				ImportStateVerifyIgnore: []string{"state", "seconds_to_expiry", "dependent_route_ids"},
 			},
 			// Testing running Terraform Apply for already known resource
 			{
@@ -145,6 +155,9 @@ func TestAcc_Platform_AwsPeering(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
 					resource.TestCheckResourceAttrSet(resourceName, "expires_at"),
 					resource.TestCheckResourceAttrSet(resourceName, "state"),
+					// The route is created after the peering, so it's only listed once the peering is refreshed.
+					resource.TestCheckResourceAttr(resourceName, "dependent_route_ids.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "dependent_route_ids.0", "hcp_hvn_route.route", "hvn_route_id"),
 					testLink(resourceName, "self_link", hvnPeeringUniqueAWSName, PeeringResourceType, "hcp_hvn.test"),
 				),
 			},
@@ -223,3 +236,33 @@ func testAccCheckHvnPeeringDestroy(s *terraform.State) error {
 	}
 	return nil
 }
+
+func Test_resourceAwsNetworkPeeringDelete_alreadyDeleted(t *testing.T) {
+	r := require.New(t)
+
+	var deleted int
+	client := &clients.Client{
+		Network: &testNetworkClient{
+			deletePeering: func(params *network_service.DeletePeeringParams) (*network_service.DeletePeeringOK, error) {
+				r.Equal("test-peering", params.ID)
+				r.Equal("test-hvn", params.HvnID)
+				deleted++
+
+				// The network peering was deleted outside of Terraform.
+				return nil, network_service.NewDeletePeeringDefault(http.StatusNotFound)
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceAwsNetworkPeering().Schema, map[string]interface{}{
+		"hvn_id":     "test-hvn",
+		"peering_id": "test-peering",
+	})
+	d.SetId(testPeeringLink("test-peering"))
+	r.NoError(d.Set("state", clients.PeeringStateActive))
+
+	diags := resourceAwsNetworkPeeringDelete(context.Background(), d, client)
+	r.False(diags.HasError(), "unexpected diagnostics: %v", diags)
+	r.Equal(1, deleted)
+	r.Empty(d.Id())
+}
@@ -76,7 +76,7 @@ func resourceAwsTransitGatewayAttachment() *schema.Resource {
 				Description: `
 The ID of the HCP project where the transit gateway attachment is located." 
 If not specified, the project specified in the HCP Provider config block will be used, if configured.
-If a project is not configured in the HCP Provider config block, the oldest project in the organization will be used.`,
+If a project is not configured in the HCP Provider config block, the organization's only project will be used.`,
 				Type:         schema.TypeString,
 				Optional:     true,
 				ForceNew:     true,
@@ -137,7 +137,7 @@ func resourceAwsTransitGatewayAttachmentCreate(ctx context.Context, d *schema.Re
 	}
 
 	// Check for an existing HVN
-	_, err = clients.GetHvnByID(ctx, client, loc, hvnID)
+	hvn, err := clients.GetHvnByIDCached(ctx, client, loc, hvnID)
 	if err != nil {
 		if clients.IsResponseCodeNotFound(err) {
 			return diag.Errorf("unable to find the HVN (%s) for the transit gateway attachment", hvnID)
@@ -145,6 +145,10 @@ func resourceAwsTransitGatewayAttachmentCreate(ctx context.Context, d *schema.Re
 
 		return diag.Errorf("unable to check for presence of an existing HVN (%s): %v", hvnID, err)
 	}
+
+	if err := clients.CheckHvnNotFailed(hvn); err != nil {
+		return diag.FromErr(err)
+	}
 	log.Printf("[INFO] HVN (%s) found, proceeding with transit gateway attachment create", hvnID)
 
 	// Check if TGW attachment already exists
@@ -181,7 +185,7 @@ func resourceAwsTransitGatewayAttachmentCreate(ctx context.Context, d *schema.Re
 	log.Printf("[INFO] Creating transit gateway attachment for HVN (%s) and transit gateway (%s)", hvnID, tgwID)
 	createTGWAttachmentResponse, err := client.Network.CreateTGWAttachment(createTGWAttachmentParams, nil)
 	if err != nil {
-		return diag.Errorf("unable to create transit gateway attachment for HVN (%s) and transit gateway (%s): %v", hvnID, tgwID, err)
+		return apiErrorDiag(err, "unable to create transit gateway attachment for HVN (%s) and transit gateway (%s)", hvnID, tgwID)
 	}
 
 	tgwAtt := createTGWAttachmentResponse.Payload.TgwAttachment
@@ -197,7 +201,7 @@ func resourceAwsTransitGatewayAttachmentCreate(ctx context.Context, d *schema.Re
 
 	// Wait for TGW attachment creation to complete
 	if err := clients.WaitForOperation(ctx, client, "create transit gateway attachment", loc, createTGWAttachmentResponse.Payload.Operation.ID); err != nil {
-		return diag.Errorf("unable to create transit gateway attachment (%s) for HVN (%s) and transit gateway (%s): %v", tgwAtt.ID, tgwAtt.Hvn.ID, tgwAtt.ProviderData.AwsData.TgwID, err)
+		return apiErrorDiag(err, "unable to create transit gateway attachment (%s) for HVN (%s) and transit gateway (%s)", tgwAtt.ID, tgwAtt.Hvn.ID, tgwAtt.ProviderData.AwsData.TgwID)
 	}
 
 	log.Printf("[INFO] Created transit gateway attachment (%s) for HVN (%s) and transit gateway (%s)", tgwAtt.ID, tgwAtt.Hvn.ID, tgwAtt.ProviderData.AwsData.TgwID)
@@ -238,7 +242,7 @@ func resourceAwsTransitGatewayAttachmentRead(ctx context.Context, d *schema.Reso
 			return nil
 		}
 
-		return diag.Errorf("unable to retrieve transit gateway attachment (%s): %v", tgwAttID, err)
+		return apiErrorDiag(err, "unable to retrieve transit gateway attachment (%s)", tgwAttID)
 	}
 
 	// TGW attachment has been found, update resource data
@@ -275,12 +279,12 @@ func resourceAwsTransitGatewayAttachmentDelete(ctx context.Context, d *schema.Re
 			return nil
 		}
 
-		return diag.Errorf("unable to delete transit gateway attachment (%s): %v", tgwAttID, err)
+		return apiErrorDiag(err, "unable to delete transit gateway attachment (%s)", tgwAttID)
 	}
 
 	// Wait for TGW attachment to be deleted
 	if err := clients.WaitForOperation(ctx, client, "delete transit gateway attachment", loc, deleteTGWAttResponse.Payload.Operation.ID); err != nil {
-		return diag.Errorf("unable to delete transit gateway attachment (%s): %v", tgwAttID, err)
+		return apiErrorDiag(err, "unable to delete transit gateway attachment (%s)", tgwAttID)
 	}
 
 	log.Printf("[INFO] Transit gateway attachment (%s) deleted, removing from state", tgwAttID)
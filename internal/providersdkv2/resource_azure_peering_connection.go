@@ -5,24 +5,39 @@ package providersdkv2
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/client/network_service"
 	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
 	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
 )
 
+// azurePeeringConnectionStateOnlyDefaults are the default values of the peering connection's attributes that only
+// exist in the Terraform state.
+var azurePeeringConnectionStateOnlyDefaults = map[string]interface{}{
+	"deletion_protection": false,
+	"cascade_delete":      false,
+	"wait_for_deletion":   false,
+}
+
 func resourceAzurePeeringConnection() *schema.Resource {
 	return &schema.Resource{
 		Description: "The Azure peering connection resource allows you to manage a peering connection between an HVN and a peer Azure VNet.",
 
 		CreateContext: resourceAzurePeeringConnectionCreate,
 		ReadContext:   resourceAzurePeeringConnectionRead,
+		UpdateContext: resourceAzurePeeringConnectionUpdate,
 		DeleteContext: resourceAzurePeeringConnectionDelete,
+		CustomizeDiff: customdiff.All(peeringReplaceIfExpired, autoRouteCustomizeDiff),
 		Timeouts: &schema.ResourceTimeout{
 			Default: &peeringDefaultTimeout,
 			Create:  &peeringCreateTimeout,
@@ -34,7 +49,7 @@ func resourceAzurePeeringConnection() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			// Required inputs
 			"hvn_link": {
-				Description: "The `self_link` of the HashiCorp Virtual Network (HVN).",
+				Description: "The `self_link` of the HashiCorp Virtual Network (HVN). Peering connections can't be moved to another HVN, so changing it replaces the peering connection.",
 				Type:        schema.TypeString,
 				Required:    true,
 				ForceNew:    true,
@@ -53,31 +68,50 @@ func resourceAzurePeeringConnection() *schema.Resource {
 				ForceNew:    true,
 			},
 			"peer_subscription_id": {
-				Description: "The subscription ID of the peer VNet in Azure.",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
+				Description:  "The subscription ID of the peer VNet in Azure. Required if `peer_resource_group_name` is set. Conflicts with `peer_resource_group_id`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"peer_resource_group_name"},
 			},
 			"peer_vnet_region": {
-				Description: "The region of the peer VNet in Azure.",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
+				Description:      "The region of the peer VNet in Azure. May differ from the region of the HVN. A VNet can't be moved to another region, so changing it replaces the peering connection. Both the programmatic and display names of a region, e.g. `westus` and `West US`, are accepted.",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateAzureRegion,
 				DiffSuppressFunc: func(_, old, new string, _ *schema.ResourceData) bool {
-					return strings.EqualFold(old, new)
+					return azureRegionsEqual(old, new)
 				},
 			},
 			"peer_tenant_id": {
-				Description: "The tenant ID of the peer VNet in Azure.",
+				Description: "The ID of the Azure tenant that owns `peer_subscription_id`. It can differ from the tenant of the credentials used to manage the rest of your Azure resources, in which case the service principal for `application_id` must be created in this tenant.",
 				Type:        schema.TypeString,
 				Required:    true,
 				ForceNew:    true,
 			},
 			"peer_resource_group_name": {
-				Description: "The resource group name of the peer VNet in Azure.",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
+				Description:  "The resource group name of the peer VNet in Azure. Exactly one of `peer_resource_group_name` or `peer_resource_group_id` must be set.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"peer_subscription_id"},
+				ExactlyOneOf: []string{"peer_resource_group_name", "peer_resource_group_id"},
+			},
+			"peer_resource_group_id": {
+				Description:      "The fully qualified Azure resource ID of the peer VNet's resource group, in the form `/subscriptions/{subscription_id}/resourceGroups/{resource_group_name}`. Exactly one of `peer_resource_group_name` or `peer_resource_group_id` must be set.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"peer_resource_group_name", "peer_resource_group_id"},
+				ConflictsWith:    []string{"peer_subscription_id"},
+				ValidateDiagFunc: validateAzureResourceGroupID,
+				DiffSuppressFunc: func(_, old, new string, _ *schema.ResourceData) bool {
+					return strings.EqualFold(old, new)
+				},
 			},
 			// Optional inputs
 			"allow_forwarded_traffic": {
@@ -94,6 +128,15 @@ func resourceAzurePeeringConnection() *schema.Resource {
 				Computed:    true,
 				ForceNew:    true,
 			},
+			"description":                 peeringDescriptionSchema("peering connection"),
+			"cascade_delete":              cascadeDeleteSchema("peering connection"),
+			"deletion_protection":         deletionProtectionSchema("peering connection"),
+			"wait_for_deletion":           waitForDeletionSchema("peering connection"),
+			"auto_route_destination_cidr": autoRouteDestinationCIDRSchema("peering connection"),
+			"auto_route_id":               autoRouteIDSchema("peering connection"),
+			"dependent_route_ids":         dependentRouteIDsSchema("peering connection"),
+			"location":                    peeringLocationSchema("peering connection"),
+			"cloud_provider":              peeringCloudProviderSchema("peering connection"),
 			// Computed outputs
 			"organization_id": {
 				Description: "The ID of the HCP organization where the peering connection is located. Always matches the HVN's organization.",
@@ -105,6 +148,11 @@ func resourceAzurePeeringConnection() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"peer_vnet_id": {
+				Description: "The fully qualified Azure resource ID of the peer VNet.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 			"application_id": {
 				Description: "The ID of the Azure application whose credentials are used to peer the HCP HVN's underlying VNet with the customer VNet.",
 				Type:        schema.TypeString,
@@ -115,6 +163,11 @@ func resourceAzurePeeringConnection() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"ready_for_import": {
+				Description: "Whether the peering connection can be fully imported, i.e. once `azure_peering_id` is set after the peering connection has been accepted. Peering connections imported before then are missing their Azure-side details until they are accepted.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
 			"created_at": {
 				Description: "The time that the peering connection was created.",
 				Type:        schema.TypeString,
@@ -125,6 +178,17 @@ func resourceAzurePeeringConnection() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"updated_at": {
+				Description: "The time that the peering connection was last updated.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"raw_json": peeringRawJSONSchema("peering connection"),
+			"seconds_to_expiry": {
+				Description: "The number of seconds left to accept the peering connection before it expires, as of the last refresh. `0` if the peering connection isn't in a `PENDING_ACCEPTANCE` state.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
 			"self_link": {
 				Description: "A unique URL identifying the peering connection.",
 				Type:        schema.TypeString,
@@ -135,6 +199,21 @@ func resourceAzurePeeringConnection() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"acceptance_command": {
+				Description: "The Azure CLI commands that grant the peering connection's `application_id` the permissions HCP requires on the peer VNet. Useful to complete the peering connection outside of Terraform. The Azure CLI must be logged in to the `peer_tenant_id` tenant, e.g. with `az login --tenant <peer_tenant_id>`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"acceptance_details_json": {
+				Description: "The details needed to accept the peering connection outside of Terraform, encoded as JSON, e.g. for external tooling: `application_id`, `peer_subscription_id`, `peer_tenant_id`, `peer_resource_group_name`, `peer_vnet_name` and `peer_vnet_id`. `application_id` is empty until HCP has assigned the peering connection an application.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"connectivity_state": {
+				Description: "The connectivity state of the peering connection. `NOT_CONNECTED` if the peering connection is not `ACTIVE`, otherwise `UNKNOWN`, since HCP doesn't currently report whether traffic is flowing over a peering connection.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -159,13 +238,20 @@ func resourceAzurePeeringConnectionCreate(ctx context.Context, d *schema.Resourc
 	allowForwardedTraffic := d.Get("allow_forwarded_traffic").(bool)
 	useRemoteGateways := d.Get("use_remote_gateways").(bool)
 
+	if v, ok := d.GetOk("peer_resource_group_id"); ok {
+		peerSubscriptionID, peerResourceGroupName, err = parseAzureResourceGroupID(v.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	hvnLink, err := buildLinkFromURL(d.Get("hvn_link").(string), HvnResourceType, client.Config.OrganizationID)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	// Check for an existing HVN
-	_, err = clients.GetHvnByID(ctx, client, hvnLink.Location, hvnLink.ID)
+	hvn, err := clients.GetHvnByIDCached(ctx, client, hvnLink.Location, hvnLink.ID)
 	if err != nil {
 		if clients.IsResponseCodeNotFound(err) {
 			return diag.Errorf("unable to find the HVN (%s) for the peering connection", hvnLink.ID)
@@ -173,6 +259,10 @@ func resourceAzurePeeringConnectionCreate(ctx context.Context, d *schema.Resourc
 
 		return diag.Errorf("unable to check for presence of an existing HVN (%s): %v", hvnLink.ID, err)
 	}
+
+	if err := clients.CheckHvnNotFailed(hvn); err != nil {
+		return diag.FromErr(err)
+	}
 	log.Printf("[INFO] HVN (%s) found, proceeding with peering connection create", hvnLink.ID)
 
 	loc := &sharedmodels.HashicorpCloudLocationLocation{
@@ -222,7 +312,15 @@ func resourceAzurePeeringConnectionCreate(ctx context.Context, d *schema.Resourc
 	log.Printf("[INFO] Creating peering connection between HVN (%s) and peer (%s)", hvnLink.ID, peerVnetID)
 	peeringResponse, err := client.Network.CreatePeering(peerNetworkParams, nil)
 	if err != nil {
-		return diag.Errorf("unable to create peering connection between HVN (%s) and peer (%s): %v", hvnLink.ID, peerVnetID, err)
+		if diags := peeringIDInUseDiag(ctx, client, err, "peering connection", peeringID, hvnLink.ID, loc); diags != nil {
+			return diags
+		}
+
+		if diags := azurePeeringPermissionDiag(err, hvnLink.ID, peerVnetID); diags != nil {
+			return diags
+		}
+
+		return apiErrorDiag(err, "unable to create peering connection between HVN (%s) and peer (%s)", hvnLink.ID, peerVnetID)
 	}
 
 	peering := peeringResponse.Payload.Peering
@@ -236,18 +334,26 @@ func resourceAzurePeeringConnectionCreate(ctx context.Context, d *schema.Resourc
 	}
 	d.SetId(url)
 
-	peering, err = clients.WaitForPeeringToBePendingAcceptance(ctx, client, peering.ID, hvnLink.ID, loc, d.Timeout(schema.TimeoutCreate))
+	if _, err := clients.GetPeeringAfterCreate(ctx, client, peering.ID, hvnLink.ID, loc); err != nil {
+		return apiErrorDiag(err, "unable to retrieve peering connection (%s)", peering.ID)
+	}
+
+	var diags diag.Diagnostics
+	peering, err = clients.WaitForPeeringToBePendingAcceptance(ctx, client, peering.ID, hvnLink.ID, loc, d.Timeout(schema.TimeoutCreate),
+		peeringStuckCreatingWarning(&diags, "peering connection", peering.ID, hvnLink.ID))
 	if err != nil {
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
 
 	log.Printf("[INFO] peering connection (%s) is now in PENDING_ACCEPTANCE state", peering.ID)
 
 	if err := setAzurePeeringResourceData(d, peering); err != nil {
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
 
-	return nil
+	// The peering connection is usually still pending acceptance, in which
+	// case the auto route is created by a later apply.
+	return append(diags, syncAutoRoute(ctx, client, d, "peering connection", hvnLink, peering.ID)...)
 }
 
 func resourceAzurePeeringConnectionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -276,7 +382,11 @@ func resourceAzurePeeringConnectionRead(ctx context.Context, d *schema.ResourceD
 			return nil
 		}
 
-		return diag.Errorf("unable to retrieve peering connection (%s): %v", peeringID, err)
+		return apiErrorDiag(err, "unable to retrieve peering connection (%s)", peeringID)
+	}
+
+	if err := setStateOnlyDefaults(d, azurePeeringConnectionStateOnlyDefaults); err != nil {
+		return diag.FromErr(err)
 	}
 
 	// peering connection found, update resource data
@@ -284,7 +394,37 @@ func resourceAzurePeeringConnectionRead(ctx context.Context, d *schema.ResourceD
 		return diag.FromErr(err)
 	}
 
-	return nil
+	// The peering connection itself was read, so failing to read the routes
+	// that target it only leaves their attributes stale.
+	var diags diag.Diagnostics
+	if err := setDependentRouteIDs(ctx, client, d, hvnLink.ID, peeringID, loc); err != nil {
+		diags = append(diags, partialReadWarning(err, "unable to list the HVN routes targeting peering connection (%s)", peeringID)...)
+	}
+
+	if err := readAutoRoute(ctx, client, d, hvnLink.ID, loc); err != nil {
+		diags = append(diags, partialReadWarning(err, "unable to retrieve the HVN route of peering connection (%s)", peeringID)...)
+	}
+
+	return append(diags, peeringExpiredWarning(d, "peering connection", peeringID)...)
+}
+
+// resourceAzurePeeringConnectionUpdate only has to sync the auto route, since
+// the other attributes either replace the peering connection or only exist in
+// the Terraform state.
+func resourceAzurePeeringConnectionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*clients.Client)
+
+	link, err := buildLinkFromURL(d.Id(), PeeringResourceType, client.Config.OrganizationID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	hvnLink, err := buildLinkFromURL(d.Get("hvn_link").(string), HvnResourceType, link.Location.OrganizationID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return syncAutoRoute(ctx, client, d, "peering connection", hvnLink, link.ID)
 }
 
 func resourceAzurePeeringConnectionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -297,11 +437,46 @@ func resourceAzurePeeringConnectionDelete(ctx context.Context, d *schema.Resourc
 
 	peeringID := link.ID
 	loc := link.Location
+
+	if diags := checkDeletionProtection(d, "peering connection", peeringID); diags != nil {
+		return diags
+	}
+
 	hvnLink, err := buildLinkFromURL(d.Get("hvn_link").(string), HvnResourceType, loc.OrganizationID)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	// The auto route is managed by the peering connection, so it is deleted
+	// regardless of cascade_delete.
+	if routeID := d.Get("auto_route_id").(string); routeID != "" {
+		if diags := deletePeeringRoute(ctx, client, "peering connection", hvnLink.ID, routeID, peeringID, loc); diags != nil {
+			return diags
+		}
+	}
+
+	if diags := deleteDependentRoutes(ctx, client, d, "peering connection", hvnLink.ID, peeringID, loc); diags != nil {
+		return diags
+	}
+
+	if d.Get("state").(string) == clients.PeeringStatePendingAcceptance {
+		log.Printf("[INFO] Peering connection (%s) is pending acceptance, canceling it", peeringID)
+		err := clients.CancelPeering(ctx, client, peeringID, hvnLink.ID, loc)
+		if err == nil {
+			if diags := waitForPeeringDeletion(ctx, client, d, "peering connection", hvnLink.ID, peeringID, loc); diags != nil {
+				return diags
+			}
+			log.Printf("[INFO] Peering connection (%s) canceled, removing from state", peeringID)
+			d.SetId("")
+			return nil
+		}
+		if !errors.Is(err, clients.ErrPeeringNotPending) {
+			return diag.Errorf("unable to cancel peering connection (%s): %v", peeringID, err)
+		}
+
+		log.Printf("[INFO] Peering connection (%s) has been accepted, deleting it", peeringID)
+	}
+
 	deletePeeringParams := network_service.NewDeletePeeringParams()
 	deletePeeringParams.Context = ctx
 	deletePeeringParams.ID = peeringID
@@ -313,10 +488,11 @@ func resourceAzurePeeringConnectionDelete(ctx context.Context, d *schema.Resourc
 	if err != nil {
 		if clients.IsResponseCodeNotFound(err) {
 			log.Printf("[WARN] peering connection (%s) not found, so no action was taken", peeringID)
+			d.SetId("")
 			return nil
 		}
 
-		return diag.Errorf("unable to delete peering connection (%s): %v", peeringID, err)
+		return apiErrorDiag(err, "unable to delete peering connection (%s)", peeringID)
 	}
 
 	// Wait for peering to be deleted
@@ -324,7 +500,11 @@ func resourceAzurePeeringConnectionDelete(ctx context.Context, d *schema.Resourc
 		if strings.Contains(err.Error(), "execution already started") {
 			return nil
 		}
-		return diag.Errorf("unable to delete peering connection (%s): %v", peeringID, err)
+		return apiErrorDiag(err, "unable to delete peering connection (%s)", peeringID)
+	}
+
+	if diags := waitForPeeringDeletion(ctx, client, d, "peering connection", hvnLink.ID, peeringID, loc); diags != nil {
+		return diags
 	}
 
 	log.Printf("[INFO] peering connection (%s) deleted, removing from state", peeringID)
@@ -332,6 +512,31 @@ func resourceAzurePeeringConnectionDelete(ctx context.Context, d *schema.Resourc
 	return nil
 }
 
+// azurePeeringPermissionDiag returns an error diagnostic explaining which
+// permissions are missing on the peer VNet if err, returned by a request to
+// create an Azure peering connection, was caused by HCP not being allowed to
+// peer with the VNet. It returns nil otherwise, so that the caller can report
+// err as is.
+func azurePeeringPermissionDiag(err error, hvnID, peerVnetID string) diag.Diagnostics {
+	applicationID, ok := clients.AzurePeeringPermissionError(err)
+	if !ok {
+		return nil
+	}
+
+	principal := "the service principal of the peering connection's `application_id`"
+	if applicationID != "" {
+		principal = fmt.Sprintf("the service principal of application (%s)", applicationID)
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("unable to create peering connection between HVN (%s) and peer (%s): HCP isn't authorized to peer with the VNet", hvnID, peerVnetID),
+		Detail: fmt.Sprintf("Assign %s a role on the peer VNet that allows the following actions, then apply again:\n  %s\n\n"+
+			"The actions are also provided by the hcp_azure_peering_required_permissions data source.\n\n%v",
+			principal, strings.Join(clients.AzurePeeringRequiredActions(), "\n  "), err),
+	}}
+}
+
 func setAzurePeeringResourceData(d *schema.ResourceData, peering *networkmodels.HashicorpCloudNetwork20200907Peering) error {
 	if err := d.Set("organization_id", peering.Hvn.Location.OrganizationID); err != nil {
 		return err
@@ -342,6 +547,15 @@ func setAzurePeeringResourceData(d *schema.ResourceData, peering *networkmodels.
 	if err := d.Set("peering_id", peering.ID); err != nil {
 		return err
 	}
+	// Set the HVN the peering connection belongs to, so that a peering
+	// connection that no longer belongs to the configured HVN is replaced.
+	hvnURL, err := linkURL(newLink(peering.Hvn.Location, HvnResourceType, peering.Hvn.ID))
+	if err != nil {
+		return err
+	}
+	if err := d.Set("hvn_link", hvnURL); err != nil {
+		return err
+	}
 	if err := d.Set("peer_subscription_id", peering.Target.AzureTarget.SubscriptionID); err != nil {
 		return err
 	}
@@ -354,12 +568,21 @@ func setAzurePeeringResourceData(d *schema.ResourceData, peering *networkmodels.
 	if err := d.Set("peer_resource_group_name", peering.Target.AzureTarget.ResourceGroupName); err != nil {
 		return err
 	}
+	if err := d.Set("peer_resource_group_id", clients.AzureResourceGroupID(peering.Target.AzureTarget)); err != nil {
+		return err
+	}
 	if err := d.Set("peer_tenant_id", peering.Target.AzureTarget.TenantID); err != nil {
 		return err
 	}
+	if err := d.Set("peer_vnet_id", clients.AzureVnetResourceID(peering.Target.AzureTarget)); err != nil {
+		return err
+	}
 	if err := d.Set("azure_peering_id", peering.ProviderPeeringID); err != nil {
 		return err
 	}
+	if err := d.Set("ready_for_import", peering.ProviderPeeringID != ""); err != nil {
+		return err
+	}
 	if err := d.Set("application_id", peering.Target.AzureTarget.ApplicationID); err != nil {
 		return err
 	}
@@ -375,9 +598,27 @@ func setAzurePeeringResourceData(d *schema.ResourceData, peering *networkmodels.
 	if err := d.Set("expires_at", peering.ExpiresAt.String()); err != nil {
 		return err
 	}
+	if err := d.Set("updated_at", peering.UpdatedAt.String()); err != nil {
+		return err
+	}
+	if err := setPeeringRawJSON(d, peering); err != nil {
+		return err
+	}
+	if err := d.Set("seconds_to_expiry", clients.PeeringSecondsToExpiry(peering, time.Now())); err != nil {
+		return err
+	}
 	if err := d.Set("state", peering.State); err != nil {
 		return err
 	}
+	if err := d.Set("connectivity_state", clients.PeeringConnectivityState(peering)); err != nil {
+		return err
+	}
+	if err := d.Set("acceptance_command", clients.AzurePeeringAcceptanceCommand(peering)); err != nil {
+		return err
+	}
+	if err := d.Set("acceptance_details_json", clients.AzurePeeringAcceptanceDetailsJSON(peering)); err != nil {
+		return err
+	}
 
 	link := newLink(peering.Hvn.Location, PeeringResourceType, peering.ID)
 	selfLink, err := linkURL(link)
@@ -387,10 +628,31 @@ func setAzurePeeringResourceData(d *schema.ResourceData, peering *networkmodels.
 	if err := d.Set("self_link", selfLink); err != nil {
 		return err
 	}
+	if err := setPeeringLocation(d, peering.Hvn.Location); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// azureRegionsEqual returns true if both names refer to the same Azure region.
+// Azure accepts both the programmatic name of a region, eg. `westus`, and its
+// display name, eg. `West US`.
+func azureRegionsEqual(a, b string) bool {
+	return strings.EqualFold(strings.ReplaceAll(a, " ", ""), strings.ReplaceAll(b, " ", ""))
+}
+
+// parseAzureResourceGroupID splits the fully qualified Azure resource ID of a
+// resource group into its subscription ID and resource group name.
+func parseAzureResourceGroupID(id string) (subscriptionID, resourceGroupName string, err error) {
+	matches := azureResourceGroupIDRegex.FindStringSubmatch(id)
+	if matches == nil {
+		return "", "", fmt.Errorf("unexpected format of resource group ID (%q), expected /subscriptions/{subscription_id}/resourceGroups/{resource_group_name}", id)
+	}
+
+	return matches[1], matches[2], nil
+}
+
 // resourceAzurePeeringConnectionImport implements the logic necessary to import an
 // un-tracked (by Terraform) peering connection resource into Terraform state.
 func resourceAzurePeeringConnectionImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -401,7 +663,15 @@ func resourceAzurePeeringConnectionImport(ctx context.Context, d *schema.Resourc
 	//   terraform import hcp_azure_peering_connection.test {hvn_id}:{peering_id}
 
 	client := meta.(*clients.Client)
-	projectID, hvnID, peeringID, err := parsePeeringResourceID(d.Id(), client.Config.ProjectID)
+
+	// Peering connections created in the HCP Portal are usually imported
+	// without knowing their IDs, so if only an HVN ID is given, list the
+	// HVN's peering connections that can be imported.
+	if d.Id() != "" && !strings.Contains(d.Id(), ":") {
+		return nil, azurePeeringImportSuggestionsError(ctx, client, d.Id())
+	}
+
+	projectID, hvnID, peeringID, err := importPeering(ctx, client, d.Id())
 	if err != nil {
 		return nil, err
 	}
@@ -427,5 +697,60 @@ func resourceAzurePeeringConnectionImport(ctx context.Context, d *schema.Resourc
 		return nil, err
 	}
 
+	if err := d.Set("deletion_protection", false); err != nil {
+		return nil, err
+	}
+
+	if err := d.Set("cascade_delete", false); err != nil {
+		return nil, err
+	}
+
+	if err := d.Set("wait_for_deletion", false); err != nil {
+		return nil, err
+	}
+
 	return []*schema.ResourceData{d}, nil
 }
+
+// azurePeeringImportSuggestionsError returns the error reported when an Azure
+// peering connection is imported with the ID of an HVN instead of the ID of a
+// peering connection. It lists the import IDs of the HVN's Azure peering
+// connections, so that they can be imported one at a time.
+func azurePeeringImportSuggestionsError(ctx context.Context, client *clients.Client, hvnID string) error {
+	const expected = "expected {hvn_id}:{peering_id} or {project_id}:{hvn_id}:{peering_id}"
+
+	projectID, err := GetProjectID("", client.Config.ProjectID)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve project ID: %v", err)
+	}
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: client.Config.OrganizationID,
+		ProjectID:      projectID,
+	}
+
+	log.Printf("[INFO] Listing peering connections of HVN (%s) to suggest import IDs", hvnID)
+	peerings, err := clients.ListPeerings(ctx, client, hvnID, loc)
+	if err != nil {
+		if clients.IsResponseCodeNotFound(err) {
+			return fmt.Errorf("unexpected format of ID (%q), %s; no HVN with ID (%s) found in project (%s)", hvnID, expected, hvnID, projectID)
+		}
+		return fmt.Errorf("unexpected format of ID (%q), %s; unable to list the peering connections of HVN (%s): %v", hvnID, expected, hvnID, err)
+	}
+
+	var importIDs []string
+	for _, peering := range peerings {
+		if peering.Target == nil || peering.Target.AzureTarget == nil {
+			continue
+		}
+		importIDs = append(importIDs, fmt.Sprintf("%s:%s", hvnID, peering.ID))
+	}
+
+	if len(importIDs) == 0 {
+		return fmt.Errorf("unexpected format of ID (%q), %s; HVN (%s) has no Azure peering connections", hvnID, expected, hvnID)
+	}
+
+	sort.Strings(importIDs)
+	return fmt.Errorf("unexpected format of ID (%q), %s; HVN (%s) has the following Azure peering connections, import each of them with its ID:\n  %s",
+		hvnID, expected, hvnID, strings.Join(importIDs, "\n  "))
+}
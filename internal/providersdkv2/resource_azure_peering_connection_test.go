@@ -5,13 +5,26 @@ package providersdkv2
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
-
+	"time"
+
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/client/network_service"
+	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
 )
 
 var (
@@ -47,6 +60,8 @@ func azureAdConfig(resID string) string {
 	  application_id = hcp_azure_peering_connection.peering.application_id
 	}
 
+	data "hcp_azure_peering_required_permissions" "required" {}
+
 	resource "azurerm_role_definition" "definition" {
 	  name  = "%[1]s"
 	  scope = azurerm_virtual_network.vnet.id
@@ -56,11 +71,7 @@ func azureAdConfig(resID string) string {
 	  ]
 
 	  permissions {
-		actions = [
-		  "Microsoft.Network/virtualNetworks/peer/action",
-		  "Microsoft.Network/virtualNetworks/virtualNetworkPeerings/read",
-		  "Microsoft.Network/virtualNetworks/virtualNetworkPeerings/write"
-		]
+		actions = data.hcp_azure_peering_required_permissions.required.actions
 	  }
 	}
 
@@ -210,6 +221,8 @@ func testAccAzurePeeringConnection(t *testing.T, adConfig string) {
 					resource.TestCheckResourceAttr(resourceName, "peer_subscription_id", subscriptionID),
 					resource.TestCheckResourceAttr(resourceName, "peer_tenant_id", tenantID),
 					resource.TestCheckResourceAttr(resourceName, "peer_vnet_name", uniqueAzurePeeringTestID),
+					resource.TestCheckResourceAttrPair(resourceName, "peer_vnet_id", "azurerm_virtual_network.vnet", "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "connectivity_state"),
 					resource.TestCheckResourceAttr(resourceName, "allow_forwarded_traffic", "false"),
 					resource.TestCheckResourceAttr(resourceName, "use_remote_gateways", "false"),
 					resource.TestCheckResourceAttrSet(resourceName, "peer_vnet_region"),
@@ -217,10 +230,24 @@ func testAccAzurePeeringConnection(t *testing.T, adConfig string) {
 					resource.TestCheckResourceAttrSet(resourceName, "project_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
 					resource.TestCheckResourceAttrSet(resourceName, "expires_at"),
+					resource.TestCheckResourceAttrSet(resourceName, "seconds_to_expiry"),
 					resource.TestCheckResourceAttrSet(resourceName, "state"),
+					func(s *terraform.State) error {
+						attrs := s.RootModule().Resources[resourceName].Primary.Attributes
+						if !strings.Contains(attrs["acceptance_command"], "--assignee "+attrs["application_id"]) {
+							return fmt.Errorf("acceptance_command (%q) doesn't assign the role to application_id (%s)", attrs["acceptance_command"], attrs["application_id"])
+						}
+						return nil
+					},
 					testLink(resourceName, "hvn_link", uniqueAzurePeeringTestID, HvnResourceType, resourceName),
 					testLink(resourceName, "self_link", uniqueAzurePeeringTestID, PeeringResourceType, "hcp_hvn.test"),
+					resource.TestCheckResourceAttrPair(resourceName, "location.0.organization_id", "hcp_hvn.test", "organization_id"),
+					resource.TestCheckResourceAttrPair(resourceName, "location.0.project_id", "hcp_hvn.test", "project_id"),
+					resource.TestCheckResourceAttrPair(resourceName, "location.0.cloud_provider", "hcp_hvn.test", "cloud_provider"),
+					resource.TestCheckResourceAttr(resourceName, "cloud_provider", "azure"),
+					resource.TestCheckResourceAttrPair(resourceName, "location.0.region", "hcp_hvn.test", "region"),
 					// Note: azure_peering_id is not set until the peering is accepted after creation.
+					resource.TestCheckResourceAttr(resourceName, "ready_for_import", "false"),
 				),
 			},
 			// Tests import
@@ -239,7 +266,7 @@ func testAccAzurePeeringConnection(t *testing.T, adConfig string) {
 					return fmt.Sprintf("%s:%s", hvnID, peerID), nil
 				},
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"state", "azure_peering_id"},
+				ImportStateVerifyIgnore: []string{"state", "connectivity_state", "seconds_to_expiry", "azure_peering_id", "ready_for_import", "dependent_route_ids"},
 			},
 			// Tests read
 			{
@@ -254,10 +281,12 @@ func testAccAzurePeeringConnection(t *testing.T, adConfig string) {
 					resource.TestCheckResourceAttr(resourceName, "use_remote_gateways", "false"),
 					resource.TestCheckResourceAttrSet(resourceName, "peer_vnet_region"),
 					resource.TestCheckResourceAttrSet(resourceName, "azure_peering_id"),
+					resource.TestCheckResourceAttr(resourceName, "ready_for_import", "true"),
 					resource.TestCheckResourceAttrSet(resourceName, "organization_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "project_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
 					resource.TestCheckResourceAttrSet(resourceName, "expires_at"),
+					resource.TestCheckResourceAttr(resourceName, "seconds_to_expiry", "0"),
 					resource.TestCheckResourceAttrSet(resourceName, "state"),
 					testLink(resourceName, "hvn_link", uniqueAzurePeeringTestID, HvnResourceType, resourceName),
 					testLink(resourceName, "self_link", uniqueAzurePeeringTestID, PeeringResourceType, "hcp_hvn.test"),
@@ -267,6 +296,237 @@ func testAccAzurePeeringConnection(t *testing.T, adConfig string) {
 	})
 }
 
+// TestAcc_Platform_AzurePeeringConnectionCrossRegion tests peering an HVN with
+// a VNet in a different Azure region.
+func TestAcc_Platform_AzurePeeringConnectionCrossRegion(t *testing.T) {
+	t.Parallel()
+
+	uniqueAzurePeeringTestID := testAccUniqueNameWithPrefix("p-az-peer-xreg")
+	resourceName := "hcp_azure_peering_connection.peering"
+	dataSourceName := "data.hcp_azure_peering_connection.peering"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t, map[string]bool{"aws": false, "azure": true}) },
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {VersionConstraint: "~> 3.63"},
+			"azuread": {VersionConstraint: "~> 2.39"},
+		},
+		CheckDestroy: testAccCheckAzurePeeringDestroy,
+
+		Steps: []resource.TestStep{
+			{
+				Config: testConfig(crossRegionConfig(uniqueAzurePeeringTestID)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAzurePeeringExists(resourceName),
+					resource.TestCheckResourceAttr("hcp_hvn.test", "region", "eastus"),
+					resource.TestCheckResourceAttr(resourceName, "peer_vnet_region", "westus"),
+					resource.TestCheckResourceAttrPair(resourceName, "peer_vnet_id", "azurerm_virtual_network.vnet", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "state", "ACTIVE"),
+					resource.TestCheckResourceAttr(dataSourceName, "peer_vnet_region", "westus"),
+				),
+			},
+			// Tests that reading the peering back doesn't cause a diff
+			{
+				Config:   testConfig(crossRegionConfig(uniqueAzurePeeringTestID)),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+// crossRegionConfig is the config for an eastus HVN peered with a westus VNet.
+func crossRegionConfig(resID string) string {
+	return fmt.Sprintf(`
+	provider "azurerm" {
+	  features {}
+	}
+
+	resource "hcp_hvn" "test" {
+	  hvn_id         = "%[1]s"
+	  cloud_provider = "azure"
+	  region         = "eastus"
+	  cidr_block     = "172.25.16.0/20"
+	}
+
+	resource "hcp_azure_peering_connection" "peering" {
+	  hvn_link                 = hcp_hvn.test.self_link
+	  peering_id               = "%[1]s"
+	  peer_subscription_id     = "%[2]s"
+	  peer_tenant_id           = "%[3]s"
+	  peer_vnet_name           = azurerm_virtual_network.vnet.name
+	  peer_resource_group_name = azurerm_resource_group.rg.name
+	  peer_vnet_region         = azurerm_virtual_network.vnet.location
+	}
+
+	data "hcp_azure_peering_connection" "peering" {
+	  hvn_link              = hcp_hvn.test.self_link
+	  peering_id            = hcp_azure_peering_connection.peering.peering_id
+	  wait_for_active_state = true
+
+	  depends_on = [azurerm_role_assignment.assignment]
+	}
+
+	resource "azurerm_resource_group" "rg" {
+	  name     = "%[1]s"
+	  location = "West US"
+	}
+
+	resource "azurerm_virtual_network" "vnet" {
+	  name                = "%[1]s"
+	  location            = azurerm_resource_group.rg.location
+	  resource_group_name = azurerm_resource_group.rg.name
+
+	  address_space = [
+		"10.0.0.0/16"
+	  ]
+	}
+
+	%[4]s
+	`, resID, subscriptionID, tenantID, azureAdConfig(resID))
+}
+
+// TestAcc_Platform_AzurePeeringConnectionCrossTenant tests peering an HVN with
+// a VNet in a tenant other than ARM_TENANT_ID. It requires a second tenant
+// that the Azure credentials can sign in to, so it is skipped unless
+// ARM_PEER_TENANT_ID and ARM_PEER_SUBSCRIPTION_ID are set.
+func TestAcc_Platform_AzurePeeringConnectionCrossTenant(t *testing.T) {
+	peerTenantID := os.Getenv("ARM_PEER_TENANT_ID")
+	peerSubscriptionID := os.Getenv("ARM_PEER_SUBSCRIPTION_ID")
+	if peerTenantID == "" || peerSubscriptionID == "" {
+		t.Skip("ARM_PEER_TENANT_ID and ARM_PEER_SUBSCRIPTION_ID must be set for cross-tenant acceptance tests")
+	}
+	t.Parallel()
+
+	uniqueAzurePeeringTestID := testAccUniqueNameWithPrefix("p-az-peer-xten")
+	resourceName := "hcp_azure_peering_connection.peering"
+	dataSourceName := "data.hcp_azure_peering_connection.peering"
+	tfConfig := crossTenantConfig(uniqueAzurePeeringTestID, peerTenantID, peerSubscriptionID)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAccPreCheck(t, map[string]bool{"aws": false, "azure": true})
+			if peerTenantID == tenantID {
+				t.Fatal("ARM_PEER_TENANT_ID must differ from ARM_TENANT_ID")
+			}
+		},
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		ExternalProviders: map[string]resource.ExternalProvider{
+			"azurerm": {VersionConstraint: "~> 3.63"},
+			"azuread": {VersionConstraint: "~> 2.39"},
+		},
+		CheckDestroy: testAccCheckAzurePeeringDestroy,
+
+		Steps: []resource.TestStep{
+			{
+				Config: testConfig(tfConfig),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAzurePeeringExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "peer_tenant_id", peerTenantID),
+					resource.TestCheckResourceAttr(resourceName, "peer_subscription_id", peerSubscriptionID),
+					resource.TestCheckResourceAttrPair(resourceName, "peer_vnet_id", "azurerm_virtual_network.vnet", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "state", "ACTIVE"),
+					resource.TestCheckResourceAttr(dataSourceName, "peer_tenant_id", peerTenantID),
+				),
+			},
+			// Tests that reading the peering back doesn't cause a diff
+			{
+				Config:   testConfig(tfConfig),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+// crossTenantConfig is the config for an HVN peered with a VNet in another
+// tenant. The peer VNet and the service principal are managed by aliased
+// providers for the peer tenant.
+func crossTenantConfig(resID, peerTenantID, peerSubscriptionID string) string {
+	return fmt.Sprintf(`
+	provider "azurerm" {
+	  alias           = "peer"
+	  tenant_id       = "%[2]s"
+	  subscription_id = "%[3]s"
+	  features {}
+	}
+
+	provider "azuread" {
+	  alias     = "peer"
+	  tenant_id = "%[2]s"
+	}
+
+	resource "hcp_hvn" "test" {
+	  hvn_id         = "%[1]s"
+	  cloud_provider = "azure"
+	  region         = "eastus"
+	  cidr_block     = "172.25.16.0/20"
+	}
+
+	resource "hcp_azure_peering_connection" "peering" {
+	  hvn_link                 = hcp_hvn.test.self_link
+	  peering_id               = "%[1]s"
+	  peer_subscription_id     = "%[3]s"
+	  peer_tenant_id           = "%[2]s"
+	  peer_vnet_name           = azurerm_virtual_network.vnet.name
+	  peer_resource_group_name = azurerm_resource_group.rg.name
+	  peer_vnet_region         = "eastus"
+	}
+
+	data "hcp_azure_peering_connection" "peering" {
+	  hvn_link              = hcp_hvn.test.self_link
+	  peering_id            = hcp_azure_peering_connection.peering.peering_id
+	  wait_for_active_state = true
+
+	  depends_on = [azurerm_role_assignment.assignment]
+	}
+
+	resource "azurerm_resource_group" "rg" {
+	  provider = azurerm.peer
+	  name     = "%[1]s"
+	  location = "East US"
+	}
+
+	resource "azurerm_virtual_network" "vnet" {
+	  provider            = azurerm.peer
+	  name                = "%[1]s"
+	  location            = azurerm_resource_group.rg.location
+	  resource_group_name = azurerm_resource_group.rg.name
+
+	  address_space = [
+		"10.0.0.0/16"
+	  ]
+	}
+
+	resource "azuread_service_principal" "principal" {
+	  provider       = azuread.peer
+	  application_id = hcp_azure_peering_connection.peering.application_id
+	}
+
+	data "hcp_azure_peering_required_permissions" "required" {}
+
+	resource "azurerm_role_definition" "definition" {
+	  provider = azurerm.peer
+	  name     = "%[1]s"
+	  scope    = azurerm_virtual_network.vnet.id
+
+	  assignable_scopes = [
+		azurerm_virtual_network.vnet.id
+	  ]
+
+	  permissions {
+		actions = data.hcp_azure_peering_required_permissions.required.actions
+	  }
+	}
+
+	resource "azurerm_role_assignment" "assignment" {
+	  provider           = azurerm.peer
+	  principal_id       = azuread_service_principal.principal.id
+	  scope              = azurerm_virtual_network.vnet.id
+	  role_definition_id = azurerm_role_definition.definition.role_definition_resource_id
+	}
+	`, resID, peerTenantID, peerSubscriptionID)
+}
+
 // TestAcc_Platform_AzurePeeringConnectionNVA tests Azure peering with NVA hub / spoke networking
 func TestAcc_Platform_AzurePeeringConnectionNVA(t *testing.T) {
 	t.Parallel()
@@ -330,7 +590,7 @@ func testAccAzurePeeringConnectionNVA(t *testing.T, adConfig string) {
 					return fmt.Sprintf("%s:%s", hvnID, peerID), nil
 				},
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"state", "azure_peering_id"},
+				ImportStateVerifyIgnore: []string{"state", "connectivity_state", "seconds_to_expiry", "azure_peering_id", "ready_for_import", "dependent_route_ids"},
 			},
 			// Tests read
 			{
@@ -345,6 +605,7 @@ func testAccAzurePeeringConnectionNVA(t *testing.T, adConfig string) {
 					resource.TestCheckResourceAttr(resourceName, "use_remote_gateways", "false"),
 					resource.TestCheckResourceAttrSet(resourceName, "peer_vnet_region"),
 					resource.TestCheckResourceAttrSet(resourceName, "azure_peering_id"),
+					resource.TestCheckResourceAttr(resourceName, "ready_for_import", "true"),
 					resource.TestCheckResourceAttrSet(resourceName, "organization_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "project_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
@@ -421,7 +682,7 @@ func testAccAzurePeeringConnectionGateway(t *testing.T, adConfig string) {
 					return fmt.Sprintf("%s:%s", hvnID, peerID), nil
 				},
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"state", "azure_peering_id"},
+				ImportStateVerifyIgnore: []string{"state", "connectivity_state", "seconds_to_expiry", "azure_peering_id", "ready_for_import", "dependent_route_ids"},
 			},
 			// Tests read
 			{
@@ -436,6 +697,7 @@ func testAccAzurePeeringConnectionGateway(t *testing.T, adConfig string) {
 					resource.TestCheckResourceAttr(resourceName, "use_remote_gateways", "true"),
 					resource.TestCheckResourceAttrSet(resourceName, "peer_vnet_region"),
 					resource.TestCheckResourceAttrSet(resourceName, "azure_peering_id"),
+					resource.TestCheckResourceAttr(resourceName, "ready_for_import", "true"),
 					resource.TestCheckResourceAttrSet(resourceName, "organization_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "project_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
@@ -512,7 +774,7 @@ func testAccAzurePeeringConnectionNVAandGateway(t *testing.T, adConfig string) {
 					return fmt.Sprintf("%s:%s", hvnID, peerID), nil
 				},
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"state", "azure_peering_id"},
+				ImportStateVerifyIgnore: []string{"state", "connectivity_state", "seconds_to_expiry", "azure_peering_id", "ready_for_import", "dependent_route_ids"},
 			},
 			// Tests read
 			{
@@ -527,6 +789,7 @@ func testAccAzurePeeringConnectionNVAandGateway(t *testing.T, adConfig string) {
 					resource.TestCheckResourceAttr(resourceName, "use_remote_gateways", "true"),
 					resource.TestCheckResourceAttrSet(resourceName, "peer_vnet_region"),
 					resource.TestCheckResourceAttrSet(resourceName, "azure_peering_id"),
+					resource.TestCheckResourceAttr(resourceName, "ready_for_import", "true"),
 					resource.TestCheckResourceAttrSet(resourceName, "organization_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "project_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
@@ -540,6 +803,10 @@ func testAccAzurePeeringConnectionNVAandGateway(t *testing.T, adConfig string) {
 	})
 }
 
+// azurePeeringExistsTimeout is how long testAccCheckAzurePeeringExists retries
+// reading a peering connection for.
+const azurePeeringExistsTimeout = 2 * time.Minute
+
 func testAccCheckAzurePeeringExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[name]
@@ -572,7 +839,20 @@ func testAccCheckAzurePeeringExists(name string) resource.TestCheckFunc {
 		azurePeeringID := peeringLink.ID
 		loc := peeringLink.Location
 
-		if _, err := clients.GetPeeringByID(context.Background(), client, azurePeeringID, hvnLink.ID, loc); err != nil {
+		// Reads may briefly fail right after create, either because the
+		// peering connection isn't visible yet or because of a transient
+		// server error, so retry those for a bounded time.
+		err = retry.RetryContext(context.Background(), azurePeeringExistsTimeout, func() *retry.RetryError {
+			_, err := clients.GetPeeringByID(context.Background(), client, azurePeeringID, hvnLink.ID, loc)
+			if err == nil {
+				return nil
+			}
+			if apiErr, ok := clients.ParseAPIError(err); clients.IsResponseCodeNotFound(err) || (ok && apiErr.HTTPCode >= http.StatusInternalServerError) {
+				return retry.RetryableError(err)
+			}
+			return retry.NonRetryableError(err)
+		})
+		if err != nil {
 			return fmt.Errorf("unable to get peering connection %q: %v", id, err)
 		}
 
@@ -622,3 +902,1308 @@ func testAccCheckAzurePeeringDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+// Test_resourceAzurePeeringConnectionRead_externallyDeleted simulates a peering
+// that was deleted outside of Terraform: the resource should be removed from
+// state so that it's planned for recreation.
+func Test_resourceAzurePeeringConnectionRead_externallyDeleted(t *testing.T) {
+	r := require.New(t)
+
+	client := &clients.Client{
+		Network: &testNetworkClient{
+			getPeering: func(*network_service.GetPeeringParams) (*network_service.GetPeeringOK, error) {
+				return nil, network_service.NewGetPeeringDefault(http.StatusNotFound)
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceAzurePeeringConnection().Schema, map[string]interface{}{
+		"hvn_link":   testHvnLink("test-hvn"),
+		"peering_id": "test-peering",
+	})
+	d.SetId(testPeeringLink("test-peering"))
+
+	diags := resourceAzurePeeringConnectionRead(context.Background(), d, client)
+	r.False(diags.HasError())
+	r.Empty(d.Id())
+}
+
+func Test_resourceAzurePeeringConnectionRead_routesUnavailable(t *testing.T) {
+	r := require.New(t)
+
+	client := &clients.Client{
+		Network: &testNetworkClient{
+			getPeering: func(*network_service.GetPeeringParams) (*network_service.GetPeeringOK, error) {
+				return testPeeringOK(testPeering("test-peering", networkmodels.HashicorpCloudNetwork20200907PeeringStateACTIVE, &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{
+					AzureTarget: &networkmodels.HashicorpCloudNetwork20200907AzurePeeringTarget{VnetName: "test-vnet"},
+				})), nil
+			},
+			listHVNRoutes: func(*network_service.ListHVNRoutesParams) (*network_service.ListHVNRoutesOK, error) {
+				return nil, network_service.NewListHVNRoutesDefault(http.StatusServiceUnavailable)
+			},
+			getHVNRoute: func(*network_service.GetHVNRouteParams) (*network_service.GetHVNRouteOK, error) {
+				return nil, network_service.NewGetHVNRouteDefault(http.StatusServiceUnavailable)
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceAzurePeeringConnection().Schema, map[string]interface{}{
+		"hvn_link":                    testHvnLink("test-hvn"),
+		"peering_id":                  "test-peering",
+		"auto_route_destination_cidr": "10.0.0.0/16",
+	})
+	d.SetId(testPeeringLink("test-peering"))
+	r.NoError(d.Set("dependent_route_ids", []string{"test-route"}))
+	r.NoError(d.Set("auto_route_id", "test-peering"))
+
+	// The peering connection is refreshed, and the attributes that depend on
+	// the failed requests keep their previous values.
+	diags := resourceAzurePeeringConnectionRead(context.Background(), d, client)
+	r.False(diags.HasError(), "unexpected diagnostics: %v", diags)
+	r.Len(diags, 2)
+	r.Equal(diag.Warning, diags[0].Severity)
+	r.Contains(diags[0].Summary, "unable to list the HVN routes targeting peering connection (test-peering)")
+	r.Contains(diags[1].Summary, "unable to retrieve the HVN route of peering connection (test-peering)")
+
+	r.Equal(testPeeringLink("test-peering"), d.Id())
+	r.Equal("ACTIVE", d.Get("state"))
+	r.Equal("test-vnet", d.Get("peer_vnet_name"))
+	r.Equal([]interface{}{"test-route"}, d.Get("dependent_route_ids"))
+	r.Equal("test-peering", d.Get("auto_route_id"))
+	r.Equal("10.0.0.0/16", d.Get("auto_route_destination_cidr"))
+}
+
+func Test_setAzurePeeringResourceData(t *testing.T) {
+	r := require.New(t)
+
+	peering := &networkmodels.HashicorpCloudNetwork20200907Peering{
+		ID: "test-peering",
+		Hvn: &sharedmodels.HashicorpCloudLocationLink{
+			ID:       "test-hvn",
+			Location: testLocation(),
+		},
+		Target: &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{
+			AzureTarget: &networkmodels.HashicorpCloudNetwork20200907AzurePeeringTarget{
+				SubscriptionID:    "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b",
+				ResourceGroupName: "test-rg",
+				VnetName:          "test-vnet",
+				Region:            "eastus",
+				TenantID:          "0d7b3c1e-8a4f-4e6d-b2c9-5f1a3e7d9c2b",
+				ApplicationID:     "5c1d6f3a-9e2b-4a7c-8d0f-3b6e1a4c7d9e",
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceAzurePeeringConnection().Schema, map[string]interface{}{})
+	r.NoError(setAzurePeeringResourceData(d, peering))
+
+	expected := fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/virtualNetworks/%s",
+		d.Get("peer_subscription_id"), d.Get("peer_resource_group_name"), d.Get("peer_vnet_name"))
+	r.Equal(expected, d.Get("peer_vnet_id"))
+	r.Equal("/subscriptions/2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b/resourceGroups/test-rg/providers/Microsoft.Network/virtualNetworks/test-vnet", d.Get("peer_vnet_id"))
+	r.Equal("/subscriptions/2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b/resourceGroups/test-rg", d.Get("peer_resource_group_id"))
+	r.Equal(clients.PeeringConnectivityNotConnected, d.Get("connectivity_state"))
+	r.Contains(d.Get("acceptance_command"), "--assignee 5c1d6f3a-9e2b-4a7c-8d0f-3b6e1a4c7d9e")
+	r.Contains(d.Get("acceptance_command"), d.Get("peer_vnet_id"))
+
+	// The peering connection can only be fully imported once it has been
+	// accepted, and Azure has assigned it an ID.
+	r.Empty(d.Get("azure_peering_id"))
+	r.Equal(false, d.Get("ready_for_import"))
+
+	peering.State = networkmodels.HashicorpCloudNetwork20200907PeeringStateACTIVE.Pointer()
+	peering.ProviderPeeringID = "/subscriptions/2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b/resourceGroups/hcp-rg/providers/Microsoft.Network/virtualNetworks/hcp-vnet/virtualNetworkPeerings/test-peering"
+	r.NoError(setAzurePeeringResourceData(d, peering))
+	r.Equal(peering.ProviderPeeringID, d.Get("azure_peering_id"))
+	r.Equal(true, d.Get("ready_for_import"))
+}
+
+// unknownValue is how the SDK represents a value that isn't known until apply
+// in a raw resource config.
+const unknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+// Test_resourceAzurePeeringConnection_unknownPeer checks that peering
+// connections can be planned when the peer VNet's attributes come from azurerm
+// resources created in the same apply, and so are unknown.
+func Test_resourceAzurePeeringConnection_unknownPeer(t *testing.T) {
+	baseConfig := map[string]interface{}{
+		"hvn_link":         testHvnLink("test-hvn"),
+		"peering_id":       "test-peering",
+		"peer_vnet_region": "eastus",
+		"peer_tenant_id":   "0d7b3c1e-8a4f-4e6d-b2c9-5f1a3e7d9c2b",
+	}
+
+	tcs := map[string]map[string]interface{}{
+		"unknown vnet name": {
+			"peer_vnet_name":           unknownValue,
+			"peer_subscription_id":     "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b",
+			"peer_resource_group_name": "test-rg",
+		},
+		"unknown vnet name and resource group name": {
+			"peer_vnet_name":           unknownValue,
+			"peer_subscription_id":     unknownValue,
+			"peer_resource_group_name": unknownValue,
+		},
+		"unknown vnet name and resource group id": {
+			"peer_vnet_name":         unknownValue,
+			"peer_resource_group_id": unknownValue,
+		},
+	}
+
+	for n, config := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			raw := make(map[string]interface{})
+			for k, v := range baseConfig {
+				raw[k] = v
+			}
+			for k, v := range config {
+				raw[k] = v
+			}
+
+			res := resourceAzurePeeringConnection()
+			cfg := sdkterraform.NewResourceConfigRaw(raw)
+			diags := res.Validate(cfg)
+			r.False(diags.HasError(), "unexpected diagnostics: %v", diags)
+
+			d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{})
+			diff, err := res.Diff(context.Background(), d.State(), cfg, nil)
+			r.NoError(err)
+			r.NotNil(diff)
+
+			for k, v := range config {
+				if v == unknownValue {
+					r.True(diff.Attributes[k].NewComputed, k)
+				}
+			}
+			r.True(diff.Attributes["peer_vnet_id"].NewComputed)
+		})
+	}
+}
+
+func Test_resourceAzurePeeringConnection_peerValidation(t *testing.T) {
+	baseConfig := map[string]interface{}{
+		"hvn_link":         testHvnLink("test-hvn"),
+		"peering_id":       "test-peering",
+		"peer_vnet_name":   "test-vnet",
+		"peer_vnet_region": "eastus",
+		"peer_tenant_id":   "0d7b3c1e-8a4f-4e6d-b2c9-5f1a3e7d9c2b",
+	}
+
+	tcs := map[string]struct {
+		config map[string]interface{}
+		unset  []string
+		hasErr bool
+		// missing are the attributes that the diagnostics must name.
+		missing []string
+	}{
+		"name and subscription": {
+			config: map[string]interface{}{
+				"peer_subscription_id":     "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b",
+				"peer_resource_group_name": "test-rg",
+			},
+			hasErr: false,
+		},
+		"resource group id": {
+			config: map[string]interface{}{
+				"peer_resource_group_id": "/subscriptions/2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b/resourceGroups/test-rg",
+			},
+			hasErr: false,
+		},
+		"neither form": {
+			config:  map[string]interface{}{},
+			hasErr:  true,
+			missing: []string{"peer_resource_group_name", "peer_resource_group_id"},
+		},
+		"name without subscription": {
+			config: map[string]interface{}{
+				"peer_resource_group_name": "test-rg",
+			},
+			hasErr:  true,
+			missing: []string{"peer_subscription_id"},
+		},
+		"subscription without name": {
+			config: map[string]interface{}{
+				"peer_subscription_id": "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b",
+			},
+			hasErr:  true,
+			missing: []string{"peer_resource_group_name"},
+		},
+		"vnet name missing": {
+			config: map[string]interface{}{
+				"peer_subscription_id":     "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b",
+				"peer_resource_group_name": "test-rg",
+			},
+			unset:   []string{"peer_vnet_name"},
+			hasErr:  true,
+			missing: []string{"peer_vnet_name"},
+		},
+		"tenant missing": {
+			config: map[string]interface{}{
+				"peer_resource_group_id": "/subscriptions/2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b/resourceGroups/test-rg",
+			},
+			unset:   []string{"peer_tenant_id"},
+			hasErr:  true,
+			missing: []string{"peer_tenant_id"},
+		},
+		"only vnet name": {
+			config:  map[string]interface{}{},
+			unset:   []string{"peer_tenant_id"},
+			hasErr:  true,
+			missing: []string{"peer_tenant_id", "peer_resource_group_name", "peer_resource_group_id"},
+		},
+		"both forms": {
+			config: map[string]interface{}{
+				"peer_subscription_id":     "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b",
+				"peer_resource_group_name": "test-rg",
+				"peer_resource_group_id":   "/subscriptions/2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b/resourceGroups/test-rg",
+			},
+			hasErr: true,
+		},
+		"resource group id and subscription": {
+			config: map[string]interface{}{
+				"peer_subscription_id":   "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b",
+				"peer_resource_group_id": "/subscriptions/2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b/resourceGroups/test-rg",
+			},
+			hasErr: true,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			raw := make(map[string]interface{})
+			for k, v := range baseConfig {
+				raw[k] = v
+			}
+			for k, v := range tc.config {
+				raw[k] = v
+			}
+			for _, k := range tc.unset {
+				delete(raw, k)
+			}
+
+			diags := resourceAzurePeeringConnection().Validate(sdkterraform.NewResourceConfigRaw(raw))
+			r.Equal(tc.hasErr, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+			var messages []string
+			for _, d := range diags {
+				messages = append(messages, d.Summary+": "+d.Detail)
+			}
+			for _, attr := range tc.missing {
+				r.Contains(strings.Join(messages, "\n"), attr)
+			}
+		})
+	}
+}
+
+func Test_parseAzureResourceGroupID(t *testing.T) {
+	r := require.New(t)
+
+	subscriptionID, resourceGroupName, err := parseAzureResourceGroupID("/subscriptions/2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b/resourceGroups/test-rg")
+	r.NoError(err)
+	r.Equal("2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b", subscriptionID)
+	r.Equal("test-rg", resourceGroupName)
+
+	_, _, err = parseAzureResourceGroupID("test-rg")
+	r.Error(err)
+}
+
+func Test_resourceAzurePeeringConnectionDelete_pendingAcceptance(t *testing.T) {
+	tcs := map[string]struct {
+		currentState networkmodels.HashicorpCloudNetwork20200907PeeringState
+	}{
+		"still pending acceptance": {
+			currentState: networkmodels.HashicorpCloudNetwork20200907PeeringStatePENDINGACCEPTANCE,
+		},
+		"accepted since last refresh": {
+			currentState: networkmodels.HashicorpCloudNetwork20200907PeeringStateACTIVE,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			var deleted int
+			client := &clients.Client{
+				Network: &testNetworkClient{
+					getPeering: func(*network_service.GetPeeringParams) (*network_service.GetPeeringOK, error) {
+						return testPeeringOK(testPeering("test-peering", tc.currentState, nil)), nil
+					},
+					deletePeering: func(params *network_service.DeletePeeringParams) (*network_service.DeletePeeringOK, error) {
+						r.Equal("test-peering", params.ID)
+						r.Equal("test-hvn", params.HvnID)
+						deleted++
+
+						return &network_service.DeletePeeringOK{
+							Payload: &networkmodels.HashicorpCloudNetwork20200907DeletePeeringResponse{
+								Operation: &sharedmodels.HashicorpCloudOperationOperation{ID: "delete-peering"},
+							},
+						}, nil
+					},
+				},
+				Operation: &testOperationClient{},
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceAzurePeeringConnection().Schema, map[string]interface{}{
+				"hvn_link":   testHvnLink("test-hvn"),
+				"peering_id": "test-peering",
+			})
+			d.SetId(testPeeringLink("test-peering"))
+			r.NoError(d.Set("state", clients.PeeringStatePendingAcceptance))
+
+			diags := resourceAzurePeeringConnectionDelete(context.Background(), d, client)
+			r.False(diags.HasError(), "unexpected diagnostics: %v", diags)
+			r.Equal(1, deleted)
+		})
+	}
+}
+
+func Test_resourceAzurePeeringConnection_autoRoute(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: testOrganizationID,
+		ProjectID:      testProjectID,
+		Region: &sharedmodels.HashicorpCloudLocationRegion{
+			Provider: "azure",
+			Region:   "eastus",
+		},
+	}
+
+	var (
+		created *networkmodels.HashicorpCloudNetwork20200907Peering
+		state   = networkmodels.HashicorpCloudNetwork20200907PeeringStatePENDINGACCEPTANCE
+		route   *networkmodels.HashicorpCloudNetwork20200907HVNRoute
+		deleted []string
+
+		// routeIDs are the IDs of the HVN's routes. A route managed by an
+		// hcp_hvn_route resource is named after the peering connection.
+		routeIDs = map[string]bool{"test-peering": true}
+	)
+	client := &clients.Client{
+		Config: clients.ClientConfig{OrganizationID: testOrganizationID, PollInterval: time.Millisecond},
+		Network: &testNetworkClient{
+			get: func(*network_service.GetParams) (*network_service.GetOK, error) {
+				return &network_service.GetOK{
+					Payload: &networkmodels.HashicorpCloudNetwork20200907GetResponse{
+						Network: &networkmodels.HashicorpCloudNetwork20200907Network{ID: "test-hvn", CidrBlock: "172.25.16.0/20", Location: loc},
+					},
+				}, nil
+			},
+			getPeering: func(*network_service.GetPeeringParams) (*network_service.GetPeeringOK, error) {
+				if created == nil {
+					return nil, network_service.NewGetPeeringDefault(http.StatusNotFound)
+				}
+
+				peering := *created
+				peering.State = state.Pointer()
+				return testPeeringOK(&peering), nil
+			},
+			createPeering: func(params *network_service.CreatePeeringParams) (*network_service.CreatePeeringOK, error) {
+				created = params.Body.Peering
+				created.Hvn.Location = loc
+				return &network_service.CreatePeeringOK{
+					Payload: &networkmodels.HashicorpCloudNetwork20200907CreatePeeringResponse{Peering: created},
+				}, nil
+			},
+			deletePeering: func(params *network_service.DeletePeeringParams) (*network_service.DeletePeeringOK, error) {
+				deleted = append(deleted, "peering/"+params.ID)
+				return &network_service.DeletePeeringOK{
+					Payload: &networkmodels.HashicorpCloudNetwork20200907DeletePeeringResponse{
+						Operation: &sharedmodels.HashicorpCloudOperationOperation{ID: "delete-peering"},
+					},
+				}, nil
+			},
+			createHVNRoute: func(params *network_service.CreateHVNRouteParams) (*network_service.CreateHVNRouteOK, error) {
+				if routeIDs[params.Body.ID] {
+					return nil, network_service.NewCreateHVNRouteDefault(http.StatusConflict)
+				}
+				routeIDs[params.Body.ID] = true
+
+				route = &networkmodels.HashicorpCloudNetwork20200907HVNRoute{
+					ID:          params.Body.ID,
+					Destination: params.Body.Destination,
+					Target:      params.Body.Target,
+				}
+				return &network_service.CreateHVNRouteOK{
+					Payload: &networkmodels.HashicorpCloudNetwork20200907CreateHVNRouteResponse{
+						Route:     route,
+						Operation: &sharedmodels.HashicorpCloudOperationOperation{ID: "create-route"},
+					},
+				}, nil
+			},
+			getHVNRoute: func(params *network_service.GetHVNRouteParams) (*network_service.GetHVNRouteOK, error) {
+				r.Equal("test-peering-auto", params.ID)
+				return &network_service.GetHVNRouteOK{
+					Payload: &networkmodels.HashicorpCloudNetwork20200907GetHVNRouteResponse{Route: route},
+				}, nil
+			},
+			deleteHVNRoute: func(params *network_service.DeleteHVNRouteParams) (*network_service.DeleteHVNRouteOK, error) {
+				deleted = append(deleted, "route/"+params.ID)
+				return &network_service.DeleteHVNRouteOK{
+					Payload: &networkmodels.HashicorpCloudNetwork20200907DeleteHVNRouteResponse{
+						Operation: &sharedmodels.HashicorpCloudOperationOperation{ID: "delete-route"},
+					},
+				}, nil
+			},
+		},
+		Operation: &testOperationClient{},
+	}
+
+	config := map[string]interface{}{
+		"hvn_link":                    testHvnLink("test-hvn"),
+		"peering_id":                  "test-peering",
+		"peer_subscription_id":        "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b",
+		"peer_tenant_id":              "0d7b3c1e-8a4f-4e6d-b2c9-5f1a3e7d9c2b",
+		"peer_resource_group_name":    "test-rg",
+		"peer_vnet_name":              "test-vnet",
+		"peer_vnet_region":            "eastus",
+		"auto_route_destination_cidr": "10.0.0.0/16",
+	}
+
+	// The route isn't created while the peering connection is pending
+	// acceptance.
+	res := resourceAzurePeeringConnection()
+	d := schema.TestResourceDataRaw(t, res.Schema, config)
+	diags := res.CreateContext(ctx, d, client)
+	r.False(diags.HasError(), "%v", diags)
+	r.Nil(route)
+	r.Empty(d.Get("auto_route_id"))
+
+	diags = res.ReadContext(ctx, d, client)
+	r.False(diags.HasError(), "%v", diags)
+	diff, err := res.Diff(ctx, d.State(), sdkterraform.NewResourceConfigRaw(config), client)
+	r.NoError(err)
+	r.Nil(diff)
+
+	// Once the peering connection is active, the next apply creates it, with
+	// an ID that doesn't collide with the route named after the peering
+	// connection.
+	state = networkmodels.HashicorpCloudNetwork20200907PeeringStateACTIVE
+	diags = res.ReadContext(ctx, d, client)
+	r.False(diags.HasError(), "%v", diags)
+
+	diff, err = res.Diff(ctx, d.State(), sdkterraform.NewResourceConfigRaw(config), client)
+	r.NoError(err)
+	r.NotNil(diff)
+	r.False(diff.RequiresNew())
+	r.True(diff.Attributes["auto_route_id"].NewComputed)
+
+	newState, diags := res.Apply(ctx, d.State(), diff, client)
+	r.False(diags.HasError(), "%v", diags)
+	r.Equal("test-peering-auto", newState.Attributes["auto_route_id"])
+	r.NotNil(route)
+	r.Equal("10.0.0.0/16", route.Destination)
+	r.Equal("test-peering", route.Target.HvnConnection.ID)
+	r.Equal(PeeringResourceType, route.Target.HvnConnection.Type)
+
+	// The route is deleted before the peering connection, even though
+	// cascade_delete isn't set.
+	d = res.Data(newState)
+	diags = res.ReadContext(ctx, d, client)
+	r.False(diags.HasError(), "%v", diags)
+	r.Equal("10.0.0.0/16", d.Get("auto_route_destination_cidr"))
+
+	diags = res.DeleteContext(ctx, d, client)
+	r.False(diags.HasError(), "%v", diags)
+	r.Equal([]string{"route/test-peering-auto", "peering/test-peering"}, deleted)
+}
+
+func Test_resourceAzurePeeringConnection_waitForDeletion(t *testing.T) {
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: testOrganizationID,
+		ProjectID:      testProjectID,
+		Region:         &sharedmodels.HashicorpCloudLocationRegion{Provider: "azure", Region: "eastus"},
+	}
+
+	tcs := map[string]struct {
+		waitForDeletion bool
+		expectedError   string
+	}{
+		"recreate after waiting": {
+			waitForDeletion: true,
+		},
+		"recreate without waiting": {
+			waitForDeletion: false,
+			expectedError:   "already exists",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+			ctx := context.Background()
+
+			// Deleted peering connections are reported as DELETING for a few
+			// polls after the delete operation completes, during which a
+			// peering connection with the same ID can't be created.
+			var (
+				peering      *networkmodels.HashicorpCloudNetwork20200907Peering
+				deletingGets int
+			)
+			client := &clients.Client{
+				Config: clients.ClientConfig{OrganizationID: testOrganizationID, PollInterval: time.Millisecond},
+				Network: &testNetworkClient{
+					get: func(*network_service.GetParams) (*network_service.GetOK, error) {
+						return &network_service.GetOK{
+							Payload: &networkmodels.HashicorpCloudNetwork20200907GetResponse{
+								Network: &networkmodels.HashicorpCloudNetwork20200907Network{ID: "test-hvn", CidrBlock: "172.25.16.0/20", Location: loc},
+							},
+						}, nil
+					},
+					getPeering: func(*network_service.GetPeeringParams) (*network_service.GetPeeringOK, error) {
+						if peering == nil {
+							return nil, network_service.NewGetPeeringDefault(http.StatusNotFound)
+						}
+						if *peering.State == networkmodels.HashicorpCloudNetwork20200907PeeringStateDELETING {
+							if deletingGets++; deletingGets > 2 {
+								peering = nil
+								return nil, network_service.NewGetPeeringDefault(http.StatusNotFound)
+							}
+						}
+						p := *peering
+						return testPeeringOK(&p), nil
+					},
+					createPeering: func(params *network_service.CreatePeeringParams) (*network_service.CreatePeeringOK, error) {
+						if peering != nil {
+							return nil, network_service.NewCreatePeeringDefault(http.StatusConflict)
+						}
+						peering = params.Body.Peering
+						peering.Hvn.Location = loc
+						peering.State = networkmodels.HashicorpCloudNetwork20200907PeeringStatePENDINGACCEPTANCE.Pointer()
+						return &network_service.CreatePeeringOK{
+							Payload: &networkmodels.HashicorpCloudNetwork20200907CreatePeeringResponse{Peering: peering},
+						}, nil
+					},
+					deletePeering: func(*network_service.DeletePeeringParams) (*network_service.DeletePeeringOK, error) {
+						peering.State = networkmodels.HashicorpCloudNetwork20200907PeeringStateDELETING.Pointer()
+						return &network_service.DeletePeeringOK{
+							Payload: &networkmodels.HashicorpCloudNetwork20200907DeletePeeringResponse{
+								Operation: &sharedmodels.HashicorpCloudOperationOperation{ID: "delete-peering"},
+							},
+						}, nil
+					},
+					listPeerings: func(*network_service.ListPeeringsParams) (*network_service.ListPeeringsOK, error) {
+						payload := &networkmodels.HashicorpCloudNetwork20200907ListPeeringsResponse{}
+						if peering != nil {
+							payload.Peerings = append(payload.Peerings, peering)
+						}
+						return &network_service.ListPeeringsOK{Payload: payload}, nil
+					},
+				},
+				Operation: &testOperationClient{},
+			}
+
+			config := map[string]interface{}{
+				"hvn_link":                 testHvnLink("test-hvn"),
+				"peering_id":               "test-peering",
+				"peer_subscription_id":     "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b",
+				"peer_tenant_id":           "0d7b3c1e-8a4f-4e6d-b2c9-5f1a3e7d9c2b",
+				"peer_resource_group_name": "test-rg",
+				"peer_vnet_name":           "test-vnet",
+				"peer_vnet_region":         "eastus",
+				"wait_for_deletion":        tc.waitForDeletion,
+			}
+
+			res := resourceAzurePeeringConnection()
+			d := schema.TestResourceDataRaw(t, res.Schema, config)
+			diags := res.CreateContext(ctx, d, client)
+			r.False(diags.HasError(), "%v", diags)
+
+			// The peering connection was accepted, so it's deleted rather than
+			// canceled.
+			peering.State = networkmodels.HashicorpCloudNetwork20200907PeeringStateACTIVE.Pointer()
+			r.NoError(d.Set("state", clients.PeeringStateActive))
+			diags = res.DeleteContext(ctx, d, client)
+			r.False(diags.HasError(), "%v", diags)
+
+			// The peering connection is recreated with the same ID, e.g. because
+			// it was replaced.
+			d = schema.TestResourceDataRaw(t, res.Schema, config)
+			diags = res.CreateContext(ctx, d, client)
+			if tc.expectedError != "" {
+				r.True(diags.HasError())
+				r.Contains(diags[0].Summary, tc.expectedError)
+				return
+			}
+			r.False(diags.HasError(), "%v", diags)
+			r.Equal(3, deletingGets)
+			r.Equal(clients.PeeringStatePendingAcceptance, d.Get("state"))
+		})
+	}
+}
+
+func Test_resourceAzurePeeringConnectionDelete_alreadyDeleted(t *testing.T) {
+	tcs := map[string]struct {
+		lastState string
+	}{
+		"active": {
+			lastState: clients.PeeringStateActive,
+		},
+		"pending acceptance": {
+			lastState: clients.PeeringStatePendingAcceptance,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			// The peering connection was deleted outside of Terraform.
+			client := &clients.Client{
+				Network: &testNetworkClient{
+					getPeering: func(*network_service.GetPeeringParams) (*network_service.GetPeeringOK, error) {
+						return nil, network_service.NewGetPeeringDefault(http.StatusNotFound)
+					},
+					deletePeering: func(*network_service.DeletePeeringParams) (*network_service.DeletePeeringOK, error) {
+						return nil, network_service.NewDeletePeeringDefault(http.StatusNotFound)
+					},
+				},
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceAzurePeeringConnection().Schema, map[string]interface{}{
+				"hvn_link":   testHvnLink("test-hvn"),
+				"peering_id": "test-peering",
+			})
+			d.SetId(testPeeringLink("test-peering"))
+			r.NoError(d.Set("state", tc.lastState))
+
+			diags := resourceAzurePeeringConnectionDelete(context.Background(), d, client)
+			r.False(diags.HasError(), "unexpected diagnostics: %v", diags)
+			r.Empty(d.Id())
+		})
+	}
+}
+
+func Test_resourceAzurePeeringConnection_expired(t *testing.T) {
+	config := map[string]interface{}{
+		"hvn_link":                 testHvnLink("test-hvn"),
+		"peering_id":               "test-peering",
+		"peer_vnet_name":           "test-vnet",
+		"peer_vnet_region":         "eastus",
+		"peer_tenant_id":           "0d7b3c1e-8a4f-4e6d-b2c9-5f1a3e7d9c2b",
+		"peer_subscription_id":     "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b",
+		"peer_resource_group_name": "test-rg",
+	}
+
+	tcs := map[string]struct {
+		state           networkmodels.HashicorpCloudNetwork20200907PeeringState
+		expectWarning   bool
+		expectReplacing bool
+	}{
+		"pending acceptance": {
+			state:           networkmodels.HashicorpCloudNetwork20200907PeeringStatePENDINGACCEPTANCE,
+			expectWarning:   false,
+			expectReplacing: false,
+		},
+		"expired": {
+			state:           networkmodels.HashicorpCloudNetwork20200907PeeringStateEXPIRED,
+			expectWarning:   true,
+			expectReplacing: true,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			client := &clients.Client{
+				Network: &testNetworkClient{
+					getPeering: func(*network_service.GetPeeringParams) (*network_service.GetPeeringOK, error) {
+						return testPeeringOK(testPeering("test-peering", tc.state, &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{
+							AzureTarget: &networkmodels.HashicorpCloudNetwork20200907AzurePeeringTarget{
+								SubscriptionID:    "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b",
+								ResourceGroupName: "test-rg",
+								VnetName:          "test-vnet",
+								Region:            "eastus",
+								TenantID:          "0d7b3c1e-8a4f-4e6d-b2c9-5f1a3e7d9c2b",
+							},
+						})), nil
+					},
+				},
+			}
+
+			res := resourceAzurePeeringConnection()
+
+			// Refresh the peering connection, as Terraform does before planning.
+			d := schema.TestResourceDataRaw(t, res.Schema, config)
+			d.SetId(testPeeringLink("test-peering"))
+			diags := res.ReadContext(context.Background(), d, client)
+			r.False(diags.HasError())
+			r.Equal(tc.expectWarning, len(diags) == 1 && diags[0].Severity == diag.Warning)
+
+			// Plan the next apply against the refreshed state.
+			diff, err := res.Diff(context.Background(), d.State(), sdkterraform.NewResourceConfigRaw(config), client)
+			r.NoError(err)
+			r.Equal(tc.expectReplacing, diff != nil && diff.RequiresNew())
+		})
+	}
+}
+
+// Test_resourceAzurePeeringConnectionCreate_crossRegion ensures the region of
+// the peer VNet is sent as configured, rather than the region of the HVN, and
+// that reading it back from the API doesn't cause a diff.
+func Test_resourceAzurePeeringConnectionCreate_crossRegion(t *testing.T) {
+	r := require.New(t)
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: testOrganizationID,
+		ProjectID:      testProjectID,
+		Region: &sharedmodels.HashicorpCloudLocationRegion{
+			Provider: "azure",
+			Region:   "eastus",
+		},
+	}
+
+	var created *networkmodels.HashicorpCloudNetwork20200907Peering
+	client := &clients.Client{
+		Config: clients.ClientConfig{OrganizationID: testOrganizationID, PollInterval: time.Millisecond},
+		Network: &testNetworkClient{
+			get: func(*network_service.GetParams) (*network_service.GetOK, error) {
+				return &network_service.GetOK{
+					Payload: &networkmodels.HashicorpCloudNetwork20200907GetResponse{
+						Network: &networkmodels.HashicorpCloudNetwork20200907Network{ID: "test-hvn", Location: loc},
+					},
+				}, nil
+			},
+			getPeering: func(*network_service.GetPeeringParams) (*network_service.GetPeeringOK, error) {
+				if created == nil {
+					return nil, network_service.NewGetPeeringDefault(http.StatusNotFound)
+				}
+
+				// The API reports the programmatic name of the region.
+				peering := *created
+				target := *created.Target.AzureTarget
+				target.Region = "westus"
+				peering.Target = &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{AzureTarget: &target}
+				peering.State = networkmodels.HashicorpCloudNetwork20200907PeeringStatePENDINGACCEPTANCE.Pointer()
+				return testPeeringOK(&peering), nil
+			},
+			createPeering: func(params *network_service.CreatePeeringParams) (*network_service.CreatePeeringOK, error) {
+				created = params.Body.Peering
+				created.Hvn.Location = loc
+				return &network_service.CreatePeeringOK{
+					Payload: &networkmodels.HashicorpCloudNetwork20200907CreatePeeringResponse{Peering: created},
+				}, nil
+			},
+		},
+	}
+
+	config := map[string]interface{}{
+		"hvn_link":                 testHvnLink("test-hvn"),
+		"peering_id":               "test-peering",
+		"peer_subscription_id":     "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b",
+		"peer_tenant_id":           "0d7b3c1e-8a4f-4e6d-b2c9-5f1a3e7d9c2b",
+		"peer_resource_group_name": "test-rg",
+		"peer_vnet_name":           "test-vnet",
+		"peer_vnet_region":         "West US",
+	}
+
+	res := resourceAzurePeeringConnection()
+	d := schema.TestResourceDataRaw(t, res.Schema, config)
+	diags := res.CreateContext(context.Background(), d, client)
+	r.False(diags.HasError(), "%v", diags)
+
+	r.Equal("West US", created.Target.AzureTarget.Region)
+
+	diags = res.ReadContext(context.Background(), d, client)
+	r.False(diags.HasError(), "%v", diags)
+	r.Equal("westus", d.Get("peer_vnet_region"))
+	r.Equal("azure", d.Get("cloud_provider"))
+
+	diff, err := res.Diff(context.Background(), d.State(), sdkterraform.NewResourceConfigRaw(config), client)
+	r.NoError(err)
+	r.Nil(diff)
+}
+
+func Test_resourceAzurePeeringConnectionRead_hubAndSpokeDrift(t *testing.T) {
+	r := require.New(t)
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: testOrganizationID,
+		ProjectID:      testProjectID,
+	}
+
+	var current *networkmodels.HashicorpCloudNetwork20200907Peering
+	client := &clients.Client{
+		Config: clients.ClientConfig{OrganizationID: testOrganizationID, PollInterval: time.Millisecond},
+		Network: &testNetworkClient{
+			get: func(*network_service.GetParams) (*network_service.GetOK, error) {
+				return &network_service.GetOK{
+					Payload: &networkmodels.HashicorpCloudNetwork20200907GetResponse{
+						Network: &networkmodels.HashicorpCloudNetwork20200907Network{ID: "test-hvn", Location: loc},
+					},
+				}, nil
+			},
+			getPeering: func(*network_service.GetPeeringParams) (*network_service.GetPeeringOK, error) {
+				if current == nil {
+					return nil, network_service.NewGetPeeringDefault(http.StatusNotFound)
+				}
+
+				peering := *current
+				target := *current.Target.AzureTarget
+				peering.Target = &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{AzureTarget: &target}
+				peering.State = networkmodels.HashicorpCloudNetwork20200907PeeringStatePENDINGACCEPTANCE.Pointer()
+				return testPeeringOK(&peering), nil
+			},
+			createPeering: func(params *network_service.CreatePeeringParams) (*network_service.CreatePeeringOK, error) {
+				current = params.Body.Peering
+				current.Hvn.Location = loc
+				return &network_service.CreatePeeringOK{
+					Payload: &networkmodels.HashicorpCloudNetwork20200907CreatePeeringResponse{Peering: current},
+				}, nil
+			},
+		},
+	}
+
+	config := map[string]interface{}{
+		"hvn_link":                 testHvnLink("test-hvn"),
+		"peering_id":               "test-peering",
+		"peer_subscription_id":     "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b",
+		"peer_tenant_id":           "0d7b3c1e-8a4f-4e6d-b2c9-5f1a3e7d9c2b",
+		"peer_resource_group_name": "test-rg",
+		"peer_vnet_name":           "test-vnet",
+		"peer_vnet_region":         "eastus",
+		"allow_forwarded_traffic":  true,
+		"use_remote_gateways":      true,
+	}
+
+	res := resourceAzurePeeringConnection()
+	d := schema.TestResourceDataRaw(t, res.Schema, config)
+	diags := res.CreateContext(context.Background(), d, client)
+	r.False(diags.HasError(), "%v", diags)
+
+	// Refreshing an unchanged peering connection doesn't produce a diff.
+	diags = res.ReadContext(context.Background(), d, client)
+	r.False(diags.HasError(), "%v", diags)
+	r.True(d.Get("allow_forwarded_traffic").(bool))
+	r.True(d.Get("use_remote_gateways").(bool))
+
+	diff, err := res.Diff(context.Background(), d.State(), sdkterraform.NewResourceConfigRaw(config), client)
+	r.NoError(err)
+	r.Nil(diff)
+
+	// Forwarded traffic is disallowed outside of Terraform.
+	current.Target.AzureTarget.AllowForwardedTraffic = false
+
+	diags = res.ReadContext(context.Background(), d, client)
+	r.False(diags.HasError(), "%v", diags)
+	r.False(d.Get("allow_forwarded_traffic").(bool))
+	r.True(d.Get("use_remote_gateways").(bool))
+
+	diff, err = res.Diff(context.Background(), d.State(), sdkterraform.NewResourceConfigRaw(config), client)
+	r.NoError(err)
+	r.NotNil(diff)
+	r.Contains(diff.Attributes, "allow_forwarded_traffic")
+	r.Equal("true", diff.Attributes["allow_forwarded_traffic"].New)
+	r.True(diff.Attributes["allow_forwarded_traffic"].RequiresNew)
+	r.True(diff.RequiresNew())
+}
+
+func Test_resourceAzurePeeringConnection_peerIdentity(t *testing.T) {
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: testOrganizationID,
+		ProjectID:      testProjectID,
+	}
+
+	tcs := map[string]struct {
+		attribute string
+		value     string
+		drift     func(target *networkmodels.HashicorpCloudNetwork20200907AzurePeeringTarget, value string)
+	}{
+		"peer_subscription_id": {
+			attribute: "peer_subscription_id",
+			value:     "7c1d2e3f-4a5b-4c6d-8e9f-0a1b2c3d4e5f",
+			drift: func(target *networkmodels.HashicorpCloudNetwork20200907AzurePeeringTarget, value string) {
+				target.SubscriptionID = value
+			},
+		},
+		"peer_tenant_id": {
+			attribute: "peer_tenant_id",
+			value:     "9e8d7c6b-5a4f-4e3d-a2c1-b0f9e8d7c6b5",
+			drift: func(target *networkmodels.HashicorpCloudNetwork20200907AzurePeeringTarget, value string) {
+				target.TenantID = value
+			},
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			var current *networkmodels.HashicorpCloudNetwork20200907Peering
+			client := &clients.Client{
+				Config: clients.ClientConfig{OrganizationID: testOrganizationID, PollInterval: time.Millisecond},
+				Network: &testNetworkClient{
+					get: func(*network_service.GetParams) (*network_service.GetOK, error) {
+						return &network_service.GetOK{
+							Payload: &networkmodels.HashicorpCloudNetwork20200907GetResponse{
+								Network: &networkmodels.HashicorpCloudNetwork20200907Network{ID: "test-hvn", Location: loc},
+							},
+						}, nil
+					},
+					getPeering: func(*network_service.GetPeeringParams) (*network_service.GetPeeringOK, error) {
+						if current == nil {
+							return nil, network_service.NewGetPeeringDefault(http.StatusNotFound)
+						}
+
+						peering := *current
+						target := *current.Target.AzureTarget
+						peering.Target = &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{AzureTarget: &target}
+						peering.State = networkmodels.HashicorpCloudNetwork20200907PeeringStatePENDINGACCEPTANCE.Pointer()
+						return testPeeringOK(&peering), nil
+					},
+					createPeering: func(params *network_service.CreatePeeringParams) (*network_service.CreatePeeringOK, error) {
+						current = params.Body.Peering
+						current.Hvn.Location = loc
+						return &network_service.CreatePeeringOK{
+							Payload: &networkmodels.HashicorpCloudNetwork20200907CreatePeeringResponse{Peering: current},
+						}, nil
+					},
+				},
+			}
+
+			config := map[string]interface{}{
+				"hvn_link":                 testHvnLink("test-hvn"),
+				"peering_id":               "test-peering",
+				"peer_subscription_id":     "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b",
+				"peer_tenant_id":           "0d7b3c1e-8a4f-4e6d-b2c9-5f1a3e7d9c2b",
+				"peer_resource_group_name": "test-rg",
+				"peer_vnet_name":           "test-vnet",
+				"peer_vnet_region":         "eastus",
+			}
+
+			res := resourceAzurePeeringConnection()
+			d := schema.TestResourceDataRaw(t, res.Schema, config)
+			diags := res.CreateContext(context.Background(), d, client)
+			r.False(diags.HasError(), "%v", diags)
+
+			// Changing the field in the config replaces the peering connection.
+			changed := make(map[string]interface{}, len(config))
+			for k, v := range config {
+				changed[k] = v
+			}
+			changed[tc.attribute] = tc.value
+
+			diff, err := res.Diff(context.Background(), d.State(), sdkterraform.NewResourceConfigRaw(changed), client)
+			r.NoError(err)
+			r.NotNil(diff)
+			r.Equal(tc.value, diff.Attributes[tc.attribute].New)
+			r.True(diff.Attributes[tc.attribute].RequiresNew)
+			r.True(diff.RequiresNew())
+
+			// The field is refreshed when it no longer matches the peering
+			// connection, which is then replaced to match the config again.
+			tc.drift(current.Target.AzureTarget, tc.value)
+
+			diags = res.ReadContext(context.Background(), d, client)
+			r.False(diags.HasError(), "%v", diags)
+			r.Equal(tc.value, d.Get(tc.attribute))
+
+			diff, err = res.Diff(context.Background(), d.State(), sdkterraform.NewResourceConfigRaw(config), client)
+			r.NoError(err)
+			r.NotNil(diff)
+			r.Equal(config[tc.attribute], diff.Attributes[tc.attribute].New)
+			r.True(diff.Attributes[tc.attribute].RequiresNew)
+			r.True(diff.RequiresNew())
+		})
+	}
+}
+
+func Test_resourceAzurePeeringConnectionImport_hvnID(t *testing.T) {
+	azurePeering := func(id string) *networkmodels.HashicorpCloudNetwork20200907Peering {
+		return &networkmodels.HashicorpCloudNetwork20200907Peering{
+			ID: id,
+			Target: &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{
+				AzureTarget: &networkmodels.HashicorpCloudNetwork20200907AzurePeeringTarget{VnetName: "test-vnet"},
+			},
+		}
+	}
+
+	tcs := map[string]struct {
+		pages         [][]*networkmodels.HashicorpCloudNetwork20200907Peering
+		listErr       error
+		expectedError string
+	}{
+		"suggests the azure peering connections of the hvn": {
+			pages: [][]*networkmodels.HashicorpCloudNetwork20200907Peering{
+				{azurePeering("peering-b"), {
+					ID: "aws-peering",
+					Target: &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{
+						AwsTarget: &networkmodels.HashicorpCloudNetwork20200907AWSPeeringTarget{AccountID: "123456789012"},
+					},
+				}},
+				{azurePeering("peering-a")},
+			},
+			expectedError: "HVN (test-hvn) has the following Azure peering connections, import each of them with its ID:\n  test-hvn:peering-a\n  test-hvn:peering-b",
+		},
+		"hvn without azure peering connections": {
+			pages:         [][]*networkmodels.HashicorpCloudNetwork20200907Peering{{}},
+			expectedError: "HVN (test-hvn) has no Azure peering connections",
+		},
+		"hvn not found": {
+			listErr:       network_service.NewListPeeringsDefault(http.StatusNotFound),
+			expectedError: "no HVN with ID (test-hvn) found in project (e20ad934-b88a-4897-a58e-d8318dd43cc3)",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			client := &clients.Client{
+				Config: clients.ClientConfig{
+					OrganizationID: testOrganizationID,
+					ProjectID:      testProjectID,
+				},
+				Network: &testNetworkClient{
+					listPeerings: func(params *network_service.ListPeeringsParams) (*network_service.ListPeeringsOK, error) {
+						r.Equal("test-hvn", params.HvnID)
+						r.Equal(testOrganizationID, params.LocationOrganizationID)
+						r.Equal(testProjectID, params.LocationProjectID)
+						if tc.listErr != nil {
+							return nil, tc.listErr
+						}
+
+						page := 0
+						if params.PaginationNextPageToken != nil {
+							_, err := fmt.Sscanf(*params.PaginationNextPageToken, "page-%d", &page)
+							r.NoError(err)
+						}
+
+						payload := &networkmodels.HashicorpCloudNetwork20200907ListPeeringsResponse{Peerings: tc.pages[page]}
+						if page+1 < len(tc.pages) {
+							payload.Pagination = &sharedmodels.HashicorpCloudCommonPaginationResponse{NextPageToken: fmt.Sprintf("page-%d", page+1)}
+						}
+						return &network_service.ListPeeringsOK{Payload: payload}, nil
+					},
+				},
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceAzurePeeringConnection().Schema, map[string]interface{}{})
+			d.SetId("test-hvn")
+
+			_, err := resourceAzurePeeringConnectionImport(context.Background(), d, client)
+			r.ErrorContains(err, tc.expectedError)
+			r.ErrorContains(err, "expected {hvn_id}:{peering_id} or {project_id}:{hvn_id}:{peering_id}")
+		})
+	}
+}
+
+func Test_azurePeeringPermissionDiag(t *testing.T) {
+	createPeeringErr := func(code int, grpcCode codes.Code, message string) error {
+		err := network_service.NewCreatePeeringDefault(code)
+		err.Payload = &sharedmodels.GrpcGatewayRuntimeError{Code: int32(grpcCode), Message: message}
+		return err
+	}
+
+	tcs := map[string]struct {
+		err               error
+		expectedPrincipal string
+	}{
+		"azure authorization failed": {
+			err:               createPeeringErr(http.StatusBadRequest, codes.FailedPrecondition, "unable to peer networks: AuthorizationFailed: The client 'f4b2e8a6-3c1d-4e5f-9a7b-8c6d5e4f3a2b' with object id '0e1d2c3b-4a59-6877-8695-a4b3c2d1e0f9' does not have authorization to perform action 'Microsoft.Network/virtualNetworks/peer/action' over scope '/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet'"),
+			expectedPrincipal: "application (f4b2e8a6-3c1d-4e5f-9a7b-8c6d5e4f3a2b)",
+		},
+		"generic authorization error naming the action": {
+			err:               createPeeringErr(http.StatusForbidden, codes.PermissionDenied, "not authorized to perform Microsoft.Network/virtualNetworks/virtualNetworkPeerings/write"),
+			expectedPrincipal: "the peering connection's `application_id`",
+		},
+		"hcp credentials": {
+			err: createPeeringErr(http.StatusForbidden, codes.PermissionDenied, "principal does not have permission"),
+		},
+		"vnet not found": {
+			err: createPeeringErr(http.StatusNotFound, codes.NotFound, "unable to peer networks: ResourceNotFound: The Resource 'Microsoft.Network/virtualNetworks/vnet' under resource group 'rg' was not found"),
+		},
+		"other error": {
+			err: createPeeringErr(http.StatusBadRequest, codes.InvalidArgument, "invalid VNet name"),
+		},
+		"not an api error": {
+			err: errors.New("connection reset by peer"),
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			diags := azurePeeringPermissionDiag(tc.err, "test-hvn", "test-vnet")
+			if tc.expectedPrincipal == "" {
+				r.Nil(diags)
+				return
+			}
+
+			r.Len(diags, 1)
+			r.Equal(diag.Error, diags[0].Severity)
+			r.Equal("unable to create peering connection between HVN (test-hvn) and peer (test-vnet): HCP isn't authorized to peer with the VNet", diags[0].Summary)
+			r.Contains(diags[0].Detail, tc.expectedPrincipal)
+			for _, action := range clients.AzurePeeringRequiredActions() {
+				r.Contains(diags[0].Detail, action)
+			}
+			r.Contains(diags[0].Detail, tc.err.Error())
+		})
+	}
+}
+
+func Test_resourceAzurePeeringConnection_hvnLinkChange(t *testing.T) {
+	r := require.New(t)
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: testOrganizationID,
+		ProjectID:      testProjectID,
+	}
+
+	// reportedHvnID is the ID of the HVN that the API reports the peering
+	// connection belongs to.
+	reportedHvnID := "test-hvn"
+	client := &clients.Client{
+		Config: clients.ClientConfig{OrganizationID: testOrganizationID},
+		Network: &testNetworkClient{
+			getPeering: func(params *network_service.GetPeeringParams) (*network_service.GetPeeringOK, error) {
+				return &network_service.GetPeeringOK{
+					Payload: &networkmodels.HashicorpCloudNetwork20200907GetPeeringResponse{
+						Peering: &networkmodels.HashicorpCloudNetwork20200907Peering{
+							ID:  params.ID,
+							Hvn: &sharedmodels.HashicorpCloudLocationLink{ID: reportedHvnID, Location: loc},
+							Target: &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{
+								AzureTarget: &networkmodels.HashicorpCloudNetwork20200907AzurePeeringTarget{
+									SubscriptionID:    "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b",
+									TenantID:          "0d7b3c1e-8a4f-4e6d-b2c9-5f1a3e7d9c2b",
+									ResourceGroupName: "test-rg",
+									VnetName:          "test-vnet",
+									Region:            "eastus",
+								},
+							},
+							State: networkmodels.HashicorpCloudNetwork20200907PeeringStateACTIVE.Pointer(),
+						},
+					},
+				}, nil
+			},
+		},
+	}
+
+	config := map[string]interface{}{
+		"hvn_link":                 testHvnLink("test-hvn"),
+		"peering_id":               "test-peering",
+		"peer_subscription_id":     "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b",
+		"peer_tenant_id":           "0d7b3c1e-8a4f-4e6d-b2c9-5f1a3e7d9c2b",
+		"peer_resource_group_name": "test-rg",
+		"peer_vnet_name":           "test-vnet",
+		"peer_vnet_region":         "eastus",
+	}
+
+	res := resourceAzurePeeringConnection()
+	d := schema.TestResourceDataRaw(t, res.Schema, config)
+	d.SetId(testPeeringLink("test-peering"))
+	diags := res.ReadContext(context.Background(), d, client)
+	r.False(diags.HasError(), "%v", diags)
+
+	// Moving the peering connection to another HVN replaces it.
+	moved := map[string]interface{}{}
+	for k, v := range config {
+		moved[k] = v
+	}
+	moved["hvn_link"] = testHvnLink("other-hvn")
+
+	diff, err := res.Diff(context.Background(), d.State(), sdkterraform.NewResourceConfigRaw(moved), client)
+	r.NoError(err)
+	r.True(diff.RequiresNew())
+	r.True(diff.Attributes["hvn_link"].RequiresNew)
+
+	// Read reconciles the HVN of a peering connection that doesn't belong to
+	// the configured HVN anymore, so that it is replaced too.
+	reportedHvnID = "other-hvn"
+	diags = res.ReadContext(context.Background(), d, client)
+	r.False(diags.HasError(), "%v", diags)
+	r.Equal(moved["hvn_link"], d.Get("hvn_link"))
+
+	diff, err = res.Diff(context.Background(), d.State(), sdkterraform.NewResourceConfigRaw(config), client)
+	r.NoError(err)
+	r.True(diff.RequiresNew())
+}
+
+func Test_resourceAzurePeeringConnection_peerVnetRegionChange(t *testing.T) {
+	r := require.New(t)
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: testOrganizationID,
+		ProjectID:      testProjectID,
+	}
+
+	// reportedRegion is the region of the peer VNet that the API reports.
+	reportedRegion := "eastus"
+	client := &clients.Client{
+		Config: clients.ClientConfig{OrganizationID: testOrganizationID},
+		Network: &testNetworkClient{
+			getPeering: func(params *network_service.GetPeeringParams) (*network_service.GetPeeringOK, error) {
+				return &network_service.GetPeeringOK{
+					Payload: &networkmodels.HashicorpCloudNetwork20200907GetPeeringResponse{
+						Peering: &networkmodels.HashicorpCloudNetwork20200907Peering{
+							ID:  params.ID,
+							Hvn: &sharedmodels.HashicorpCloudLocationLink{ID: "test-hvn", Location: loc},
+							Target: &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{
+								AzureTarget: &networkmodels.HashicorpCloudNetwork20200907AzurePeeringTarget{
+									SubscriptionID:    "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b",
+									TenantID:          "0d7b3c1e-8a4f-4e6d-b2c9-5f1a3e7d9c2b",
+									ResourceGroupName: "test-rg",
+									VnetName:          "test-vnet",
+									Region:            reportedRegion,
+								},
+							},
+							State: networkmodels.HashicorpCloudNetwork20200907PeeringStateACTIVE.Pointer(),
+						},
+					},
+				}, nil
+			},
+		},
+	}
+
+	config := func(region string) *sdkterraform.ResourceConfig {
+		return sdkterraform.NewResourceConfigRaw(map[string]interface{}{
+			"hvn_link":                 testHvnLink("test-hvn"),
+			"peering_id":               "test-peering",
+			"peer_subscription_id":     "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b",
+			"peer_tenant_id":           "0d7b3c1e-8a4f-4e6d-b2c9-5f1a3e7d9c2b",
+			"peer_resource_group_name": "test-rg",
+			"peer_vnet_name":           "test-vnet",
+			"peer_vnet_region":         region,
+		})
+	}
+
+	res := resourceAzurePeeringConnection()
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"hvn_link": testHvnLink("test-hvn"),
+	})
+	d.SetId(testPeeringLink("test-peering"))
+	diags := res.ReadContext(context.Background(), d, client)
+	r.False(diags.HasError(), "%v", diags)
+	r.Equal("eastus", d.Get("peer_vnet_region"))
+
+	// The display name of the same region doesn't change anything.
+	diff, err := res.Diff(context.Background(), d.State(), config("East US"), client)
+	r.NoError(err)
+	r.Nil(diff)
+
+	// Changing the region replaces the peering connection.
+	diff, err = res.Diff(context.Background(), d.State(), config("westus"), client)
+	r.NoError(err)
+	r.True(diff.RequiresNew())
+	r.True(diff.Attributes["peer_vnet_region"].RequiresNew)
+
+	// Read reconciles a region that changed outside of Terraform, so that the
+	// peering connection is replaced too.
+	reportedRegion = "westus2"
+	diags = res.ReadContext(context.Background(), d, client)
+	r.False(diags.HasError(), "%v", diags)
+	r.Equal("westus2", d.Get("peer_vnet_region"))
+
+	diff, err = res.Diff(context.Background(), d.State(), config("eastus"), client)
+	r.NoError(err)
+	r.True(diff.RequiresNew())
+	r.Equal("eastus", diff.Attributes["peer_vnet_region"].New)
+}
@@ -149,7 +149,7 @@ func resourceConsulCluster() *schema.Resource {
 				Description: `
 The ID of the HCP project where the HCP Consul cluster is located.
 If not specified, the project specified in the HCP Provider config block will be used, if configured.
-If a project is not configured in the HCP Provider config block, the oldest project in the organization will be used.`,
+If a project is not configured in the HCP Provider config block, the organization's only project will be used.`,
 				Type:         schema.TypeString,
 				Optional:     true,
 				ForceNew:     true,
@@ -287,7 +287,7 @@ func resourceConsulClusterCreate(ctx context.Context, d *schema.ResourceData, me
 	}
 
 	// Use the hvn to get provider and region.
-	hvn, err := clients.GetHvnByID(ctx, client, loc, hvnID)
+	hvn, err := clients.GetHvnByIDCached(ctx, client, loc, hvnID)
 	if err != nil {
 		return diag.Errorf("unable to find existing HVN (%s): %v", hvnID, err)
 	}
@@ -5,6 +5,7 @@ package providersdkv2
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
@@ -23,6 +24,12 @@ var hvnDefaultTimeout = time.Minute * 1
 var hvnCreateTimeout = time.Minute * 10
 var hvnDeleteTimeout = time.Minute * 10
 
+// hvnStateOnlyDefaults are the default values of the HVN's attributes that only
+// exist in the Terraform state.
+var hvnStateOnlyDefaults = map[string]interface{}{
+	"deletion_protection": false,
+}
+
 var hvnResourceCloudProviders = []string{
 	"aws",
 	// Available to internal users only
@@ -35,7 +42,9 @@ func resourceHvn() *schema.Resource {
 
 		CreateContext: resourceHvnCreate,
 		ReadContext:   resourceHvnRead,
+		UpdateContext: resourceStateOnlyUpdate,
 		DeleteContext: resourceHvnDelete,
+		CustomizeDiff: resourceHvnCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Default: &hvnDefaultTimeout,
 			Create:  &hvnCreateTimeout,
@@ -75,10 +84,9 @@ func resourceHvn() *schema.Resource {
 			},
 			// Optional inputs
 			"cidr_block": {
-				Description:      "The CIDR range of the HVN. If this is not provided, the service will provide a default value.",
+				Description:      "The CIDR range of the HVN. Its prefix length must be between `/16` and `/25` for AWS HVNs, and between `/16` and `/24` for Azure HVNs. If this is not provided, the service will allocate a default CIDR range, which is then read into state. HCP doesn't support changing the CIDR range of an existing HVN, so changing it replaces the HVN.",
 				Type:             schema.TypeString,
 				Optional:         true,
-				ForceNew:         true,
 				ValidateDiagFunc: validateCIDRBlockHVN,
 				Computed:         true,
 			},
@@ -86,13 +94,14 @@ func resourceHvn() *schema.Resource {
 				Description: `
 The ID of the HCP project where the HVN is located.
 If not specified, the project specified in the HCP Provider config block will be used, if configured.
-If a project is not configured in the HCP Provider config block, the oldest project in the organization will be used.`,
+If a project is not configured in the HCP Provider config block, the organization's only project will be used.`,
 				Type:         schema.TypeString,
 				Optional:     true,
 				ForceNew:     true,
 				ValidateFunc: validation.IsUUID,
 				Computed:     true,
 			},
+			"deletion_protection": deletionProtectionSchema("HVN"),
 			// Computed outputs
 			"organization_id": {
 				Description: "The ID of the HCP organization where the HVN is located.",
@@ -104,6 +113,8 @@ If a project is not configured in the HCP Provider config block, the oldest proj
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"aws_config":   hvnAwsConfigSchema(),
+			"azure_config": hvnAzureConfigSchema(),
 			"created_at": {
 				Description: "The time that the HVN was created.",
 				Type:        schema.TypeString,
@@ -167,9 +178,9 @@ func resourceHvnCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 	createNetworkParams.NetworkLocationOrganizationID = loc.OrganizationID
 	createNetworkParams.NetworkLocationProjectID = loc.ProjectID
 	log.Printf("[INFO] Creating HVN (%s)", hvnID)
-	createNetworkResponse, err := client.Network.Create(createNetworkParams, nil)
+	createNetworkResponse, err := clients.CreateHvnWithRetry(ctx, client, createNetworkParams, d.Timeout(schema.TimeoutCreate))
 	if err != nil {
-		return diag.Errorf("unable to create HVN (%s): %v", hvnID, err)
+		return apiErrorDiag(err, "unable to create HVN (%s)", hvnID)
 	}
 
 	link := newLink(loc, HvnResourceType, hvnID)
@@ -181,7 +192,7 @@ func resourceHvnCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 
 	// Wait for HVN to be created
 	if err := clients.WaitForOperation(ctx, client, "create HVN", loc, createNetworkResponse.Payload.Operation.ID); err != nil {
-		return diag.Errorf("unable to create HVN (%s): %v", createNetworkResponse.Payload.Network.ID, err)
+		return apiErrorDiag(err, "unable to create HVN (%s)", createNetworkResponse.Payload.Network.ID)
 	}
 
 	log.Printf("[INFO] Created HVN (%s)", createNetworkResponse.Payload.Network.ID)
@@ -189,7 +200,7 @@ func resourceHvnCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 	// Get the updated HVN
 	hvn, err := clients.GetHvnByID(ctx, client, loc, createNetworkResponse.Payload.Network.ID)
 	if err != nil {
-		return diag.Errorf("unable to retrieve HVN (%s): %v", createNetworkResponse.Payload.Network.ID, err)
+		return apiErrorDiag(err, "unable to retrieve HVN (%s)", createNetworkResponse.Payload.Network.ID)
 	}
 
 	if err := setHvnResourceData(d, hvn); err != nil {
@@ -199,6 +210,39 @@ func resourceHvnCreate(ctx context.Context, d *schema.ResourceData, meta interfa
 	return nil
 }
 
+// resourceHvnCustomizeDiff validates the prefix length of the HVN's CIDR block
+// for its cloud provider, which the cidr_block validation can't access.
+//
+// It also replaces the HVN if its CIDR block changes and can't be updated in
+// place. If deletion protection is enabled, the HVN can't be replaced either,
+// so the plan fails with the reason instead of failing when the replacement
+// deletes the HVN.
+func resourceHvnCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.NewValueKnown("cidr_block") && d.NewValueKnown("cloud_provider") {
+		if err := validateHvnCidrBlockPrefixLength(d.Get("cidr_block").(string), d.Get("cloud_provider").(string)); err != nil {
+			return err
+		}
+	}
+
+	if d.Id() == "" || !d.HasChange("cidr_block") {
+		return nil
+	}
+
+	oldCidrBlock, newCidrBlock := d.GetChange("cidr_block")
+	err := clients.CheckHvnCidrBlockUpdate(d.Get("hvn_id").(string), oldCidrBlock.(string), newCidrBlock.(string))
+	if err == nil {
+		return nil
+	}
+
+	// The deletion protection of the existing HVN is what prevents the
+	// replacement from deleting it.
+	if deletionProtection, _ := d.GetChange("deletion_protection"); deletionProtection.(bool) {
+		return fmt.Errorf("%v, which deletion protection prevents. Revert the CIDR block, or set deletion_protection to false and apply the configuration before changing it", err)
+	}
+
+	return d.ForceNew("cidr_block")
+}
+
 func resourceHvnRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*clients.Client)
 
@@ -219,7 +263,7 @@ func resourceHvnRead(ctx context.Context, d *schema.ResourceData, meta interface
 			return nil
 		}
 
-		return diag.Errorf("unable to retrieve HVN (%s): %v", hvnID, err)
+		return apiErrorDiag(err, "unable to retrieve HVN (%s)", hvnID)
 	}
 
 	// The HVN has already been deleted, remove from state.
@@ -229,6 +273,10 @@ func resourceHvnRead(ctx context.Context, d *schema.ResourceData, meta interface
 		return nil
 	}
 
+	if err := setStateOnlyDefaults(d, hvnStateOnlyDefaults); err != nil {
+		return diag.FromErr(err)
+	}
+
 	// HVN found, update resource data
 	if err := setHvnResourceData(d, hvn); err != nil {
 		return diag.FromErr(err)
@@ -248,6 +296,10 @@ func resourceHvnDelete(ctx context.Context, d *schema.ResourceData, meta interfa
 	hvnID := link.ID
 	loc := link.Location
 
+	if diags := checkDeletionProtection(d, "HVN", hvnID); diags != nil {
+		return diags
+	}
+
 	deleteParams := network_service.NewDeleteParams()
 	deleteParams.Context = ctx
 	deleteParams.ID = hvnID
@@ -258,16 +310,19 @@ func resourceHvnDelete(ctx context.Context, d *schema.ResourceData, meta interfa
 	if err != nil {
 		if clients.IsResponseCodeNotFound(err) {
 			log.Printf("[WARN] HVN (%s) not found, so no action was taken", hvnID)
+			clients.InvalidateHvn(client, loc, hvnID)
+			d.SetId("")
 			return nil
 		}
 
-		return diag.Errorf("unable to delete HVN (%s): %v", hvnID, err)
+		return apiErrorDiag(err, "unable to delete HVN (%s)", hvnID)
 	}
 
 	// Wait for delete hvn operation
 	if err := clients.WaitForOperation(ctx, client, "delete HVN", loc, deleteResponse.Payload.Operation.ID); err != nil {
-		return diag.Errorf("unable to delete HVN (%s): %v", hvnID, err)
+		return apiErrorDiag(err, "unable to delete HVN (%s)", hvnID)
 	}
+	clients.InvalidateHvn(client, loc, hvnID)
 
 	log.Printf("[INFO] HVN (%s) deleted, removing from state", hvnID)
 
@@ -303,7 +358,9 @@ func setHvnResourceData(d *schema.ResourceData, hvn *networkmodels.HashicorpClou
 	var providerAccountID string
 	switch d.Get("cloud_provider") {
 	case "aws":
-		providerAccountID = hvn.ProviderNetworkData.AwsNetworkData.AccountID
+		if hvn.ProviderNetworkData != nil && hvn.ProviderNetworkData.AwsNetworkData != nil {
+			providerAccountID = hvn.ProviderNetworkData.AwsNetworkData.AccountID
+		}
 	case "azure":
 		// No equivalent field exposed in Azure HVNs at this time
 		providerAccountID = ""
@@ -311,6 +368,12 @@ func setHvnResourceData(d *schema.ResourceData, hvn *networkmodels.HashicorpClou
 	if err := d.Set("provider_account_id", providerAccountID); err != nil {
 		return err
 	}
+	if err := d.Set("aws_config", hvnAwsConfig(hvn)); err != nil {
+		return err
+	}
+	if err := d.Set("azure_config", hvnAzureConfig(hvn)); err != nil {
+		return err
+	}
 
 	link := newLink(hvn.Location, HvnResourceType, hvn.ID)
 	selfLink, err := linkURL(link)
@@ -323,6 +386,96 @@ func setHvnResourceData(d *schema.ResourceData, hvn *networkmodels.HashicorpClou
 	return nil
 }
 
+// hvnAwsConfigSchema returns the schema of the aws_config attribute of the HVN
+// resource and data source.
+func hvnAwsConfigSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "The AWS specific details of the HVN's network. Only set if `cloud_provider` is `aws`.",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"account_id": {
+					Description: "The ID of the AWS account where the HVN's VPC is located, e.g. to share resources with it.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+// hvnAzureConfigSchema returns the schema of the azure_config attribute of the
+// HVN resource and data source.
+func hvnAzureConfigSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "The Azure specific details of the HVN's network. Only set if `cloud_provider` is `azure`.",
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"network_data": {
+					Description: "The details that HCP reports about the HVN's VNet, keyed by their names in the HCP API. Nested values are JSON encoded. Empty if HCP doesn't report any details.",
+					Type:        schema.TypeMap,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+// hvnAwsConfig returns the value of the aws_config attribute of an HVN, which
+// is only set for AWS HVNs.
+func hvnAwsConfig(hvn *networkmodels.HashicorpCloudNetwork20200907Network) []interface{} {
+	if hvn.Location.Region.Provider != "aws" {
+		return nil
+	}
+
+	var accountID string
+	if hvn.ProviderNetworkData != nil && hvn.ProviderNetworkData.AwsNetworkData != nil {
+		accountID = hvn.ProviderNetworkData.AwsNetworkData.AccountID
+	}
+	return []interface{}{map[string]interface{}{
+		"account_id": accountID,
+	}}
+}
+
+// hvnAzureConfig returns the value of the azure_config attribute of an HVN,
+// which is only set for Azure HVNs. The HCP API doesn't define the Azure
+// network data, so it is exposed as a map of its fields.
+func hvnAzureConfig(hvn *networkmodels.HashicorpCloudNetwork20200907Network) []interface{} {
+	if hvn.Location.Region.Provider != "azure" {
+		return nil
+	}
+
+	networkData := make(map[string]interface{})
+	if hvn.ProviderNetworkData != nil {
+		if fields, ok := hvn.ProviderNetworkData.AzureNetworkData.(map[string]interface{}); ok {
+			for name, value := range fields {
+				switch v := value.(type) {
+				case nil:
+					continue
+				case string:
+					networkData[name] = v
+				case bool, float64, json.Number:
+					networkData[name] = fmt.Sprint(v)
+				default:
+					encoded, err := json.Marshal(v)
+					if err != nil {
+						log.Printf("[WARN] Unable to encode the Azure network data field (%s) of HVN (%s): %v", name, hvn.ID, err)
+						continue
+					}
+					networkData[name] = string(encoded)
+				}
+			}
+		}
+	}
+	return []interface{}{map[string]interface{}{
+		"network_data": networkData,
+	}}
+}
+
 // resourceHvnImport implements the logic necessary to import an un-tracked
 // (by Terraform) HVN resource into Terraform state.
 func resourceHvnImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -331,13 +484,22 @@ func resourceHvnImport(ctx context.Context, d *schema.ResourceData, meta interfa
 	//   terraform import hcp_hvn.test f709ec73-55d4-46d8-897d-816ebba28778:test-hvn
 	// use default project ID from provider:
 	//   terraform import hcp_hvn.test test-hvn
+	// use the HVN's self_link:
+	//   terraform import hcp_hvn.test /project/f709ec73-55d4-46d8-897d-816ebba28778/hashicorp.network.hvn/test-hvn
 
 	client := meta.(*clients.Client)
 	projectID := ""
 	hvnID := ""
 	var err error
 
-	if strings.Contains(d.Id(), ":") { // {project_id}:{hvn_id}
+	if strings.HasPrefix(d.Id(), "/project/") { // {self_link}
+		hvnLink, err := parseLinkURL(d.Id(), HvnResourceType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid import ID: %v", err)
+		}
+		hvnID = hvnLink.ID
+		projectID = hvnLink.Location.ProjectID
+	} else if strings.Contains(d.Id(), ":") { // {project_id}:{hvn_id}
 		idParts := strings.SplitN(d.Id(), ":", 2)
 		hvnID = idParts[1]
 		projectID = idParts[0]
@@ -361,5 +523,9 @@ func resourceHvnImport(ctx context.Context, d *schema.ResourceData, meta interfa
 
 	d.SetId(url)
 
+	if err := d.Set("deletion_protection", false); err != nil {
+		return nil, err
+	}
+
 	return []*schema.ResourceData{d}, nil
 }
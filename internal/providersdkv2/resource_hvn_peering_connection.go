@@ -5,6 +5,7 @@ package providersdkv2
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"strings"
 
@@ -16,11 +17,18 @@ import (
 	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
 )
 
+// hvnPeeringConnectionStateOnlyDefaults are the default values of the peering connection's attributes that only
+// exist in the Terraform state.
+var hvnPeeringConnectionStateOnlyDefaults = map[string]interface{}{
+	"deletion_protection": false,
+}
+
 func resourceHvnPeeringConnection() *schema.Resource {
 	return &schema.Resource{
 		Description:   "The HVN peering connection resource allows you to manage a peering connection between HVNs.",
 		CreateContext: resourceHvnPeeringConnectionCreate,
 		ReadContext:   resourceHvnPeeringConnectionRead,
+		UpdateContext: resourceStateOnlyUpdate,
 		DeleteContext: resourceHvnPeeringConnectionDelete,
 		CustomizeDiff: resourceHvnPeeringConnectionCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
@@ -45,6 +53,15 @@ func resourceHvnPeeringConnection() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
+			// Optional inputs
+			"name_prefix": {
+				Description:      fmt.Sprintf("A prefix for the ID of the peering connection, e.g. to namespace the peering connections of a team. If set, the ID is generated by appending a unique %d character suffix to the prefix, rather than by HCP. It isn't set on import.", peeringIDSuffixLength),
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validatePeeringNamePrefix,
+			},
+			"deletion_protection": deletionProtectionSchema("peering connection"),
 			// Computed outputs
 			"peering_id": {
 				Description: "The ID of the peering connection.",
@@ -77,6 +94,12 @@ Remove this attribute from the configuration for any affected resources.
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"updated_at": {
+				Description: "The time that the peering connection was last updated.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"raw_json": peeringRawJSONSchema("peering connection"),
 			"self_link": {
 				Description: "A unique URL identifying the peering connection",
 				Type:        schema.TypeString,
@@ -132,15 +155,28 @@ func resourceHvnPeeringConnectionCreate(ctx context.Context, d *schema.ResourceD
 		return diag.FromErr(err)
 	}
 
-	hvn2, err := clients.GetHvnByID(ctx, client, hvn2Link.Location, hvn2Link.ID)
+	hvn2, err := clients.GetHvnByIDCached(ctx, client, hvn2Link.Location, hvn2Link.ID)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+
+	if err := clients.CheckHvnNotFailed(hvn2); err != nil {
+		return diag.FromErr(err)
+	}
 	hvn2Link.Location.Region = &sharedmodels.HashicorpCloudLocationRegion{
 		Provider: hvn2.Location.Region.Provider,
 		Region:   hvn2.Location.Region.Region,
 	}
 
+	// HCP generates the peering ID, unless it has to start with name_prefix.
+	var peeringID string
+	if prefix := d.Get("name_prefix").(string); prefix != "" {
+		peeringID, err = generatePeeringID(prefix)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	peerNetworkParams := network_service.NewCreatePeeringParams()
 	peerNetworkParams.Context = ctx
 	peerNetworkParams.PeeringHvnID = hvn1Link.ID
@@ -148,6 +184,7 @@ func resourceHvnPeeringConnectionCreate(ctx context.Context, d *schema.ResourceD
 	peerNetworkParams.PeeringHvnLocationProjectID = hvn1Link.Location.ProjectID
 	peerNetworkParams.Body = &networkmodels.HashicorpCloudNetwork20200907CreatePeeringRequest{
 		Peering: &networkmodels.HashicorpCloudNetwork20200907Peering{
+			ID:  peeringID,
 			Hvn: hvn1Link,
 			Target: &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{
 				HvnTarget: &networkmodels.HashicorpCloudNetwork20200907NetworkTarget{
@@ -159,7 +196,7 @@ func resourceHvnPeeringConnectionCreate(ctx context.Context, d *schema.ResourceD
 	log.Printf("[INFO] Creating peering connection between HVNs (%s), (%s)", hvn1Link.ID, hvn2Link.ID)
 	peeringResponse, err := client.Network.CreatePeering(peerNetworkParams, nil)
 	if err != nil {
-		return diag.Errorf("unable to create peering connection between HVNs (%s) and (%s): %v", hvn1Link.ID, hvn1Link.ID, err)
+		return apiErrorDiag(err, "unable to create peering connection between HVNs (%s) and (%s)", hvn1Link.ID, hvn1Link.ID)
 	}
 
 	peering := peeringResponse.Payload.Peering
@@ -174,21 +211,23 @@ func resourceHvnPeeringConnectionCreate(ctx context.Context, d *schema.ResourceD
 
 	// Wait for peering connection to be created
 	if err := clients.WaitForOperation(ctx, client, "create peering connection", hvn1Link.Location, peeringResponse.Payload.Operation.ID); err != nil {
-		return diag.Errorf("unable to create peering connection (%s) between HVNs (%s) and (%s): %v", peering.ID, peering.Hvn.ID, peering.Target.HvnTarget.Hvn.ID, err)
+		return apiErrorDiag(err, "unable to create peering connection (%s) between HVNs (%s) and (%s)", peering.ID, peering.Hvn.ID, peering.Target.HvnTarget.Hvn.ID)
 	}
 	log.Printf("[INFO] Created peering connection (%s) between HVNs (%s) and (%s)", peering.ID, peering.Hvn.ID, peering.Target.HvnTarget.Hvn.ID)
 
-	peering, err = clients.WaitForPeeringToBeAccepted(ctx, client, peering.ID, hvn1Link.ID, hvn1Link.Location, d.Timeout(schema.TimeoutCreate))
+	var diags diag.Diagnostics
+	peering, err = clients.WaitForPeeringToBeAccepted(ctx, client, peering.ID, hvn1Link.ID, hvn1Link.Location, d.Timeout(schema.TimeoutCreate),
+		peeringStuckCreatingWarning(&diags, "peering connection", peering.ID, hvn1Link.ID))
 	if err != nil {
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
 	log.Printf("[INFO] Peering connection (%s) is now in ACCEPTED state", peering.ID)
 
 	if err := setHvnPeeringResourceData(d, peering); err != nil {
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
 
-	return nil
+	return diags
 }
 
 func resourceHvnPeeringConnectionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -213,7 +252,11 @@ func resourceHvnPeeringConnectionRead(ctx context.Context, d *schema.ResourceDat
 			d.SetId("")
 			return nil
 		}
-		return diag.Errorf("unable to retrieve peering connection (%s): %v", peeringID, err)
+		return apiErrorDiag(err, "unable to retrieve peering connection (%s)", peeringID)
+	}
+
+	if err := setStateOnlyDefaults(d, hvnPeeringConnectionStateOnlyDefaults); err != nil {
+		return diag.FromErr(err)
 	}
 
 	hvn2Link := newLink(peering.Target.HvnTarget.Hvn.Location, HvnResourceType, peering.Target.HvnTarget.Hvn.ID)
@@ -247,6 +290,10 @@ func resourceHvnPeeringConnectionDelete(ctx context.Context, d *schema.ResourceD
 
 	peeringID := peeringLink.ID
 
+	if diags := checkDeletionProtection(d, "peering connection", peeringID); diags != nil {
+		return diags
+	}
+
 	deletePeeringParams := network_service.NewDeletePeeringParams()
 	deletePeeringParams.Context = ctx
 	deletePeeringParams.ID = peeringID
@@ -261,7 +308,7 @@ func resourceHvnPeeringConnectionDelete(ctx context.Context, d *schema.ResourceD
 			log.Printf("[WARN] Peering connection (%s) not found, so no action was taken", peeringID)
 			return nil
 		}
-		return diag.Errorf("unable to delete peering connection (%s): %v", peeringID, err)
+		return apiErrorDiag(err, "unable to delete peering connection (%s)", peeringID)
 	}
 
 	// Wait for peering to be deleted
@@ -269,7 +316,7 @@ func resourceHvnPeeringConnectionDelete(ctx context.Context, d *schema.ResourceD
 		if strings.Contains(err.Error(), "execution already started") {
 			return nil
 		}
-		return diag.Errorf("unable to delete peering connection (%s): %v", peeringID, err)
+		return apiErrorDiag(err, "unable to delete peering connection (%s)", peeringID)
 	}
 
 	log.Printf("[INFO] Peering connection (%s) deleted, removing from state", peeringID)
@@ -284,7 +331,7 @@ func resourceHvnPeeringConnectionImport(ctx context.Context, d *schema.ResourceD
 	//   terraform import hcp_hvn_peering_connection.test {hvn_1_id}:{peering_id}
 
 	client := meta.(*clients.Client)
-	projectID, hvnID, peeringID, err := parsePeeringResourceID(d.Id(), client.Config.ProjectID)
+	projectID, hvnID, peeringID, err := importPeering(ctx, client, d.Id())
 	if err != nil {
 		return nil, err
 	}
@@ -311,6 +358,10 @@ func resourceHvnPeeringConnectionImport(ctx context.Context, d *schema.ResourceD
 		return nil, err
 	}
 
+	if err := d.Set("deletion_protection", false); err != nil {
+		return nil, err
+	}
+
 	return []*schema.ResourceData{d}, nil
 }
 
@@ -330,7 +381,13 @@ func setHvnPeeringResourceData(d *schema.ResourceData, peering *networkmodels.Ha
 	if err := d.Set("expires_at", peering.ExpiresAt.String()); err != nil {
 		return err
 	}
-	if err := d.Set("state", peering.ExpiresAt.String()); err != nil {
+	if err := d.Set("updated_at", peering.UpdatedAt.String()); err != nil {
+		return err
+	}
+	if err := setPeeringRawJSON(d, peering); err != nil {
+		return err
+	}
+	if err := d.Set("state", peering.State); err != nil {
 		return err
 	}
 
@@ -42,6 +42,11 @@ data "hcp_hvn_peering_connection" "test" {
 	peering_id = hcp_hvn_peering_connection.test.peering_id
 	hvn_1      = hcp_hvn_peering_connection.test.hvn_1
 }
+
+data "hcp_hvn" "test_1" {
+	hvn_id     = hcp_hvn.test_1.hvn_id
+	depends_on = [hcp_hvn_peering_connection.test]
+}
 `, hvn1UniqueID, hvn2UniqueID)
 
 // This includes tests against both the resource and the corresponding datasource
@@ -115,6 +120,9 @@ func TestAcc_Platform_HvnPeeringConnection(t *testing.T) {
 					resource.TestCheckResourceAttrPair(resourceName, "state", dataSourceName, "state"),
 					testLink(dataSourceName, "hvn_1", hvn1UniqueID, HvnResourceType, dataSourceName),
 					testLink(dataSourceName, "hvn_2", hvn2UniqueID, HvnResourceType, dataSourceName),
+					// The HVN's peering count includes the new peering connection.
+					resource.TestCheckResourceAttr("data.hcp_hvn.test_1", "peering_count", "1"),
+					resource.TestCheckResourceAttr("data.hcp_hvn.test_1", "peered_account_ids.#", "0"),
 				),
 			},
 		},
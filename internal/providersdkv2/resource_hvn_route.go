@@ -22,11 +22,18 @@ var hvnRouteDefaultTimeout = time.Minute * 1
 var hvnRouteCreateTimeout = time.Minute * 35
 var hvnRouteDeleteTimeout = time.Minute * 25
 
+// hvnRouteStateOnlyDefaults are the default values of the HVN route's
+// attributes that only exist in the Terraform state.
+var hvnRouteStateOnlyDefaults = map[string]interface{}{
+	"allow_default_route": false,
+}
+
 func resourceHvnRoute() *schema.Resource {
 	return &schema.Resource{
 		Description:   "The HVN route resource allows you to manage an HVN route.",
 		CreateContext: resourceHvnRouteCreate,
 		ReadContext:   resourceHvnRouteRead,
+		UpdateContext: resourceStateOnlyUpdate,
 		DeleteContext: resourceHvnRouteDelete,
 		CustomizeDiff: resourceHvnRouteCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
@@ -53,11 +60,11 @@ func resourceHvnRoute() *schema.Resource {
 				ValidateDiagFunc: validateSlugID,
 			},
 			"destination_cidr": {
-				Description:      "The destination CIDR of the HVN route.",
+				Description:      "The destination CIDR of the HVN route. It must not overlap the CIDR block of the HVN. A default route, `0.0.0.0/0`, is only accepted if `allow_default_route` is `true`.",
 				Type:             schema.TypeString,
 				Required:         true,
 				ForceNew:         true,
-				ValidateDiagFunc: validateCIDRBlockHVNRoute,
+				ValidateDiagFunc: validateHvnRouteDestinationCIDR,
 			},
 			"target_link": {
 				Description: "A unique URL identifying the target of the HVN route. Examples of the target: [`aws_network_peering`](aws_network_peering.md), [`aws_transit_gateway_attachment`](aws_transit_gateway_attachment.md)",
@@ -66,6 +73,12 @@ func resourceHvnRoute() *schema.Resource {
 				ForceNew:    true,
 			},
 			// Optional inputs
+			"allow_default_route": {
+				Description: "If `true`, `destination_cidr` may be the default route, `0.0.0.0/0`. A default route sends all the traffic of the HVN that no more specific route matches through the target, which can silently blackhole it, so it must be explicitly allowed. It only exists in the Terraform state, so it can be changed without replacing the HVN route. Defaults to `false`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
 			"azure_config": {
 				Description: "The Azure configuration for routing.",
 				Type:        schema.TypeList,
@@ -126,6 +139,11 @@ Remove this attribute from the configuration for any affected resources.
 }
 
 func resourceHvnRouteCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if destination := d.Get("destination_cidr").(string); isDefaultRouteCIDR(destination) && !d.Get("allow_default_route").(bool) {
+		return fmt.Errorf("destination_cidr (%s) is a default route, which sends all the traffic of the HVN that no more specific route matches through the target, "+
+			"and can silently blackhole it: set allow_default_route to true if this is intended", destination)
+	}
+
 	// Force project_id to match the project_id from hvn_link if it has been manually overridden in configuration
 	// When the project_id attribute's "Optional" property is removed after the deprecation period
 	// ends, CustomizeDiff can be removed.
@@ -169,7 +187,7 @@ func resourceHvnRouteCreate(ctx context.Context, d *schema.ResourceData, meta in
 	}
 
 	// Check for an existing HVN.
-	retrievedHvn, err := clients.GetHvnByID(ctx, client, hvnLink.Location, hvnLink.ID)
+	retrievedHvn, err := clients.GetHvnByIDCached(ctx, client, hvnLink.Location, hvnLink.ID)
 	if err != nil {
 		if clients.IsResponseCodeNotFound(err) {
 			return diag.Errorf("unable to find the HVN (%s) for the HVN route", hvnLink.ID)
@@ -178,6 +196,14 @@ func resourceHvnRouteCreate(ctx context.Context, d *schema.ResourceData, meta in
 		return diag.Errorf("unable to check for presence of an existing HVN (%s): %v", hvnLink.ID, err)
 	}
 
+	if err := clients.CheckHvnNotFailed(retrievedHvn); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := validateHvnRouteDestination(destination, retrievedHvn.CidrBlock); err != nil {
+		return diag.FromErr(err)
+	}
+
 	log.Printf("[INFO] HVN (%s) found, proceeding with HVN route create", hvnLink.ID)
 
 	targetLink.Location.Region = retrievedHvn.Location.Region
@@ -185,6 +211,10 @@ func resourceHvnRouteCreate(ctx context.Context, d *schema.ResourceData, meta in
 	// Create HVN route
 	hvnRouteResp, err := clients.CreateHVNRoute(ctx, client, hvnRouteID, hvnLink, destination, targetLink, hvnLink.Location, azureConfig)
 	if err != nil {
+		if diags := hvnRouteIDInUseDiag(ctx, client, err, hvnRouteID, hvnLink.ID, hvnLink.Location); diags != nil {
+			return diags
+		}
+
 		return diag.FromErr(err)
 	}
 	hvnRoute := hvnRouteResp.Route
@@ -200,7 +230,7 @@ func resourceHvnRouteCreate(ctx context.Context, d *schema.ResourceData, meta in
 
 	// Wait for HVN route to be created.
 	if err := clients.WaitForOperation(ctx, client, "create HVN route", hvnLink.Location, hvnRouteResp.Operation.ID); err != nil {
-		return diag.Errorf("unable to create HVN route (%s): %v", hvnRouteID, err)
+		return apiErrorDiag(err, "unable to create HVN route (%s)", hvnRouteID)
 	}
 
 	log.Printf("[INFO] Created HVN route (%s)", hvnRouteID)
@@ -216,6 +246,32 @@ func resourceHvnRouteCreate(ctx context.Context, d *schema.ResourceData, meta in
 	return nil
 }
 
+// hvnRouteIDInUseDiag returns an error diagnostic if err, returned by a request
+// to create an HVN route, is a conflict caused by another route of the HVN
+// already using hvnRouteID. It returns nil otherwise, so that the caller can
+// report err as is.
+func hvnRouteIDInUseDiag(ctx context.Context, client *clients.Client, err error, hvnRouteID, hvnID string, loc *sharedmodels.HashicorpCloudLocationLocation) diag.Diagnostics {
+	if hvnRouteID == "" || !clients.IsResponseCodeConflict(err) {
+		return nil
+	}
+
+	// Conflicts are also reported for other reasons, e.g. while the HVN is
+	// being updated, so only blame the route ID if it's actually in use.
+	routes, listErr := clients.ListHVNRoutes(ctx, client, hvnID, "", "", "", loc)
+	if listErr != nil {
+		log.Printf("[WARN] Unable to list the HVN routes of HVN (%s): %v", hvnID, listErr)
+		return nil
+	}
+
+	for _, route := range routes {
+		if route.ID == hvnRouteID {
+			return diag.Errorf("hvn_route_id (%s) already in use: another route of HVN (%s) in project (%s) has this ID. Choose a different hvn_route_id, or import the existing HVN route into the state", hvnRouteID, hvnID, loc.ProjectID)
+		}
+	}
+
+	return nil
+}
+
 func resourceHvnRouteRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*clients.Client)
 
@@ -241,7 +297,11 @@ func resourceHvnRouteRead(ctx context.Context, d *schema.ResourceData, meta inte
 			return nil
 		}
 
-		return diag.Errorf("unable to retrieve HVN route (%s): %v", routeLink.ID, err)
+		return apiErrorDiag(err, "unable to retrieve HVN route (%s)", routeLink.ID)
+	}
+
+	if err := setStateOnlyDefaults(d, hvnRouteStateOnlyDefaults); err != nil {
+		return diag.FromErr(err)
 	}
 
 	// HVN route found, update resource data.
@@ -272,14 +332,15 @@ func resourceHvnRouteDelete(ctx context.Context, d *schema.ResourceData, meta in
 	if err != nil {
 		if clients.IsResponseCodeNotFound(err) {
 			log.Printf("[WARN] HVN route (%s) not found, so no action was taken", routeID)
+			d.SetId("")
 			return nil
 		}
 
-		return diag.Errorf("unable to delete HVN route (%s): %v", routeID, err)
+		return apiErrorDiag(err, "unable to delete HVN route (%s)", routeID)
 	}
 
 	if err := clients.WaitForOperation(ctx, client, "delete HVN route", hvnLink.Location, resp.Operation.ID); err != nil {
-		return diag.Errorf("unable to delete HVN route (%s): %v", routeID, err)
+		return apiErrorDiag(err, "unable to delete HVN route (%s)", routeID)
 	}
 
 	log.Printf("[INFO] HVN route (%s) deleted, removing from state", routeID)
@@ -6,12 +6,18 @@ package providersdkv2
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"regexp"
 	"testing"
 
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/client/network_service"
+	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/stretchr/testify/require"
 )
 
 // AWS config
@@ -57,6 +63,12 @@ func testAccHvnRouteConfigAws(resID string) string {
 	  target_link      = data.hcp_aws_network_peering.peering.self_link
 	}
 
+	// Looks the route above up by its destination CIDR.
+	data "hcp_hvn_route" "route" {
+	  hvn_link         = hcp_hvn.test.self_link
+	  destination_cidr = hcp_hvn_route.route.destination_cidr
+	}
+
 	resource "aws_vpc_peering_connection_accepter" "peering-accepter" {
 	  vpc_peering_connection_id = hcp_aws_network_peering.peering.provider_peering_id
 	  auto_accept               = true
@@ -311,6 +323,8 @@ func hvnRouteAzureAdConfig(resID string) string {
 	  application_id = hcp_azure_peering_connection.peering.application_id
 	}
 
+	data "hcp_azure_peering_required_permissions" "required" {}
+
 	resource "azurerm_role_definition" "definition" {
 	  name  = "%[1]s"
 	  scope = azurerm_virtual_network.vnet.id
@@ -320,11 +334,7 @@ func hvnRouteAzureAdConfig(resID string) string {
 	  ]
 
 	  permissions {
-		actions = [
-		  "Microsoft.Network/virtualNetworks/peer/action",
-		  "Microsoft.Network/virtualNetworks/virtualNetworkPeerings/read",
-		  "Microsoft.Network/virtualNetworks/virtualNetworkPeerings/write"
-		]
+		actions = data.hcp_azure_peering_required_permissions.required.actions
 	  }
 	}
 
@@ -412,6 +422,9 @@ func TestAcc_Platform_HvnRouteAws(t *testing.T) {
 					resource.TestCheckNoResourceAttr(resourceName, "azure_config.0"),
 					testLink(resourceName, "self_link", hvnRouteUniqueName, HVNRouteResourceType, "hcp_hvn.test"),
 					testLink(resourceName, "target_link", hvnRouteUniqueName, PeeringResourceType, "hcp_hvn.test"),
+					resource.TestCheckResourceAttrPair("data.hcp_hvn_route.route", "hvn_route_id", resourceName, "hvn_route_id"),
+					resource.TestCheckResourceAttrPair("data.hcp_hvn_route.route", "target_link", resourceName, "target_link"),
+					resource.TestCheckResourceAttrPair("data.hcp_hvn_route.route", "state", resourceName, "state"),
 				),
 			},
 		},
@@ -675,3 +688,165 @@ func testAccCheckHvnRouteDestroy(s *terraform.State) error {
 	}
 	return nil
 }
+
+func Test_resourceHvnRouteDelete_alreadyDeleted(t *testing.T) {
+	r := require.New(t)
+
+	var deleted int
+	client := &clients.Client{
+		Network: &testNetworkClient{
+			deleteHVNRoute: func(params *network_service.DeleteHVNRouteParams) (*network_service.DeleteHVNRouteOK, error) {
+				r.Equal("test-route", params.ID)
+				r.Equal("test-hvn", params.HvnID)
+				deleted++
+
+				// The HVN route was deleted outside of Terraform.
+				return nil, network_service.NewDeleteHVNRouteDefault(http.StatusNotFound)
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceHvnRoute().Schema, map[string]interface{}{
+		"hvn_link": testHvnLink("test-hvn"),
+	})
+	d.SetId(fmt.Sprintf("/project/%s/%s/test-route", testProjectID, HVNRouteResourceType))
+
+	diags := resourceHvnRouteDelete(context.Background(), d, client)
+	r.False(diags.HasError(), "unexpected diagnostics: %v", diags)
+	r.Equal(1, deleted)
+	r.Empty(d.Id())
+}
+
+func Test_resourceHvnRouteCreate_destinationOverlapsHvn(t *testing.T) {
+	r := require.New(t)
+
+	client := &clients.Client{
+		Config: clients.ClientConfig{OrganizationID: testOrganizationID, ProjectID: testProjectID},
+		Network: &testNetworkClient{
+			get: func(*network_service.GetParams) (*network_service.GetOK, error) {
+				return &network_service.GetOK{
+					Payload: &networkmodels.HashicorpCloudNetwork20200907GetResponse{
+						Network: &networkmodels.HashicorpCloudNetwork20200907Network{
+							ID:        "test-hvn",
+							CidrBlock: "172.25.16.0/20",
+							Location:  testLocation(),
+							State:     networkmodels.HashicorpCloudNetwork20200907NetworkStateSTABLE.Pointer(),
+						},
+					},
+				}, nil
+			},
+		},
+	}
+
+	// The route isn't created, since its destination is inside the HVN.
+	d := schema.TestResourceDataRaw(t, resourceHvnRoute().Schema, map[string]interface{}{
+		"hvn_link":         testHvnLink("test-hvn"),
+		"hvn_route_id":     "test-route",
+		"destination_cidr": "172.25.18.0/24",
+		"target_link":      testPeeringLink("test-peering"),
+	})
+	diags := resourceHvnRouteCreate(context.Background(), d, client)
+	r.True(diags.HasError())
+	r.Contains(diags[0].Summary, "destination_cidr 172.25.18.0/24 overlaps the HVN's CIDR block 172.25.16.0/20")
+	r.Empty(d.Id())
+}
+
+func Test_resourceHvnRoute_allowDefaultRoute(t *testing.T) {
+	tcs := map[string]struct {
+		destination       string
+		allowDefaultRoute bool
+		expectedError     string
+	}{
+		"default route": {
+			destination:   "0.0.0.0/0",
+			expectedError: "destination_cidr (0.0.0.0/0) is a default route",
+		},
+		"allowed default route": {
+			destination:       "0.0.0.0/0",
+			allowDefaultRoute: true,
+		},
+		"specific route": {
+			destination: "10.0.0.0/16",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			config := map[string]interface{}{
+				"hvn_link":            testHvnLink("test-hvn"),
+				"hvn_route_id":        "test-route",
+				"destination_cidr":    tc.destination,
+				"target_link":         testPeeringLink("test-peering"),
+				"allow_default_route": tc.allowDefaultRoute,
+			}
+
+			res := resourceHvnRoute()
+			diags := res.Validate(sdkterraform.NewResourceConfigRaw(config))
+			r.False(diags.HasError(), "%v", diags)
+
+			_, err := res.Diff(context.Background(), nil, sdkterraform.NewResourceConfigRaw(config), nil)
+			if tc.expectedError != "" {
+				r.ErrorContains(err, tc.expectedError)
+				return
+			}
+			r.NoError(err)
+		})
+	}
+}
+
+func Test_hvnRouteIDInUseDiag(t *testing.T) {
+	loc := testLocation()
+
+	tcs := map[string]struct {
+		err           error
+		routes        []string
+		expectedError string
+	}{
+		"route ID in use": {
+			err:           fmt.Errorf("unable to create HVN route: %w", network_service.NewCreateHVNRouteDefault(http.StatusConflict)),
+			routes:        []string{"other-route", "test-route"},
+			expectedError: "hvn_route_id (test-route) already in use",
+		},
+		"conflict for another reason": {
+			err:    fmt.Errorf("unable to create HVN route: %w", network_service.NewCreateHVNRouteDefault(http.StatusConflict)),
+			routes: []string{"other-route"},
+		},
+		"not a conflict": {
+			err:    fmt.Errorf("unable to create HVN route: %w", network_service.NewCreateHVNRouteDefault(http.StatusBadRequest)),
+			routes: []string{"test-route"},
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			client := &clients.Client{
+				Network: &testNetworkClient{
+					listHVNRoutes: func(params *network_service.ListHVNRoutesParams) (*network_service.ListHVNRoutesOK, error) {
+						r.Equal("test-hvn", params.HvnID)
+
+						var routes []*networkmodels.HashicorpCloudNetwork20200907HVNRoute
+						for _, id := range tc.routes {
+							routes = append(routes, &networkmodels.HashicorpCloudNetwork20200907HVNRoute{ID: id})
+						}
+						return &network_service.ListHVNRoutesOK{
+							Payload: &networkmodels.HashicorpCloudNetwork20200907ListHVNRoutesResponse{Routes: routes},
+						}, nil
+					},
+				},
+			}
+
+			diags := hvnRouteIDInUseDiag(context.Background(), client, tc.err, "test-route", "test-hvn", loc)
+			if tc.expectedError == "" {
+				r.Nil(diags)
+				return
+			}
+
+			r.True(diags.HasError())
+			r.Contains(diags[0].Summary, tc.expectedError)
+		})
+	}
+}
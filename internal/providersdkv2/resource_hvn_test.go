@@ -6,14 +6,25 @@ package providersdkv2
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
 	"testing"
 
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/client/network_service"
+	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
 	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	sdkterraform "github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/stretchr/testify/require"
 )
 
+// cidrBlockRegex matches the CIDR block allocated to an HVN when none is set.
+var cidrBlockRegex = regexp.MustCompile(`^\d{1,3}(\.\d{1,3}){3}/\d{1,2}$`)
+
 var (
 	hvnUniqueIDAws   = testAccUniqueNameWithPrefix("platform-hvn")
 	hvnUniqueIDAzure = testAccUniqueNameWithPrefix("platform-hvn")
@@ -65,11 +76,13 @@ func TestAcc_Platform_AwsHvnOnly(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "hvn_id", hvnUniqueIDAws),
 					resource.TestCheckResourceAttr(resourceName, "cloud_provider", "aws"),
 					resource.TestCheckResourceAttr(resourceName, "region", "us-west-2"),
-					resource.TestCheckResourceAttrSet(resourceName, "cidr_block"),
+					resource.TestMatchResourceAttr(resourceName, "cidr_block", cidrBlockRegex),
 					resource.TestCheckResourceAttrSet(resourceName, "organization_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "project_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
-					resource.TestCheckResourceAttrSet(resourceName, "provider_account_id"),
+					resource.TestMatchResourceAttr(resourceName, "provider_account_id", regexp.MustCompile(`^[0-9]{12}$`)),
+					resource.TestCheckResourceAttrPair(resourceName, "aws_config.0.account_id", resourceName, "provider_account_id"),
+					resource.TestCheckResourceAttr(resourceName, "azure_config.#", "0"),
 					resource.TestCheckResourceAttrSet(resourceName, "state"),
 					testLink(resourceName, "self_link", hvnUniqueIDAws, HvnResourceType, resourceName),
 				),
@@ -88,6 +101,20 @@ func TestAcc_Platform_AwsHvnOnly(t *testing.T) {
 				},
 				ImportStateVerify: true,
 			},
+			// Tests import by self_link
+			{
+				ResourceName: resourceName,
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources[resourceName]
+					if !ok {
+						return "", fmt.Errorf("not found: %s", resourceName)
+					}
+
+					return rs.Primary.Attributes["self_link"], nil
+				},
+				ImportStateVerify: true,
+			},
 			// Tests read
 			{
 				Config: testConfig(testAccAwsHvnConfig),
@@ -100,7 +127,7 @@ func TestAcc_Platform_AwsHvnOnly(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "organization_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "project_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
-					resource.TestCheckResourceAttrSet(resourceName, "provider_account_id"),
+					resource.TestMatchResourceAttr(resourceName, "provider_account_id", regexp.MustCompile(`^[0-9]{12}$`)),
 					resource.TestCheckResourceAttrSet(resourceName, "state"),
 					testLink(resourceName, "self_link", hvnUniqueIDAws, HvnResourceType, resourceName),
 				),
@@ -116,9 +143,11 @@ func TestAcc_Platform_AwsHvnOnly(t *testing.T) {
 					resource.TestCheckResourceAttrPair(resourceName, "organization_id", dataSourceName, "organization_id"),
 					resource.TestCheckResourceAttrPair(resourceName, "project_id", dataSourceName, "project_id"),
 					resource.TestCheckResourceAttrPair(resourceName, "provider_account_id", dataSourceName, "provider_account_id"),
+					resource.TestCheckResourceAttrPair(resourceName, "aws_config.0.account_id", dataSourceName, "aws_config.0.account_id"),
 					resource.TestCheckResourceAttrPair(resourceName, "created_at", dataSourceName, "created_at"),
 					resource.TestCheckResourceAttrPair(resourceName, "self_link", dataSourceName, "self_link"),
 					resource.TestCheckResourceAttrPair(resourceName, "state", dataSourceName, "state"),
+					resource.TestCheckResourceAttr(dataSourceName, "peering_count", "0"),
 				),
 			},
 		},
@@ -144,10 +173,13 @@ func TestAcc_Platform_AzureHvnOnly(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "hvn_id", hvnUniqueIDAzure),
 					resource.TestCheckResourceAttr(resourceName, "cloud_provider", "azure"),
 					resource.TestCheckResourceAttr(resourceName, "region", "eastus"),
-					resource.TestCheckResourceAttrSet(resourceName, "cidr_block"),
+					resource.TestMatchResourceAttr(resourceName, "cidr_block", cidrBlockRegex),
 					resource.TestCheckResourceAttrSet(resourceName, "organization_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "project_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
+					resource.TestCheckResourceAttr(resourceName, "provider_account_id", ""),
+					resource.TestCheckResourceAttr(resourceName, "azure_config.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "aws_config.#", "0"),
 					resource.TestCheckResourceAttrSet(resourceName, "state"),
 					testLink(resourceName, "self_link", hvnUniqueIDAzure, HvnResourceType, resourceName),
 				),
@@ -178,6 +210,7 @@ func TestAcc_Platform_AzureHvnOnly(t *testing.T) {
 					resource.TestCheckResourceAttrSet(resourceName, "organization_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "project_id"),
 					resource.TestCheckResourceAttrSet(resourceName, "created_at"),
+					resource.TestCheckResourceAttr(resourceName, "provider_account_id", ""),
 					resource.TestCheckResourceAttrSet(resourceName, "state"),
 					testLink(resourceName, "self_link", hvnUniqueIDAzure, HvnResourceType, resourceName),
 				),
@@ -298,3 +331,485 @@ func testAccCheckHvnDestroy(s *terraform.State) error {
 	}
 	return nil
 }
+
+func Test_resourceHvnCreate_autoAllocatedCIDR(t *testing.T) {
+	r := require.New(t)
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: testOrganizationID,
+		ProjectID:      testProjectID,
+		Region: &sharedmodels.HashicorpCloudLocationRegion{
+			Provider: "azure",
+			Region:   "eastus",
+		},
+	}
+
+	var created *networkmodels.HashicorpCloudNetwork20200907Network
+	client := &clients.Client{
+		Config: clients.ClientConfig{OrganizationID: loc.OrganizationID, ProjectID: loc.ProjectID},
+		Network: &testNetworkClient{
+			get: func(*network_service.GetParams) (*network_service.GetOK, error) {
+				if created == nil {
+					return nil, network_service.NewGetDefault(http.StatusNotFound)
+				}
+
+				// The service allocates a CIDR block if none was requested.
+				hvn := *created
+				hvn.CidrBlock = "172.25.16.0/20"
+				hvn.State = networkmodels.HashicorpCloudNetwork20200907NetworkStateSTABLE.Pointer()
+				return &network_service.GetOK{
+					Payload: &networkmodels.HashicorpCloudNetwork20200907GetResponse{Network: &hvn},
+				}, nil
+			},
+			create: func(params *network_service.CreateParams) (*network_service.CreateOK, error) {
+				created = params.Body.Network
+				return &network_service.CreateOK{
+					Payload: &networkmodels.HashicorpCloudNetwork20200907CreateResponse{
+						Network:   created,
+						Operation: &sharedmodels.HashicorpCloudOperationOperation{ID: "create-hvn"},
+					},
+				}, nil
+			},
+		},
+		Operation: &testOperationClient{},
+	}
+
+	config := map[string]interface{}{
+		"hvn_id":         "test-hvn",
+		"cloud_provider": "azure",
+		"region":         "eastus",
+	}
+
+	res := resourceHvn()
+	d := schema.TestResourceDataRaw(t, res.Schema, config)
+	diags := res.CreateContext(context.Background(), d, client)
+	r.False(diags.HasError(), "%v", diags)
+
+	// No CIDR block is requested, and the allocated one is read into state.
+	r.Empty(created.CidrBlock)
+	r.Equal("172.25.16.0/20", d.Get("cidr_block"))
+	r.False(validateCIDRBlockHVN(d.Get("cidr_block"), nil).HasError())
+
+	diags = res.ReadContext(context.Background(), d, client)
+	r.False(diags.HasError(), "%v", diags)
+
+	diff, err := res.Diff(context.Background(), d.State(), sdkterraform.NewResourceConfigRaw(config), client)
+	r.NoError(err)
+	r.Nil(diff)
+}
+
+func Test_resourceHvnCreate_skipWaits(t *testing.T) {
+	r := require.New(t)
+
+	loc := testLocation()
+
+	var created *networkmodels.HashicorpCloudNetwork20200907Network
+	gets := 0
+	operations := &testOperationClient{}
+	client := &clients.Client{
+		Config: clients.ClientConfig{OrganizationID: loc.OrganizationID, ProjectID: loc.ProjectID, SkipWaits: true},
+		Network: &testNetworkClient{
+			get: func(*network_service.GetParams) (*network_service.GetOK, error) {
+				if created == nil {
+					return nil, network_service.NewGetDefault(http.StatusNotFound)
+				}
+				gets++
+
+				// The HVN is still being created.
+				hvn := *created
+				hvn.State = networkmodels.HashicorpCloudNetwork20200907NetworkStateCREATING.Pointer()
+				return &network_service.GetOK{
+					Payload: &networkmodels.HashicorpCloudNetwork20200907GetResponse{Network: &hvn},
+				}, nil
+			},
+			create: func(params *network_service.CreateParams) (*network_service.CreateOK, error) {
+				created = params.Body.Network
+				return &network_service.CreateOK{
+					Payload: &networkmodels.HashicorpCloudNetwork20200907CreateResponse{
+						Network:   created,
+						Operation: &sharedmodels.HashicorpCloudOperationOperation{ID: "create-hvn"},
+					},
+				}, nil
+			},
+		},
+		Operation: operations,
+	}
+
+	res := resourceHvn()
+	d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+		"hvn_id":         "test-hvn",
+		"cloud_provider": "aws",
+		"region":         "us-west-2",
+		"cidr_block":     "172.25.16.0/20",
+	})
+	diags := res.CreateContext(context.Background(), d, client)
+	r.False(diags.HasError(), "%v", diags)
+
+	// The operation isn't waited on, and the current state of the HVN is read
+	// into state once.
+	r.Zero(operations.waits)
+	r.Equal(1, gets)
+	r.Equal("test-hvn", d.Get("hvn_id"))
+	r.Equal(string(networkmodels.HashicorpCloudNetwork20200907NetworkStateCREATING), d.Get("state"))
+}
+
+func Test_resourceHvnImport(t *testing.T) {
+	projectID := testOrganizationID
+	defaultProjectID := testProjectID
+
+	tcs := map[string]struct {
+		importID      string
+		expectedID    string
+		expectedError string
+	}{
+		"hvn ID": {
+			importID:   "test-hvn",
+			expectedID: "/project/" + defaultProjectID + "/hashicorp.network.hvn/test-hvn",
+		},
+		"project and hvn ID": {
+			importID:   projectID + ":test-hvn",
+			expectedID: "/project/" + projectID + "/hashicorp.network.hvn/test-hvn",
+		},
+		"self link": {
+			importID:   "/project/" + projectID + "/hashicorp.network.hvn/test-hvn",
+			expectedID: "/project/" + projectID + "/hashicorp.network.hvn/test-hvn",
+		},
+		"self link of another resource type": {
+			importID:      "/project/" + projectID + "/hashicorp.network.peering/test-peering",
+			expectedError: "invalid import ID",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			client := &clients.Client{Config: clients.ClientConfig{ProjectID: defaultProjectID}}
+			d := schema.TestResourceDataRaw(t, resourceHvn().Schema, map[string]interface{}{})
+			d.SetId(tc.importID)
+
+			imported, err := resourceHvnImport(context.Background(), d, client)
+			if tc.expectedError != "" {
+				r.ErrorContains(err, tc.expectedError)
+				return
+			}
+			r.NoError(err)
+			r.Len(imported, 1)
+			r.Equal(tc.expectedID, imported[0].Id())
+			r.False(imported[0].Get("deletion_protection").(bool))
+		})
+	}
+}
+
+func Test_resourceHvnDelete_alreadyDeleted(t *testing.T) {
+	r := require.New(t)
+
+	var deleted int
+	client := &clients.Client{
+		Network: &testNetworkClient{
+			delete: func(params *network_service.DeleteParams) (*network_service.DeleteOK, error) {
+				r.Equal("test-hvn", params.ID)
+				deleted++
+
+				// The HVN was deleted outside of Terraform.
+				return nil, network_service.NewDeleteDefault(http.StatusNotFound)
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceHvn().Schema, map[string]interface{}{
+		"hvn_id": "test-hvn",
+	})
+	d.SetId(testHvnLink("test-hvn"))
+
+	diags := resourceHvnDelete(context.Background(), d, client)
+	r.False(diags.HasError(), "unexpected diagnostics: %v", diags)
+	r.Equal(1, deleted)
+	r.Empty(d.Id())
+}
+
+func Test_resourceHvnCustomizeDiff_cidrBlock(t *testing.T) {
+	hvnLink := fmt.Sprintf("/project/%s/%s/test-hvn", testProjectID, HvnResourceType)
+
+	tcs := map[string]struct {
+		cidrBlock          string
+		deletionProtection bool
+		expectReplacing    bool
+		expectedError      string
+	}{
+		"unchanged": {
+			cidrBlock: "172.25.16.0/20",
+		},
+		"changed": {
+			cidrBlock:       "172.25.32.0/20",
+			expectReplacing: true,
+		},
+		"changed with deletion protection": {
+			cidrBlock:          "172.25.32.0/20",
+			deletionProtection: true,
+			expectedError:      "the CIDR block of HVN (test-hvn) can't be updated from 172.25.16.0/20 to 172.25.32.0/20: HCP doesn't support changing the CIDR block of an existing HVN, so it must be recreated, which deletion protection prevents",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			res := resourceHvn()
+			d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{
+				"hvn_id":              "test-hvn",
+				"cloud_provider":      "aws",
+				"region":              "us-west-2",
+				"cidr_block":          "172.25.16.0/20",
+				"deletion_protection": tc.deletionProtection,
+			})
+			d.SetId(hvnLink)
+
+			config := map[string]interface{}{
+				"hvn_id":              "test-hvn",
+				"cloud_provider":      "aws",
+				"region":              "us-west-2",
+				"cidr_block":          tc.cidrBlock,
+				"deletion_protection": tc.deletionProtection,
+			}
+			diff, err := res.Diff(context.Background(), d.State(), sdkterraform.NewResourceConfigRaw(config), &clients.Client{})
+			if tc.expectedError != "" {
+				r.ErrorContains(err, tc.expectedError)
+				return
+			}
+
+			r.NoError(err)
+			r.Equal(tc.expectReplacing, diff != nil && diff.RequiresNew())
+		})
+	}
+}
+
+func Test_resourceHvnCustomizeDiff_cidrBlockPrefixLength(t *testing.T) {
+	r := require.New(t)
+
+	config := map[string]interface{}{
+		"hvn_id":         "test-hvn",
+		"cloud_provider": "aws",
+		"region":         "us-west-2",
+		"cidr_block":     "172.25.16.0/28",
+	}
+
+	_, err := resourceHvn().Diff(context.Background(), nil, sdkterraform.NewResourceConfigRaw(config), &clients.Client{})
+	r.EqualError(err, "cidr_block 172.25.16.0/28 is too small for an HVN in aws: its prefix length must be between /16 and /25")
+
+	config["cidr_block"] = "172.25.16.0/20"
+	diff, err := resourceHvn().Diff(context.Background(), nil, sdkterraform.NewResourceConfigRaw(config), &clients.Client{})
+	r.NoError(err)
+	r.Equal("172.25.16.0/20", diff.Attributes["cidr_block"].New)
+}
+
+func Test_dataSourceHvnRead_peeringCount(t *testing.T) {
+	r := require.New(t)
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: testOrganizationID,
+		ProjectID:      testProjectID,
+		Region:         &sharedmodels.HashicorpCloudLocationRegion{Provider: "aws", Region: "us-west-2"},
+	}
+
+	// The peering connections are listed two per page.
+	var peerings []*networkmodels.HashicorpCloudNetwork20200907Peering
+	var lists int
+	client := &clients.Client{
+		Config: clients.ClientConfig{OrganizationID: loc.OrganizationID, ProjectID: testProjectID},
+		Network: &testNetworkClient{
+			get: func(params *network_service.GetParams) (*network_service.GetOK, error) {
+				return &network_service.GetOK{
+					Payload: &networkmodels.HashicorpCloudNetwork20200907GetResponse{
+						Network: &networkmodels.HashicorpCloudNetwork20200907Network{ID: params.ID, CidrBlock: "172.25.16.0/20", Location: loc},
+					},
+				}, nil
+			},
+			listPeerings: func(params *network_service.ListPeeringsParams) (*network_service.ListPeeringsOK, error) {
+				r.Equal("test-hvn", params.HvnID)
+				lists++
+
+				start := 0
+				if params.PaginationNextPageToken != nil {
+					start, _ = strconv.Atoi(*params.PaginationNextPageToken)
+				}
+				end := start + 2
+				payload := &networkmodels.HashicorpCloudNetwork20200907ListPeeringsResponse{}
+				if end < len(peerings) {
+					payload.Pagination = &sharedmodels.HashicorpCloudCommonPaginationResponse{NextPageToken: strconv.Itoa(end)}
+				} else {
+					end = len(peerings)
+				}
+				payload.Peerings = peerings[start:end]
+				return &network_service.ListPeeringsOK{Payload: payload}, nil
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceHvn().Schema, map[string]interface{}{
+		"hvn_id": "test-hvn",
+	})
+
+	r.Empty(dataSourceHvnRead(context.Background(), d, client))
+	r.Equal(0, d.Get("peering_count"))
+
+	// The count increments as peering connections are created.
+	for i := 1; i <= 3; i++ {
+		peerings = append(peerings, &networkmodels.HashicorpCloudNetwork20200907Peering{ID: fmt.Sprintf("peering-%d", i)})
+		lists = 0
+
+		r.Empty(dataSourceHvnRead(context.Background(), d, client))
+		r.Equal(i, d.Get("peering_count"))
+		r.Equal((i+1)/2, lists)
+	}
+}
+
+func Test_dataSourceHvnRead_peeredAccountIDs(t *testing.T) {
+	r := require.New(t)
+
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: testOrganizationID,
+		ProjectID:      testProjectID,
+		Region:         &sharedmodels.HashicorpCloudLocationRegion{Provider: "azure", Region: "eastus"},
+	}
+	azurePeering := func(id, subscriptionID string) *networkmodels.HashicorpCloudNetwork20200907Peering {
+		return &networkmodels.HashicorpCloudNetwork20200907Peering{
+			ID: id,
+			Target: &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{
+				AzureTarget: &networkmodels.HashicorpCloudNetwork20200907AzurePeeringTarget{SubscriptionID: subscriptionID},
+			},
+		}
+	}
+
+	var peerings []*networkmodels.HashicorpCloudNetwork20200907Peering
+	client := &clients.Client{
+		Config: clients.ClientConfig{OrganizationID: loc.OrganizationID, ProjectID: testProjectID},
+		Network: &testNetworkClient{
+			get: func(params *network_service.GetParams) (*network_service.GetOK, error) {
+				return &network_service.GetOK{
+					Payload: &networkmodels.HashicorpCloudNetwork20200907GetResponse{
+						Network: &networkmodels.HashicorpCloudNetwork20200907Network{ID: params.ID, CidrBlock: "172.25.16.0/20", Location: loc},
+					},
+				}, nil
+			},
+			listPeerings: func(params *network_service.ListPeeringsParams) (*network_service.ListPeeringsOK, error) {
+				return &network_service.ListPeeringsOK{
+					Payload: &networkmodels.HashicorpCloudNetwork20200907ListPeeringsResponse{Peerings: peerings},
+				}, nil
+			},
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceHvn().Schema, map[string]interface{}{
+		"hvn_id": "test-hvn",
+	})
+
+	r.Empty(dataSourceHvnRead(context.Background(), d, client))
+	r.Empty(d.Get("peered_account_ids"))
+	r.Empty(d.Get("peered_subscription_ids"))
+
+	// A created peering connection's subscription appears in the list.
+	peerings = append(peerings, azurePeering("peering-1", "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b"))
+	r.Empty(dataSourceHvnRead(context.Background(), d, client))
+	r.Equal([]interface{}{"2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b"}, d.Get("peered_subscription_ids"))
+	r.Empty(d.Get("peered_account_ids"))
+
+	// The IDs are unique and sorted, and peering connections to other HVNs are
+	// ignored.
+	peerings = append(peerings,
+		azurePeering("peering-2", "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b"),
+		azurePeering("peering-3", "0c4f1e2d-8a7b-4c3d-9e1f-2a3b4c5d6e7f"),
+		&networkmodels.HashicorpCloudNetwork20200907Peering{
+			ID: "peering-4",
+			Target: &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{
+				AwsTarget: &networkmodels.HashicorpCloudNetwork20200907AWSPeeringTarget{AccountID: "123456789012"},
+			},
+		},
+		&networkmodels.HashicorpCloudNetwork20200907Peering{
+			ID: "peering-5",
+			Target: &networkmodels.HashicorpCloudNetwork20200907PeeringTarget{
+				HvnTarget: &networkmodels.HashicorpCloudNetwork20200907NetworkTarget{},
+			},
+		},
+	)
+	r.Empty(dataSourceHvnRead(context.Background(), d, client))
+	r.Equal([]interface{}{"0c4f1e2d-8a7b-4c3d-9e1f-2a3b4c5d6e7f", "2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b"}, d.Get("peered_subscription_ids"))
+	r.Equal([]interface{}{"123456789012"}, d.Get("peered_account_ids"))
+	r.Equal(5, d.Get("peering_count"))
+}
+
+func Test_setHvnResourceData_providerConfig(t *testing.T) {
+	hvn := func(provider string, data *networkmodels.HashicorpCloudNetwork20200907NetworkProviderNetworkData) *networkmodels.HashicorpCloudNetwork20200907Network {
+		return &networkmodels.HashicorpCloudNetwork20200907Network{
+			ID:        "test-hvn",
+			CidrBlock: "172.25.16.0/20",
+			Location: &sharedmodels.HashicorpCloudLocationLocation{
+				OrganizationID: testOrganizationID,
+				ProjectID:      testProjectID,
+				Region:         &sharedmodels.HashicorpCloudLocationRegion{Provider: provider, Region: "us-west-2"},
+			},
+			ProviderNetworkData: data,
+		}
+	}
+
+	tcs := map[string]struct {
+		hvn                 *networkmodels.HashicorpCloudNetwork20200907Network
+		expectedAwsConfig   []interface{}
+		expectedAzureConfig []interface{}
+	}{
+		"aws": {
+			hvn: hvn("aws", &networkmodels.HashicorpCloudNetwork20200907NetworkProviderNetworkData{
+				AwsNetworkData: &networkmodels.HashicorpCloudNetwork20200907AWSNetworkData{AccountID: "123456789012"},
+			}),
+			expectedAwsConfig: []interface{}{map[string]interface{}{"account_id": "123456789012"}},
+		},
+		"aws without network data": {
+			hvn:               hvn("aws", nil),
+			expectedAwsConfig: []interface{}{map[string]interface{}{"account_id": ""}},
+		},
+		"azure": {
+			hvn: hvn("azure", &networkmodels.HashicorpCloudNetwork20200907NetworkProviderNetworkData{
+				AzureNetworkData: map[string]interface{}{
+					"managed_resource_group": "hcp-rg",
+					"address_spaces":         []interface{}{"172.25.16.0/20"},
+					"peerings":               float64(2),
+					"unset":                  nil,
+				},
+			}),
+			expectedAzureConfig: []interface{}{map[string]interface{}{
+				"network_data": map[string]interface{}{
+					"managed_resource_group": "hcp-rg",
+					"address_spaces":         `["172.25.16.0/20"]`,
+					"peerings":               "2",
+				},
+			}},
+		},
+		"azure without network data": {
+			hvn:                 hvn("azure", nil),
+			expectedAzureConfig: []interface{}{map[string]interface{}{"network_data": map[string]interface{}{}}},
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			for _, res := range []*schema.Resource{resourceHvn(), dataSourceHvn()} {
+				d := schema.TestResourceDataRaw(t, res.Schema, map[string]interface{}{})
+				r.NoError(setHvnResourceData(d, tc.hvn))
+
+				// Only the block of the HVN's cloud provider is populated.
+				r.Equal(tc.expectedAwsConfig, nilIfEmpty(d.Get("aws_config").([]interface{})))
+				r.Equal(tc.expectedAzureConfig, nilIfEmpty(d.Get("azure_config").([]interface{})))
+			}
+		})
+	}
+}
+
+// nilIfEmpty returns nil if list is empty, so that unset lists can be compared.
+func nilIfEmpty(list []interface{}) []interface{} {
+	if len(list) == 0 {
+		return nil
+	}
+	return list
+}
@@ -43,7 +43,7 @@ If you do not need to regenerate the HMAC key, it is recommended to use the %s d
 				Description: `
 The ID of the HCP project where the HCP Packer Registry is located. 
 If not specified, the project specified in the HCP Provider config block will be used, if configured.
-If a project is not configured in the HCP Provider config block, the oldest project in the organization will be used.`,
+If a project is not configured in the HCP Provider config block, the organization's only project will be used.`,
 				Type:         schema.TypeString,
 				Computed:     true,
 				Optional:     true,
@@ -68,7 +68,7 @@ func resourceVaultCluster() *schema.Resource {
 				Description: `
 The ID of the HCP project where the Vault cluster is located.
 If not specified, the project specified in the HCP Provider config block will be used, if configured.
-If a project is not configured in the HCP Provider config block, the oldest project in the organization will be used.`,
+If a project is not configured in the HCP Provider config block, the organization's only project will be used.`,
 				Type:         schema.TypeString,
 				Optional:     true,
 				ForceNew:     true,
@@ -595,7 +595,7 @@ func resourceVaultClusterCreate(ctx context.Context, d *schema.ResourceData, met
 	}
 
 	// Use the hvn to get provider and region.
-	hvn, err := clients.GetHvnByID(ctx, client, loc, hvnID)
+	hvn, err := clients.GetHvnByIDCached(ctx, client, loc, hvnID)
 	if err != nil {
 		return diag.Errorf("unable to find existing HVN (%s): %v", hvnID, err)
 	}
@@ -66,7 +66,7 @@ func resourceVaultPlugin() *schema.Resource {
 				Description: `
 The ID of the HCP project where the HCP Vault cluster is located. 
 If not specified, the project specified in the HCP Provider config block will be used, if configured.
-If a project is not configured in the HCP Provider config block, the oldest project in the organization will be used.`,
+If a project is not configured in the HCP Provider config block, the organization's only project will be used.`,
 				Type:         schema.TypeString,
 				Optional:     true,
 				ForceNew:     true,
@@ -0,0 +1,181 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package providersdkv2
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/client/network_service"
+	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
+	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+)
+
+// TestMain runs the sweepers instead of the tests if the -sweep flag is set,
+// e.g. with:
+//
+//	go test ./internal/providersdkv2 -v -sweep=all
+//
+// Sweepers only delete resources whose ID has the testAccNamePrefix, so that
+// they can safely run against accounts that are shared with other resources.
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+// testSweepConcurrency is the number of resources that sweepers delete at once.
+const testSweepConcurrency = 5
+
+func init() {
+	resource.AddTestSweepers("hcp_azure_peering_connection", &resource.Sweeper{
+		Name: "hcp_azure_peering_connection",
+		F:    testSweepAzurePeeringConnections,
+	})
+
+	resource.AddTestSweepers("hcp_hvn", &resource.Sweeper{
+		Name:         "hcp_hvn",
+		Dependencies: []string{"hcp_azure_peering_connection"},
+		F:            testSweepHvns,
+	})
+}
+
+// testSweepable reports whether the resource with the given ID was created by
+// an acceptance test, and can be swept.
+func testSweepable(id string) bool {
+	return strings.HasPrefix(id, testAccNamePrefix)
+}
+
+// testSweepClient returns a client configured by the environment, like the
+// provider is during acceptance tests. The region passed to sweepers is
+// ignored, since HCP resources are located by project.
+func testSweepClient() (*clients.Client, *sharedmodels.HashicorpCloudLocationLocation, error) {
+	p := New()()
+	if diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(nil)); diags.HasError() {
+		return nil, nil, fmt.Errorf("unable to configure provider: %v", diags)
+	}
+
+	client := p.Meta().(*clients.Client)
+	loc := &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: client.Config.OrganizationID,
+		ProjectID:      client.Config.ProjectID,
+	}
+	return client, loc, nil
+}
+
+// testSweepAzurePeeringConnections deletes the Azure peering connections
+// created by acceptance tests, in all HVNs of the project.
+func testSweepAzurePeeringConnections(_ string) error {
+	ctx := context.Background()
+	client, loc, err := testSweepClient()
+	if err != nil {
+		return err
+	}
+
+	hvns, err := clients.ListHvns(ctx, client, loc, clients.MaxListPageSize)
+	if err != nil {
+		return fmt.Errorf("unable to list HVNs: %v", err)
+	}
+
+	var peerings []*networkmodels.HashicorpCloudNetwork20200907Peering
+	for _, hvn := range hvns {
+		hvnPeerings, err := clients.ListPeerings(ctx, client, hvn.ID, loc)
+		if err != nil {
+			return fmt.Errorf("unable to list the peering connections of HVN (%s): %v", hvn.ID, err)
+		}
+
+		for _, peering := range hvnPeerings {
+			if peering.Target != nil && peering.Target.AzureTarget != nil && testSweepable(peering.ID) {
+				peerings = append(peerings, peering)
+			}
+		}
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(testSweepConcurrency)
+	for _, peering := range peerings {
+		peering := peering
+		g.Go(func() error {
+			log.Printf("[INFO] Sweeping peering connection (%s) of HVN (%s)", peering.ID, peering.Hvn.ID)
+
+			params := network_service.NewDeletePeeringParams()
+			params.Context = ctx
+			params.ID = peering.ID
+			params.HvnID = peering.Hvn.ID
+			params.LocationOrganizationID = loc.OrganizationID
+			params.LocationProjectID = loc.ProjectID
+			resp, err := client.Network.DeletePeering(params, nil)
+			if err != nil {
+				if clients.IsResponseCodeNotFound(err) {
+					return nil
+				}
+				return fmt.Errorf("unable to delete peering connection (%s): %v", peering.ID, err)
+			}
+
+			return clients.WaitForOperation(ctx, client, "delete peering connection", loc, resp.Payload.Operation.ID)
+		})
+	}
+
+	return g.Wait()
+}
+
+// testSweepHvns deletes the HVNs created by acceptance tests.
+func testSweepHvns(_ string) error {
+	ctx := context.Background()
+	client, loc, err := testSweepClient()
+	if err != nil {
+		return err
+	}
+
+	hvns, err := clients.ListHvns(ctx, client, loc, clients.MaxListPageSize)
+	if err != nil {
+		return fmt.Errorf("unable to list HVNs: %v", err)
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(testSweepConcurrency)
+	for _, hvn := range hvns {
+		if !testSweepable(hvn.ID) {
+			continue
+		}
+
+		hvn := hvn
+		g.Go(func() error {
+			log.Printf("[INFO] Sweeping HVN (%s)", hvn.ID)
+
+			params := network_service.NewDeleteParams()
+			params.Context = ctx
+			params.ID = hvn.ID
+			params.LocationOrganizationID = loc.OrganizationID
+			params.LocationProjectID = loc.ProjectID
+			resp, err := client.Network.Delete(params, nil)
+			if err != nil {
+				if clients.IsResponseCodeNotFound(err) {
+					return nil
+				}
+				return fmt.Errorf("unable to delete HVN (%s): %v", hvn.ID, err)
+			}
+
+			return clients.WaitForOperation(ctx, client, "delete HVN", loc, resp.Payload.Operation.ID)
+		})
+	}
+
+	return g.Wait()
+}
+
+func Test_testSweepable(t *testing.T) {
+	r := require.New(t)
+
+	r.True(testSweepable(testAccUniqueNameWithPrefix("p-az-peer-base")))
+	r.True(testSweepable(testAccUniqueNameWithPrefix("platform-hvn")))
+	r.False(testSweepable("prod-hvn"))
+	r.False(testSweepable("hvn-testacc-1"))
+}
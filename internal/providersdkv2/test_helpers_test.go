@@ -6,23 +6,65 @@ package providersdkv2
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"testing"
 	"time"
 
+	"github.com/go-openapi/runtime"
 	"github.com/google/uuid"
 
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/client/network_service"
+	networkmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-network/stable/2020-09-07/models"
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-operation/stable/2020-05-05/client/operation_service"
+	operationmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-operation/stable/2020-05-05/models"
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/client/organization_service"
+	"github.com/hashicorp/hcp-sdk-go/clients/cloud-resource-manager/stable/2019-12-10/client/project_service"
 	sharedmodels "github.com/hashicorp/hcp-sdk-go/clients/cloud-shared/v1/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-provider-hcp/internal/clients"
+	"github.com/hashicorp/terraform-provider-hcp/internal/input"
+	"github.com/stretchr/testify/require"
 )
 
+// testAccNamePrefix prefixes the names generated for acceptance tests, so
+// that the resources they leave behind can be told apart and swept.
+const testAccNamePrefix = "testacc-"
+
+// testAccMaxNameLength is the maximum length of an HCP slug, which is what most
+// generated test names end up being used as.
+const testAccMaxNameLength = 36
+
+var (
+	// testAccNameProcessID is generated once per test binary so that names
+	// generated by test runs executing concurrently (e.g. separate CI jobs)
+	// don't collide with each other.
+	testAccNameProcessID = uuid.New().String()[0:6]
+
+	// testAccNameCounter guarantees that names generated within the same test
+	// binary are unique, even across parallel tests.
+	testAccNameCounter atomic.Uint64
+
+	testAccNameInvalidChars = regexp.MustCompile(`[^-a-zA-Z\d]`)
+)
+
+// testAccUniqueNameWithPrefix returns a name that is unique across parallel
+// tests and test runs, and that is a valid HCP slug. The prefix is sanitized
+// and truncated as necessary so that the unique suffix is always preserved.
 func testAccUniqueNameWithPrefix(prefix string) string {
-	shortUUID := uuid.New().String()[0:8]
+	suffix := testAccNameProcessID + strconv.FormatUint(testAccNameCounter.Add(1), 36)
+
+	name := testAccNamePrefix + testAccNameInvalidChars.ReplaceAllString(prefix, "-")
+	if maxLen := testAccMaxNameLength - len(suffix) - 1; len(name) > maxLen {
+		name = strings.TrimRight(name[:maxLen], "-")
+	}
 
-	return fmt.Sprintf("testacc-%s-%s", prefix, shortUUID)
+	return fmt.Sprintf("%s-%s", name, suffix)
 }
 
 func testAccCheckFullURL(name, key, port string) resource.TestCheckFunc {
@@ -291,3 +333,184 @@ func testAccNewDummyProvider() *schema.Provider {
 		},
 	}
 }
+
+// testOrganizationID and testProjectID are the IDs of the organization and
+// project that the resources of the unit tests are in.
+const (
+	testOrganizationID = "f709ec73-55d4-46d8-897d-816ebba28778"
+	testProjectID      = "e20ad934-b88a-4897-a58e-d8318dd43cc3"
+)
+
+// testLocation returns the location of the resources of the unit tests.
+func testLocation() *sharedmodels.HashicorpCloudLocationLocation {
+	return &sharedmodels.HashicorpCloudLocationLocation{
+		OrganizationID: testOrganizationID,
+		ProjectID:      testProjectID,
+	}
+}
+
+// testHvnLink returns the self_link of the HVN with the given ID in the unit
+// tests' project.
+func testHvnLink(id string) string {
+	return fmt.Sprintf("/project/%s/%s/%s", testProjectID, HvnResourceType, id)
+}
+
+// testPeeringLink returns the self_link of the peering connection with the
+// given ID in the unit tests' project.
+func testPeeringLink(id string) string {
+	return fmt.Sprintf("/project/%s/%s/%s", testProjectID, PeeringResourceType, id)
+}
+
+// testPeering returns a peering connection of the test-hvn HVN with the given
+// ID, state and target.
+func testPeering(id string, state networkmodels.HashicorpCloudNetwork20200907PeeringState, target *networkmodels.HashicorpCloudNetwork20200907PeeringTarget) *networkmodels.HashicorpCloudNetwork20200907Peering {
+	return &networkmodels.HashicorpCloudNetwork20200907Peering{
+		ID:     id,
+		Hvn:    newLink(testLocation(), HvnResourceType, "test-hvn"),
+		State:  state.Pointer(),
+		Target: target,
+	}
+}
+
+// testPeeringOK returns the response of the network API to a request for the
+// peering connection.
+func testPeeringOK(peering *networkmodels.HashicorpCloudNetwork20200907Peering) *network_service.GetPeeringOK {
+	return &network_service.GetPeeringOK{
+		Payload: &networkmodels.HashicorpCloudNetwork20200907GetPeeringResponse{Peering: peering},
+	}
+}
+
+// testNetworkClient is a network_service.ClientService that allows unit tests
+// to stub out individual network API calls. Calling a method that hasn't been
+// stubbed panics, since the embedded interface is nil.
+type testNetworkClient struct {
+	network_service.ClientService
+
+	get           func(params *network_service.GetParams) (*network_service.GetOK, error)
+	create        func(params *network_service.CreateParams) (*network_service.CreateOK, error)
+	delete        func(params *network_service.DeleteParams) (*network_service.DeleteOK, error)
+	getPeering    func(params *network_service.GetPeeringParams) (*network_service.GetPeeringOK, error)
+	createPeering func(params *network_service.CreatePeeringParams) (*network_service.CreatePeeringOK, error)
+	deletePeering func(params *network_service.DeletePeeringParams) (*network_service.DeletePeeringOK, error)
+	listPeerings  func(params *network_service.ListPeeringsParams) (*network_service.ListPeeringsOK, error)
+
+	getHVNRoute    func(params *network_service.GetHVNRouteParams) (*network_service.GetHVNRouteOK, error)
+	createHVNRoute func(params *network_service.CreateHVNRouteParams) (*network_service.CreateHVNRouteOK, error)
+	deleteHVNRoute func(params *network_service.DeleteHVNRouteParams) (*network_service.DeleteHVNRouteOK, error)
+
+	// listHVNRoutes defaults to listing no routes.
+	listHVNRoutes func(params *network_service.ListHVNRoutesParams) (*network_service.ListHVNRoutesOK, error)
+}
+
+func (c *testNetworkClient) Get(params *network_service.GetParams, _ runtime.ClientAuthInfoWriter, _ ...network_service.ClientOption) (*network_service.GetOK, error) {
+	return c.get(params)
+}
+
+func (c *testNetworkClient) Create(params *network_service.CreateParams, _ runtime.ClientAuthInfoWriter, _ ...network_service.ClientOption) (*network_service.CreateOK, error) {
+	return c.create(params)
+}
+
+func (c *testNetworkClient) Delete(params *network_service.DeleteParams, _ runtime.ClientAuthInfoWriter, _ ...network_service.ClientOption) (*network_service.DeleteOK, error) {
+	return c.delete(params)
+}
+
+func (c *testNetworkClient) GetPeering(params *network_service.GetPeeringParams, _ runtime.ClientAuthInfoWriter, _ ...network_service.ClientOption) (*network_service.GetPeeringOK, error) {
+	return c.getPeering(params)
+}
+
+func (c *testNetworkClient) CreatePeering(params *network_service.CreatePeeringParams, _ runtime.ClientAuthInfoWriter, _ ...network_service.ClientOption) (*network_service.CreatePeeringOK, error) {
+	return c.createPeering(params)
+}
+
+func (c *testNetworkClient) DeletePeering(params *network_service.DeletePeeringParams, _ runtime.ClientAuthInfoWriter, _ ...network_service.ClientOption) (*network_service.DeletePeeringOK, error) {
+	return c.deletePeering(params)
+}
+
+func (c *testNetworkClient) ListPeerings(params *network_service.ListPeeringsParams, _ runtime.ClientAuthInfoWriter, _ ...network_service.ClientOption) (*network_service.ListPeeringsOK, error) {
+	return c.listPeerings(params)
+}
+
+func (c *testNetworkClient) GetHVNRoute(params *network_service.GetHVNRouteParams, _ runtime.ClientAuthInfoWriter, _ ...network_service.ClientOption) (*network_service.GetHVNRouteOK, error) {
+	return c.getHVNRoute(params)
+}
+
+func (c *testNetworkClient) CreateHVNRoute(params *network_service.CreateHVNRouteParams, _ runtime.ClientAuthInfoWriter, _ ...network_service.ClientOption) (*network_service.CreateHVNRouteOK, error) {
+	return c.createHVNRoute(params)
+}
+
+func (c *testNetworkClient) DeleteHVNRoute(params *network_service.DeleteHVNRouteParams, _ runtime.ClientAuthInfoWriter, _ ...network_service.ClientOption) (*network_service.DeleteHVNRouteOK, error) {
+	return c.deleteHVNRoute(params)
+}
+
+func (c *testNetworkClient) ListHVNRoutes(params *network_service.ListHVNRoutesParams, _ runtime.ClientAuthInfoWriter, _ ...network_service.ClientOption) (*network_service.ListHVNRoutesOK, error) {
+	if c.listHVNRoutes == nil {
+		return &network_service.ListHVNRoutesOK{Payload: &networkmodels.HashicorpCloudNetwork20200907ListHVNRoutesResponse{}}, nil
+	}
+	return c.listHVNRoutes(params)
+}
+
+// testOperationClient is an operation_service.ClientService whose operations
+// complete immediately. It counts the operations that are waited on.
+type testOperationClient struct {
+	operation_service.ClientService
+
+	waits int
+}
+
+func (c *testOperationClient) Wait(params *operation_service.WaitParams, _ runtime.ClientAuthInfoWriter, _ ...operation_service.ClientOption) (*operation_service.WaitOK, error) {
+	c.waits++
+	return &operation_service.WaitOK{
+		Payload: &operationmodels.HashicorpCloudOperationWaitResponse{
+			Operation: &sharedmodels.HashicorpCloudOperationOperation{
+				ID:    params.ID,
+				State: sharedmodels.HashicorpCloudOperationOperationStateDONE.Pointer(),
+			},
+		},
+	}, nil
+}
+
+// testOrganizationClient is an organization_service.ClientService that
+// allows unit tests to stub out the listing of the organizations the
+// credentials have access to.
+type testOrganizationClient struct {
+	organization_service.ClientService
+
+	list func(params *organization_service.OrganizationServiceListParams) (*organization_service.OrganizationServiceListOK, error)
+}
+
+func (c *testOrganizationClient) OrganizationServiceList(params *organization_service.OrganizationServiceListParams, _ runtime.ClientAuthInfoWriter, _ ...organization_service.ClientOption) (*organization_service.OrganizationServiceListOK, error) {
+	return c.list(params)
+}
+
+// testProjectClient is a project_service.ClientService that allows unit tests
+// to stub out the listing of projects.
+type testProjectClient struct {
+	project_service.ClientService
+
+	list func(params *project_service.ProjectServiceListParams) (*project_service.ProjectServiceListOK, error)
+}
+
+func (c *testProjectClient) ProjectServiceList(params *project_service.ProjectServiceListParams, _ runtime.ClientAuthInfoWriter, _ ...project_service.ClientOption) (*project_service.ProjectServiceListOK, error) {
+	return c.list(params)
+}
+
+func Test_testAccUniqueNameWithPrefix(t *testing.T) {
+	r := require.New(t)
+
+	prefixes := []string{
+		"platform-hvn",
+		"vault-hvn-aws-",
+		"p-az-peer-nva-gate",
+		"a-prefix-that-is-far-too-long-to-fit-in-a-slug",
+		"invalid_chars.in/prefix",
+	}
+
+	seen := make(map[string]struct{})
+	for i := 0; i < 5000; i++ {
+		name := testAccUniqueNameWithPrefix(prefixes[i%len(prefixes)])
+
+		r.True(input.IsSlug(name), "generated name %q is not a valid slug", name)
+		r.NotContains(seen, name, "generated name %q is not unique", name)
+		seen[name] = struct{}{}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"net/netip"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/go-openapi/strfmt"
 	"github.com/hashicorp/go-cty/cty"
@@ -22,6 +23,43 @@ import (
 )
 
 var (
+	// azureResourceGroupIDRegex matches the fully qualified Azure resource ID of
+	// a resource group, capturing the subscription ID and resource group name.
+	azureResourceGroupIDRegex = regexp.MustCompile(`(?i)^/subscriptions/([0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})/resourceGroups/([-\w.()]{0,89}[-\w()])$`)
+
+	// azureRegions are the programmatic names of the public Azure regions.
+	azureRegions = []string{
+		"australiacentral", "australiacentral2", "australiaeast", "australiasoutheast",
+		"brazilsouth", "brazilsoutheast",
+		"canadacentral", "canadaeast",
+		"centralindia", "centralus", "centraluseuap",
+		"chilecentral",
+		"eastasia", "eastus", "eastus2", "eastus2euap",
+		"francecentral", "francesouth",
+		"germanynorth", "germanywestcentral",
+		"indonesiacentral",
+		"israelcentral",
+		"italynorth",
+		"japaneast", "japanwest",
+		"jioindiacentral", "jioindiawest",
+		"koreacentral", "koreasouth",
+		"malaysiawest",
+		"mexicocentral",
+		"newzealandnorth",
+		"northcentralus", "northeurope",
+		"norwayeast", "norwaywest",
+		"polandcentral",
+		"qatarcentral",
+		"southafricanorth", "southafricawest",
+		"southcentralus", "southeastasia", "southindia",
+		"spaincentral",
+		"swedencentral", "swedensouth",
+		"switzerlandnorth", "switzerlandwest",
+		"uaecentral", "uaenorth",
+		"uksouth", "ukwest",
+		"westcentralus", "westeurope", "westindia", "westus", "westus2", "westus3",
+	}
+
 	// RFC1918Networks are networks defined as per RFC 1918 (Private Address Space)
 	RFC1918Networks = []net.IPNet{
 		{
@@ -380,11 +418,106 @@ func validateCIDRBlockHVN(v interface{}, path cty.Path) diag.Diagnostics {
 	return validateCIDRBlock(v, path, RFC1918Networks)
 }
 
+// hvnCidrBlockPrefixLengths are the shortest and longest prefix lengths that
+// HCP allows for the CIDR block of an HVN, by cloud provider.
+var hvnCidrBlockPrefixLengths = map[string][2]int{
+	"aws":   {16, 25},
+	"azure": {16, 24},
+}
+
+// validateHvnCidrBlockPrefixLength returns an error if the prefix length of
+// cidrBlock isn't allowed for an HVN in cloudProvider. Blocks that can't be
+// parsed are left to validateCIDRBlockHVN.
+func validateHvnCidrBlockPrefixLength(cidrBlock, cloudProvider string) error {
+	_, network, err := net.ParseCIDR(cidrBlock)
+	if err != nil {
+		return nil
+	}
+
+	limits, ok := hvnCidrBlockPrefixLengths[strings.ToLower(cloudProvider)]
+	if !ok {
+		return nil
+	}
+
+	prefixLength, _ := network.Mask.Size()
+	if prefixLength < limits[0] || prefixLength > limits[1] {
+		size := "small"
+		if prefixLength < limits[0] {
+			size = "large"
+		}
+		return fmt.Errorf("cidr_block %s is too %s for an HVN in %s: its prefix length must be between /%d and /%d",
+			cidrBlock, size, strings.ToLower(cloudProvider), limits[0], limits[1])
+	}
+
+	return nil
+}
+
+// validateHvnRouteDestination returns an error if the destination CIDR of an
+// HVN route overlaps the CIDR block of its HVN, since traffic to the HVN's own
+// addresses can't be routed out of it. CIDRs that can't be parsed are left to
+// validateCIDRBlockHVNRoute.
+func validateHvnRouteDestination(destination, hvnCidrBlock string) error {
+	_, destinationNetwork, err := net.ParseCIDR(destination)
+	if err != nil {
+		return nil
+	}
+	// A default route contains every CIDR block, but traffic to the HVN
+	// itself is still matched by the HVN's more specific local route.
+	if isDefaultRouteCIDR(destination) {
+		return nil
+	}
+	_, hvnNetwork, err := net.ParseCIDR(hvnCidrBlock)
+	if err != nil {
+		return nil
+	}
+
+	if cidrBlocksOverlap(destinationNetwork, hvnNetwork) {
+		return fmt.Errorf("destination_cidr %s overlaps the HVN's CIDR block %s: the destination must be outside of the HVN", destination, hvnCidrBlock)
+	}
+
+	return nil
+}
+
+// cidrBlocksOverlap returns whether the networks a and b have any addresses in
+// common. CIDR blocks either nest or are disjoint, so they overlap if and only
+// if one of them contains the first address of the other.
+func cidrBlocksOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
 func validateCIDRBlockHVNRoute(v interface{}, path cty.Path) diag.Diagnostics {
 	// HVN Routes allow RFC 1918 and RFC 6598 Network CIDRs
 	return validateCIDRBlock(v, path, append(RFC1918Networks, RFC6598Networks...))
 }
 
+// defaultRouteCIDR is the destination CIDR of an IPv4 default route.
+const defaultRouteCIDR = "0.0.0.0/0"
+
+// validateHvnRouteDestinationCIDR validates the destination_cidr of an HVN
+// route. It accepts the default route on top of validateCIDRBlockHVNRoute's
+// ranges, since whether a default route is allowed depends on
+// allow_default_route, which is checked by the resource's CustomizeDiff.
+func validateHvnRouteDestinationCIDR(v interface{}, path cty.Path) diag.Diagnostics {
+	if v.(string) == defaultRouteCIDR {
+		return nil
+	}
+
+	return validateCIDRBlockHVNRoute(v, path)
+}
+
+// isDefaultRouteCIDR returns true if cidr is an IPv4 CIDR block with a /0
+// prefix, i.e. a default route that matches all the traffic that no more
+// specific route matches.
+func isDefaultRouteCIDR(cidr string) bool {
+	ip, network, err := net.ParseCIDR(cidr)
+	if err != nil || ip.To4() == nil {
+		return false
+	}
+
+	ones, _ := network.Mask.Size()
+	return ones == 0
+}
+
 func validateCIDRBlock(v interface{}, path cty.Path, networks []net.IPNet) diag.Diagnostics {
 	var diagnostics diag.Diagnostics
 
@@ -501,3 +634,61 @@ func validateVaultPluginType(v interface{}, path cty.Path) diag.Diagnostics {
 
 	return diagnostics
 }
+
+// validateAzureResourceGroupID validates that the string value is the fully
+// qualified Azure resource ID of a resource group.
+func validateAzureResourceGroupID(v interface{}, path cty.Path) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	if !azureResourceGroupIDRegex.MatchString(v.(string)) {
+		msg := "must be a resource group ID in the form /subscriptions/{subscription_id}/resourceGroups/{resource_group_name}"
+		diagnostics = append(diagnostics, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       msg,
+			Detail:        msg,
+			AttributePath: path,
+		})
+	}
+
+	return diagnostics
+}
+
+// validateDuration validates that the string value is a positive duration.
+func validateDuration(v interface{}, path cty.Path) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	if d, err := time.ParseDuration(v.(string)); err != nil || d <= 0 {
+		msg := "must be a positive duration, such as \"45s\" or \"2m\""
+		diagnostics = append(diagnostics, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       msg,
+			Detail:        msg,
+			AttributePath: path,
+		})
+	}
+
+	return diagnostics
+}
+
+// validateAzureRegion warns if the string value isn't the programmatic or
+// display name of a known Azure region, eg. `westus` or `West US`. New regions
+// are added regularly, so unknown regions are still accepted.
+func validateAzureRegion(v interface{}, path cty.Path) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	for _, region := range azureRegions {
+		if azureRegionsEqual(v.(string), region) {
+			return diagnostics
+		}
+	}
+
+	msg := fmt.Sprintf("%q is not a known Azure region", v.(string))
+	diagnostics = append(diagnostics, diag.Diagnostic{
+		Severity:      diag.Warning,
+		Summary:       msg,
+		Detail:        fmt.Sprintf("The region may be newer than this version of the provider, in which case this warning can be ignored. Known regions: %s", strings.Join(azureRegions, ", ")),
+		AttributePath: path,
+	})
+
+	return diagnostics
+}
@@ -1073,3 +1073,251 @@ func Test_validateCIDRBlockHVNRoute(t *testing.T) {
 		}
 	})
 }
+
+func Test_validateAzureResourceGroupID(t *testing.T) {
+	invalidDiags := diag.Diagnostics{
+		diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "must be a resource group ID in the form /subscriptions/{subscription_id}/resourceGroups/{resource_group_name}",
+			Detail:        "must be a resource group ID in the form /subscriptions/{subscription_id}/resourceGroups/{resource_group_name}",
+			AttributePath: nil,
+		},
+	}
+
+	tcs := map[string]struct {
+		input    string
+		expected diag.Diagnostics
+	}{
+		"valid id": {
+			input:    "/subscriptions/2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b/resourceGroups/my-rg",
+			expected: nil,
+		},
+		"valid id with different casing": {
+			input:    "/Subscriptions/2B6E8A5C-5E2D-4B1E-9F3A-7D2C1E0F4A6B/resourcegroups/My_RG.(1)",
+			expected: nil,
+		},
+		"resource group name only": {
+			input:    "my-rg",
+			expected: invalidDiags,
+		},
+		"invalid subscription id": {
+			input:    "/subscriptions/not-a-subscription/resourceGroups/my-rg",
+			expected: invalidDiags,
+		},
+		"vnet id": {
+			input:    "/subscriptions/2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet",
+			expected: invalidDiags,
+		},
+		"resource group name ending in a period": {
+			input:    "/subscriptions/2b6e8a5c-5e2d-4b1e-9f3a-7d2c1e0f4a6b/resourceGroups/my-rg.",
+			expected: invalidDiags,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			result := validateAzureResourceGroupID(tc.input, nil)
+			r.Equal(tc.expected, result)
+		})
+	}
+}
+
+func Test_validateAzureRegion(t *testing.T) {
+	tcs := map[string]struct {
+		input         string
+		expectWarning bool
+	}{
+		"programmatic name": {
+			input: "westus2",
+		},
+		"display name": {
+			input: "West US 2",
+		},
+		"aws region": {
+			input:         "us-west-2",
+			expectWarning: true,
+		},
+		"unknown region": {
+			input:         "westus9",
+			expectWarning: true,
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			result := validateAzureRegion(tc.input, nil)
+			if !tc.expectWarning {
+				r.Nil(result)
+				return
+			}
+
+			r.Len(result, 1)
+			r.Equal(diag.Warning, result[0].Severity)
+			r.Equal(fmt.Sprintf("%q is not a known Azure region", tc.input), result[0].Summary)
+			r.Contains(result[0].Detail, "eastus, eastus2")
+		})
+	}
+}
+
+func Test_validateHvnCidrBlockPrefixLength(t *testing.T) {
+	tcs := map[string]struct {
+		cidrBlock     string
+		cloudProvider string
+		expectedError string
+	}{
+		"aws shortest": {
+			cidrBlock:     "10.0.0.0/16",
+			cloudProvider: "aws",
+		},
+		"aws longest": {
+			cidrBlock:     "172.25.16.0/25",
+			cloudProvider: "aws",
+		},
+		"aws too large": {
+			cidrBlock:     "10.0.0.0/15",
+			cloudProvider: "aws",
+			expectedError: "cidr_block 10.0.0.0/15 is too large for an HVN in aws: its prefix length must be between /16 and /25",
+		},
+		"aws too small": {
+			cidrBlock:     "172.25.16.0/28",
+			cloudProvider: "aws",
+			expectedError: "cidr_block 172.25.16.0/28 is too small for an HVN in aws: its prefix length must be between /16 and /25",
+		},
+		"azure longest": {
+			cidrBlock:     "192.168.1.0/24",
+			cloudProvider: "azure",
+		},
+		"azure too small": {
+			cidrBlock:     "192.168.1.0/25",
+			cloudProvider: "Azure",
+			expectedError: "cidr_block 192.168.1.0/25 is too small for an HVN in azure: its prefix length must be between /16 and /24",
+		},
+		"unknown cloud provider": {
+			cidrBlock:     "172.25.16.0/28",
+			cloudProvider: "gcp",
+		},
+		"invalid CIDR block": {
+			cidrBlock:     "172.25.16.0",
+			cloudProvider: "aws",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			err := validateHvnCidrBlockPrefixLength(tc.cidrBlock, tc.cloudProvider)
+			if tc.expectedError != "" {
+				r.EqualError(err, tc.expectedError)
+				return
+			}
+			r.NoError(err)
+		})
+	}
+}
+
+func Test_validateHvnRouteDestination(t *testing.T) {
+	tcs := map[string]struct {
+		destination   string
+		hvnCidrBlock  string
+		expectedError string
+	}{
+		"disjoint": {
+			destination:  "10.0.0.0/16",
+			hvnCidrBlock: "172.25.16.0/20",
+		},
+		"adjacent": {
+			destination:  "172.25.32.0/20",
+			hvnCidrBlock: "172.25.16.0/20",
+		},
+		"equal": {
+			destination:   "172.25.16.0/20",
+			hvnCidrBlock:  "172.25.16.0/20",
+			expectedError: "destination_cidr 172.25.16.0/20 overlaps the HVN's CIDR block 172.25.16.0/20",
+		},
+		"inside the HVN": {
+			destination:   "172.25.18.0/24",
+			hvnCidrBlock:  "172.25.16.0/20",
+			expectedError: "destination_cidr 172.25.18.0/24 overlaps the HVN's CIDR block 172.25.16.0/20",
+		},
+		"containing the HVN": {
+			destination:   "172.16.0.0/12",
+			hvnCidrBlock:  "172.25.16.0/20",
+			expectedError: "destination_cidr 172.16.0.0/12 overlaps the HVN's CIDR block 172.25.16.0/20",
+		},
+		"host bits set": {
+			destination:   "172.25.17.5/24",
+			hvnCidrBlock:  "172.25.16.0/20",
+			expectedError: "destination_cidr 172.25.17.5/24 overlaps the HVN's CIDR block 172.25.16.0/20",
+		},
+		"invalid destination": {
+			destination:  "172.25.16.0",
+			hvnCidrBlock: "172.25.16.0/20",
+		},
+		"unknown HVN CIDR block": {
+			destination:  "172.25.16.0/20",
+			hvnCidrBlock: "",
+		},
+		"default route": {
+			destination:  "0.0.0.0/0",
+			hvnCidrBlock: "172.25.16.0/20",
+		},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			r := require.New(t)
+
+			err := validateHvnRouteDestination(tc.destination, tc.hvnCidrBlock)
+			if tc.expectedError != "" {
+				r.ErrorContains(err, tc.expectedError)
+				return
+			}
+			r.NoError(err)
+		})
+	}
+}
+
+func Test_isDefaultRouteCIDR(t *testing.T) {
+	tcs := map[string]bool{
+		"0.0.0.0/0":      true,
+		"10.0.0.0/0":     true,
+		"0.0.0.0/1":      false,
+		"10.0.0.0/8":     false,
+		"172.25.16.0/20": false,
+		"::/0":           false,
+		"0.0.0.0":        false,
+		"":               false,
+	}
+
+	for cidr, expected := range tcs {
+		t.Run(cidr, func(t *testing.T) {
+			require.Equal(t, expected, isDefaultRouteCIDR(cidr))
+		})
+	}
+}
+
+func Test_validateHvnRouteDestinationCIDR(t *testing.T) {
+	tcs := map[string]struct {
+		cidr          string
+		expectedError bool
+	}{
+		"private":                  {cidr: "10.0.0.0/16"},
+		"default route":            {cidr: "0.0.0.0/0"},
+		"non-canonical /0":         {cidr: "10.0.0.0/0", expectedError: true},
+		"public":                   {cidr: "8.8.8.0/24", expectedError: true},
+		"shorter than private /8s": {cidr: "0.0.0.0/1", expectedError: true},
+		"not a CIDR block":         {cidr: "0.0.0.0", expectedError: true},
+	}
+
+	for n, tc := range tcs {
+		t.Run(n, func(t *testing.T) {
+			diags := validateHvnRouteDestinationCIDR(tc.cidr, nil)
+			require.Equal(t, tc.expectedError, diags.HasError(), "%v", diags)
+		})
+	}
+}